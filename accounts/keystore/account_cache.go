@@ -101,6 +101,17 @@ func (ac *accountCache) hasAddress(addr common.Address) bool {
 	return len(ac.byAddr[addr]) > 0
 }
 
+// accountByAddress returns the first account stored for the given address.
+func (ac *accountCache) accountByAddress(addr common.Address) (accounts.Account, bool) {
+	ac.maybeReload()
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	if matches := ac.byAddr[addr]; len(matches) > 0 {
+		return matches[0], true
+	}
+	return accounts.Account{}, false
+}
+
 func (ac *accountCache) add(newAccount accounts.Account) {
 	ac.mu.Lock()
 	defer ac.mu.Unlock()
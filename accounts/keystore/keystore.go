@@ -44,7 +44,7 @@ var (
 	ErrDecrypt = errors.New("could not decrypt key with given password")
 
 	// ErrAccountAlreadyExists is returned if an account attempted to import is
-	// already present in the keystore.
+	// already present in the keystore, along with the existing account.
 	ErrAccountAlreadyExists = errors.New("account already exists")
 )
 
@@ -451,10 +451,8 @@ func (ks *KeyStore) Import(keyJSON []byte, passphrase, newPassphrase string) (ac
 	ks.importMu.Lock()
 	defer ks.importMu.Unlock()
 
-	if ks.cache.hasAddress(key.Address) {
-		return accounts.Account{
-			Address: key.Address,
-		}, ErrAccountAlreadyExists
+	if existing, ok := ks.cache.accountByAddress(key.Address); ok {
+		return existing, ErrAccountAlreadyExists
 	}
 	return ks.importKey(key, newPassphrase)
 }
@@ -465,14 +463,21 @@ func (ks *KeyStore) ImportDilithium(d *dilithium.Dilithium, passphrase string) (
 	defer ks.importMu.Unlock()
 
 	key := newKeyFromDilithium(d)
-	if ks.cache.hasAddress(key.Address) {
-		return accounts.Account{
-			Address: key.Address,
-		}, ErrAccountAlreadyExists
+	if existing, ok := ks.cache.accountByAddress(key.Address); ok {
+		return existing, ErrAccountAlreadyExists
 	}
 	return ks.importKey(key, passphrase)
 }
 
+// ForceImportDilithium stores the given key into a new file in the key directory,
+// encrypting it with the passphrase, even if the account is already present.
+func (ks *KeyStore) ForceImportDilithium(d *dilithium.Dilithium, passphrase string) (accounts.Account, error) {
+	ks.importMu.Lock()
+	defer ks.importMu.Unlock()
+
+	return ks.importKey(newKeyFromDilithium(d), passphrase)
+}
+
 func (ks *KeyStore) importKey(key *Key, passphrase string) (accounts.Account, error) {
 	a := accounts.Account{Address: key.Address, URL: accounts.URL{Scheme: KeyStoreScheme, Path: ks.storage.JoinPath(keyFileName(key.Address))}}
 	if err := ks.storage.StoreKey(a.URL.Path, key, passphrase); err != nil {
@@ -346,14 +346,26 @@ func TestImportECDSA(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to generate key: %v", key)
 	}
-	if _, err = ks.ImportDilithium(key, "old"); err != nil {
+	acc, err := ks.ImportDilithium(key, "old")
+	if err != nil {
 		t.Errorf("importing failed: %v", err)
 	}
 	if _, err = ks.ImportDilithium(key, "old"); err == nil {
 		t.Errorf("importing same key twice succeeded")
 	}
-	if _, err = ks.ImportDilithium(key, "new"); err == nil {
-		t.Errorf("importing same key twice succeeded")
+	existing, err := ks.ImportDilithium(key, "new")
+	if err != ErrAccountAlreadyExists {
+		t.Errorf("importing same key twice: have %v, want %v", err, ErrAccountAlreadyExists)
+	}
+	if existing != acc {
+		t.Errorf("existing account mismatch: have %v, want %v", existing, acc)
+	}
+	forced, err := ks.ForceImportDilithium(key, "new")
+	if err != nil {
+		t.Errorf("forced import failed: %v", err)
+	}
+	if forced.Address != acc.Address || forced.URL == acc.URL {
+		t.Errorf("forced import not stored in a new file: have %v, existing %v", forced, acc)
 	}
 }
 
@@ -153,19 +153,44 @@ func decodeTransactions(enc [][]byte) ([]*types.Transaction, error) {
 // Withdrawals value will propagate through the returned block. Empty
 // Withdrawals value must be passed via non-nil, length 0 value in params.
 func ExecutableDataToBlock(params ExecutableData) (*types.Block, error) {
-	txs, err := decodeTransactions(params.Transactions)
+	header, txs, err := executableDataToHeader(params)
 	if err != nil {
 		return nil, err
 	}
+	block := types.NewBlockWithHeader(header).WithBody(txs).WithWithdrawals(params.Withdrawals)
+	if block.Hash() != params.BlockHash {
+		return nil, fmt.Errorf("blockhash mismatch, want %x, got %x", params.BlockHash, block.Hash())
+	}
+	return block, nil
+}
+
+// ComputeBlockHash assembles the block header described by the executable data
+// and returns its hash. The BlockHash field of the parameters is ignored, which
+// allows callers to validate or fill it in without constructing the full block.
+func ComputeBlockHash(params ExecutableData) (common.Hash, error) {
+	header, _, err := executableDataToHeader(params)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return header.Hash(), nil
+}
+
+// executableDataToHeader validates the executable data and constructs the block
+// header and transaction list it describes.
+func executableDataToHeader(params ExecutableData) (*types.Header, []*types.Transaction, error) {
+	txs, err := decodeTransactions(params.Transactions)
+	if err != nil {
+		return nil, nil, err
+	}
 	if len(params.ExtraData) > 32 {
-		return nil, fmt.Errorf("invalid extradata length: %v", len(params.ExtraData))
+		return nil, nil, fmt.Errorf("invalid extradata length: %v", len(params.ExtraData))
 	}
 	if len(params.LogsBloom) != 256 {
-		return nil, fmt.Errorf("invalid logsBloom length: %v", len(params.LogsBloom))
+		return nil, nil, fmt.Errorf("invalid logsBloom length: %v", len(params.LogsBloom))
 	}
 	// Check that baseFeePerGas is not negative or too big
 	if params.BaseFeePerGas != nil && (params.BaseFeePerGas.Sign() == -1 || params.BaseFeePerGas.BitLen() > 256) {
-		return nil, fmt.Errorf("invalid baseFeePerGas: %v", params.BaseFeePerGas)
+		return nil, nil, fmt.Errorf("invalid baseFeePerGas: %v", params.BaseFeePerGas)
 	}
 	// Only set withdrawalsRoot if it is non-nil. This allows CLs to use
 	// ExecutableData before withdrawals are enabled by marshaling
@@ -191,11 +216,7 @@ func ExecutableDataToBlock(params ExecutableData) (*types.Block, error) {
 		Random:          params.Random,
 		WithdrawalsHash: withdrawalsRoot,
 	}
-	block := types.NewBlockWithHeader(header).WithBody(txs).WithWithdrawals(params.Withdrawals)
-	if block.Hash() != params.BlockHash {
-		return nil, fmt.Errorf("blockhash mismatch, want %x, got %x", params.BlockHash, block.Hash())
-	}
-	return block, nil
+	return header, txs, nil
 }
 
 // BlockToExecutableData constructs the ExecutableData structure by filling the
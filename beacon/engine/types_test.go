@@ -0,0 +1,72 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package engine
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/types"
+)
+
+// Tests that ComputeBlockHash yields the same hash as the block assembled by
+// ExecutableDataToBlock, with and without withdrawals.
+func TestComputeBlockHash(t *testing.T) {
+	header := &types.Header{
+		ParentHash: common.Hash{0x01},
+		Coinbase:   common.Address{0x02},
+		Root:       common.Hash{0x03},
+		Number:     big.NewInt(10),
+		GasLimit:   30_000_000,
+		GasUsed:    21_000,
+		Time:       1700000000,
+		BaseFee:    big.NewInt(7),
+		Extra:      []byte("zond"),
+		Random:     common.Hash{0x04},
+	}
+	withdrawals := []*types.Withdrawal{{Index: 1, Validator: 2, Address: common.Address{0x05}, Amount: 100}}
+
+	for i, ws := range [][]*types.Withdrawal{nil, {}, withdrawals} {
+		data := *BlockToExecutableData(types.NewBlockWithHeader(header).WithWithdrawals(ws), nil).ExecutionPayload
+		data.Withdrawals = ws
+
+		// Modify the payload so the original block hash no longer applies
+		data.GasUsed++
+		hash, err := ComputeBlockHash(data)
+		if err != nil {
+			t.Fatalf("test %d: failed to compute block hash: %v", i, err)
+		}
+		if _, err := ExecutableDataToBlock(data); err == nil {
+			t.Fatalf("test %d: stale block hash accepted", i)
+		}
+		data.BlockHash = hash
+		block, err := ExecutableDataToBlock(data)
+		if err != nil {
+			t.Fatalf("test %d: failed to convert executable data: %v", i, err)
+		}
+		if block.Hash() != hash {
+			t.Fatalf("test %d: block hash mismatch: have %x, want %x", i, hash, block.Hash())
+		}
+	}
+	// Invalid payloads should be rejected the same way as in ExecutableDataToBlock
+	data := *BlockToExecutableData(types.NewBlockWithHeader(header), nil).ExecutionPayload
+	data.ExtraData = make([]byte, 33)
+	if _, err := ComputeBlockHash(data); err == nil {
+		t.Fatal("oversized extra data accepted")
+	}
+}
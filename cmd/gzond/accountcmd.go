@@ -17,8 +17,11 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/theQRL/go-qrllib/dilithium"
 	"github.com/theQRL/go-zond/accounts"
 	"github.com/theQRL/go-zond/accounts/keystore"
 	"github.com/theQRL/go-zond/cmd/utils"
@@ -28,6 +31,16 @@ import (
 )
 
 var (
+	importForceFlag = &cli.BoolFlag{
+		Name:  "force",
+		Usage: "Import the key into a new file even if the account already exists",
+	}
+	importFormatFlag = &cli.StringFlag{
+		Name:  "format",
+		Usage: "Format of the private key in the key file (hex, mnemonic)",
+		Value: "hex",
+	}
+
 	accountCommand = &cli.Command{
 		Name:  "account",
 		Usage: "Manage accounts",
@@ -126,6 +139,8 @@ changing your password is only possible interactively.
 					utils.KeyStoreDirFlag,
 					utils.PasswordFileFlag,
 					utils.LightKDFFlag,
+					importForceFlag,
+					importFormatFlag,
 				},
 				ArgsUsage: "<keyFile>",
 				Description: `
@@ -135,6 +150,8 @@ Imports an unencrypted private key from <keyfile> and creates a new account.
 Prints the address.
 
 The keyfile is assumed to contain an unencrypted private key in hexadecimal format.
+With --format mnemonic, the keyfile is expected to contain the mnemonic of the
+seed instead.
 
 The account is saved in encrypted format, you are prompted for a password.
 
@@ -144,6 +161,10 @@ For non-interactive use the password can be specified with the -password flag:
 
     gzond account import [options] <keyfile>
 
+If the account is already present in the keystore, the import is refused and
+the path of the existing key file is printed. Use --force to store the key in
+a new file regardless.
+
 Note:
 As you can directly copy your encrypted accounts to another zond instance,
 this import mechanism is not needed when you transfer an account between
@@ -185,8 +206,9 @@ func accountList(ctx *cli.Context) error {
 	return nil
 }
 
-// tries unlocking the specified account a few times.
-func unlockAccount(ks *keystore.KeyStore, address string, i int, passwords []string) (accounts.Account, string) {
+// tries unlocking the specified account a few times. If timeout is non-zero, the
+// account is locked again once it elapses.
+func unlockAccount(ks *keystore.KeyStore, address string, i int, passwords []string, timeout time.Duration) (accounts.Account, string) {
 	account, err := utils.MakeAddress(ks, address)
 	if err != nil {
 		utils.Fatalf("Could not list accounts: %v", err)
@@ -194,14 +216,14 @@ func unlockAccount(ks *keystore.KeyStore, address string, i int, passwords []str
 	for trials := 0; trials < 3; trials++ {
 		prompt := fmt.Sprintf("Unlocking account %s | Attempt %d/%d", address, trials+1, 3)
 		password := utils.GetPassPhraseWithList(prompt, false, i, passwords)
-		err = ks.Unlock(account, password)
+		err = ks.TimedUnlock(account, password, timeout)
 		if err == nil {
 			log.Info("Unlocked account", "address", account.Address.Hex())
 			return account, password
 		}
 		if err, ok := err.(*keystore.AmbiguousAddrError); ok {
 			log.Info("Unlocked account", "address", account.Address.Hex())
-			return ambiguousAddrRecovery(ks, err, password), password
+			return ambiguousAddrRecovery(ks, err, password, timeout), password
 		}
 		if err != keystore.ErrDecrypt {
 			// No need to prompt again if the error is not decryption-related.
@@ -214,7 +236,7 @@ func unlockAccount(ks *keystore.KeyStore, address string, i int, passwords []str
 	return accounts.Account{}, ""
 }
 
-func ambiguousAddrRecovery(ks *keystore.KeyStore, err *keystore.AmbiguousAddrError, auth string) accounts.Account {
+func ambiguousAddrRecovery(ks *keystore.KeyStore, err *keystore.AmbiguousAddrError, auth string, timeout time.Duration) accounts.Account {
 	fmt.Printf("Multiple key files exist for address %x:\n", err.Addr)
 	for _, a := range err.Matches {
 		fmt.Println("  ", a.URL)
@@ -222,7 +244,7 @@ func ambiguousAddrRecovery(ks *keystore.KeyStore, err *keystore.AmbiguousAddrErr
 	fmt.Println("Testing your password against all of them...")
 	var match *accounts.Account
 	for i, a := range err.Matches {
-		if e := ks.Unlock(a, auth); e == nil {
+		if e := ks.TimedUnlock(a, auth, timeout); e == nil {
 			match = &err.Matches[i]
 			break
 		}
@@ -289,7 +311,7 @@ func accountUpdate(ctx *cli.Context) error {
 	ks := backends[0].(*keystore.KeyStore)
 
 	for _, addr := range ctx.Args().Slice() {
-		account, oldPassword := unlockAccount(ks, addr, 0, nil)
+		account, oldPassword := unlockAccount(ks, addr, 0, nil, 0)
 		newPassword := utils.GetPassPhraseWithList("Please give a new password. Do not forget this password.", true, 0, nil)
 		if err := ks.Update(account, oldPassword, newPassword); err != nil {
 			utils.Fatalf("Could not update the account: %v", err)
@@ -302,8 +324,19 @@ func accountImport(ctx *cli.Context) error {
 	if ctx.Args().Len() != 1 {
 		utils.Fatalf("keyfile must be given as the only argument")
 	}
-	keyfile := ctx.Args().First()
-	key, err := pqcrypto.LoadDilithium(keyfile)
+	var (
+		keyfile = ctx.Args().First()
+		key     *dilithium.Dilithium
+		err     error
+	)
+	switch format := ctx.String(importFormatFlag.Name); format {
+	case "hex":
+		key, err = pqcrypto.LoadDilithium(keyfile)
+	case "mnemonic":
+		key, err = pqcrypto.LoadDilithiumMnemonic(keyfile)
+	default:
+		utils.Fatalf("Unknown key file format %q, want hex or mnemonic", format)
+	}
 	if err != nil {
 		utils.Fatalf("Failed to load the private key: %v", err)
 	}
@@ -315,7 +348,15 @@ func accountImport(ctx *cli.Context) error {
 	ks := backends[0].(*keystore.KeyStore)
 	passphrase := utils.GetPassPhraseWithList("Your new account is locked with a password. Please give a password. Do not forget this password.", true, 0, utils.MakePasswordList(ctx))
 
-	acct, err := ks.ImportDilithium(key, passphrase)
+	var acct accounts.Account
+	if ctx.Bool(importForceFlag.Name) {
+		acct, err = ks.ForceImportDilithium(key, passphrase)
+	} else {
+		acct, err = ks.ImportDilithium(key, passphrase)
+	}
+	if errors.Is(err, keystore.ErrAccountAlreadyExists) {
+		utils.Fatalf("Account {%x} already exists at %s, use --%s to import it again", acct.Address, acct.URL.Path, importForceFlag.Name)
+	}
 	if err != nil {
 		utils.Fatalf("Could not create the account: %v", err)
 	}
@@ -22,8 +22,10 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/cespare/cp"
+	"github.com/theQRL/go-zond/accounts/keystore"
 )
 
 // These tests are 'smoke tests' for the account related
@@ -117,6 +119,60 @@ func TestAccountImport(t *testing.T) {
 	}
 }
 
+func TestAccountImportDuplicate(t *testing.T) {
+	var (
+		dir          = t.TempDir()
+		datadir      = filepath.Join(dir, "data")
+		keyfile      = filepath.Join(dir, "key.prv")
+		passwordFile = filepath.Join(dir, "password.txt")
+	)
+	if err := os.WriteFile(keyfile, []byte("0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(passwordFile, []byte("foobar"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	importKey := func(args ...string) *testgzond {
+		return runGzond(t, append([]string{"--lightkdf", "account", "import", "--datadir", datadir, "-password", passwordFile}, append(args, keyfile)...)...)
+	}
+	gzond := importKey()
+	gzond.Expect("Address: {fcad0b19bb29d4674531d6f115237e16afce377c}\n")
+	gzond.ExpectExit()
+
+	// A second import is refused, pointing to the existing key file
+	gzond = importKey()
+	gzond.ExpectRegexp(`Fatal: Account \{fcad0b19bb29d4674531d6f115237e16afce377c\} already exists at .*UTC--.+--fcad0b19bb29d4674531d6f115237e16afce377c, use --force to import it again\n`)
+	gzond.ExpectExit()
+
+	// Forcing the import stores the key in a new file
+	gzond = importKey("--force")
+	gzond.Expect("Address: {fcad0b19bb29d4674531d6f115237e16afce377c}\n")
+	gzond.ExpectExit()
+
+	files, err := os.ReadDir(filepath.Join(datadir, "keystore"))
+	if len(files) != 2 {
+		t.Errorf("expected two key files in keystore directory, found %d files (error: %v)", len(files), err)
+	}
+}
+
+func TestAccountImportMnemonic(t *testing.T) {
+	var (
+		dir          = t.TempDir()
+		keyfile      = filepath.Join(dir, "key.mnemonic")
+		passwordFile = filepath.Join(dir, "password.txt")
+		mnemonic     = "aback bag adrift dream all innate answer peach ask spare awash absurd barren coup below grill bless mummy bother secret broken verbal butter cain carbon flew chaos loudly circus rector coast thief\n"
+	)
+	if err := os.WriteFile(keyfile, []byte(mnemonic), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(passwordFile, []byte("foobar"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	gzond := runGzond(t, "--lightkdf", "account", "import", "--format", "mnemonic", "-password", passwordFile, keyfile)
+	defer gzond.ExpectExit()
+	gzond.Expect("Address: {204d821b6bb6608181eec92166876d18bc2061cf}\n")
+}
+
 func TestAccountHelp(t *testing.T) {
 	gzond := runGzond(t, "account", "-h")
 	gzond.WaitExit()
@@ -286,6 +342,30 @@ undefined
 	}
 }
 
+func TestUnlockFlagMax(t *testing.T) {
+	datadir := tmpDatadirWithKeystore(t)
+
+	// Unlocking exactly as many accounts as allowed succeeds.
+	gzond := runMinimalGzond(t, "--port", "0", "--ipcdisable", "--datadir", datadir,
+		"--unlock.max", "2", "--password", "testdata/passwords.txt", "--unlock", "0,2",
+		"console", "--exec", "loadScript('testdata/empty.js')")
+	gzond.Expect(`
+undefined
+`)
+	gzond.ExpectExit()
+
+	if !strings.Contains(gzond.StderrText(), "Unlocked account") {
+		t.Errorf("stderr text does not contain %q", "Unlocked account")
+	}
+	// Requesting one more account than allowed fails.
+	gzond = runMinimalGzond(t, "--port", "0", "--ipcdisable", "--datadir", datadir,
+		"--unlock.max", "2", "--password", "testdata/passwords.txt", "--unlock", "0,1,2")
+	defer gzond.ExpectExit()
+	gzond.Expect(`
+Fatal: Too many accounts to unlock: 3 requested, maximum is 2
+`)
+}
+
 func TestUnlockFlagPasswordFileWrongPassword(t *testing.T) {
 	gzond := runMinimalGzond(t, "--port", "0", "--ipcdisable", "--datadir", tmpDatadirWithKeystore(t),
 		"--unlock", "f466859ead1932d743d622cb74fc058882e8648a", "--password",
@@ -360,3 +440,23 @@ Fatal: None of the listed files could be unlocked.
 `)
 	gzond.ExpectExit()
 }
+
+func TestUnlockTimeout(t *testing.T) {
+	keydir := filepath.Join(tmpDatadirWithKeystore(t), "keystore")
+	ks := keystore.NewKeyStore(keydir, keystore.LightScryptN, keystore.LightScryptP)
+
+	account, _ := unlockAccount(ks, "7ef5a6135f1fd6a02593eedc869c6d41d934aef8", 0, []string{"foobar"}, 500*time.Millisecond)
+	if _, err := ks.SignHash(account, make([]byte, 32)); err != nil {
+		t.Fatalf("account not unlocked: %v", err)
+	}
+	// Wait for the timeout to lock the account again.
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(50 * time.Millisecond) {
+		_, err := ks.SignHash(account, make([]byte, 32))
+		if err == keystore.ErrLocked {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("account not locked after timeout: %v", err)
+		}
+	}
+}
@@ -20,10 +20,12 @@ import (
 	"bufio"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"reflect"
 	"runtime"
 	"strings"
+	"text/tabwriter"
 	"unicode"
 
 	"github.com/naoina/toml"
@@ -52,6 +54,15 @@ var (
 		ArgsUsage:   "<dumpfile (optional)>",
 		Flags:       flags.Merge(nodeFlags, rpcFlags),
 		Description: `Export configuration values in TOML format (to stdout by default).`,
+		Subcommands: []*cli.Command{
+			{
+				Action:      dumpForks,
+				Name:        "forks",
+				Usage:       "Print the fork schedule of the configured chain",
+				Flags:       flags.Merge(nodeFlags, rpcFlags),
+				Description: `Print the protocol upgrades of the configured chain and their activation points as a table.`,
+			},
+		},
 	}
 
 	configFileFlag = &cli.StringFlag{
@@ -247,6 +258,35 @@ func dumpConfig(ctx *cli.Context) error {
 	return nil
 }
 
+// dumpForks prints the fork schedule of the chain selected by the command line
+// flags, defaulting to mainnet when no genesis is configured.
+func dumpForks(ctx *cli.Context) error {
+	_, cfg := makeConfigNode(ctx)
+
+	config := params.MainnetChainConfig
+	if cfg.Zond.Genesis != nil && cfg.Zond.Genesis.Config != nil {
+		config = cfg.Zond.Genesis.Config
+	}
+	return printForks(os.Stdout, config)
+}
+
+// printForks writes the forks of the given chain config as aligned columns.
+func printForks(w io.Writer, config *params.ChainConfig) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "FORK\tBLOCK\tTIMESTAMP")
+	for _, fork := range config.Forks() {
+		block, timestamp := "-", "-"
+		if fork.Block != nil {
+			block = fork.Block.String()
+		}
+		if fork.Timestamp != nil {
+			timestamp = fmt.Sprint(*fork.Timestamp)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", fork.Name, block, timestamp)
+	}
+	return tw.Flush()
+}
+
 func applyMetricConfig(ctx *cli.Context, cfg *gzondConfig) {
 	if ctx.IsSet(utils.MetricsEnabledFlag.Name) {
 		cfg.Metrics.Enabled = ctx.Bool(utils.MetricsEnabledFlag.Name)
@@ -260,6 +300,9 @@ func applyMetricConfig(ctx *cli.Context, cfg *gzondConfig) {
 	if ctx.IsSet(utils.MetricsPortFlag.Name) {
 		cfg.Metrics.Port = ctx.Int(utils.MetricsPortFlag.Name)
 	}
+	if ctx.IsSet(utils.MetricsIntervalFlag.Name) {
+		cfg.Metrics.Interval = ctx.Duration(utils.MetricsIntervalFlag.Name)
+	}
 	if ctx.IsSet(utils.MetricsEnableInfluxDBFlag.Name) {
 		cfg.Metrics.EnableInfluxDB = ctx.Bool(utils.MetricsEnableInfluxDBFlag.Name)
 	}
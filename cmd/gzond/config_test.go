@@ -0,0 +1,82 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/theQRL/go-zond/params"
+)
+
+// Tests that the fork table lists every mainnet fork with its activation point.
+func TestPrintForks(t *testing.T) {
+	var buf bytes.Buffer
+	if err := printForks(&buf, params.MainnetChainConfig); err != nil {
+		t.Fatalf("failed to print forks: %v", err)
+	}
+	rows := make(map[string][]string)
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n")[1:] {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			t.Fatalf("malformed row %q", line)
+		}
+		rows[fields[0]] = fields[1:]
+	}
+	for _, fork := range params.MainnetChainConfig.Forks() {
+		row, ok := rows[fork.Name]
+		if !ok {
+			t.Errorf("fork %s missing from table", fork.Name)
+			continue
+		}
+		want := []string{"-", "-"}
+		if fork.Block != nil {
+			want[0] = fork.Block.String()
+		}
+		if fork.Timestamp != nil {
+			want[1] = fmt.Sprint(*fork.Timestamp)
+		}
+		if row[0] != want[0] || row[1] != want[1] {
+			t.Errorf("fork %s: have %v, want %v", fork.Name, row, want)
+		}
+	}
+	if row := rows["shanghai"]; len(row) != 2 || row[0] != "-" || row[1] != "0" {
+		t.Errorf("shanghai: have %v, want [- 0]", row)
+	}
+	if row := rows["london"]; len(row) != 2 || row[0] != "0" || row[1] != "-" {
+		t.Errorf("london: have %v, want [0 -]", row)
+	}
+	if row := rows["eip3541"]; len(row) != 2 || row[0] != "0" || row[1] != "-" {
+		t.Errorf("eip3541: have %v, want [0 -]", row)
+	}
+	// Forks disabled by the config have no activation point
+	buf.Reset()
+	if err := printForks(&buf, &params.ChainConfig{AllowEFCodePrefix: true}); err != nil {
+		t.Fatalf("failed to print forks: %v", err)
+	}
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 && fields[0] == "eip3541" {
+			if len(fields) != 3 || fields[1] != "-" || fields[2] != "-" {
+				t.Errorf("eip3541: have %v, want [- -]", fields[1:])
+			}
+			return
+		}
+	}
+	t.Error("eip3541 missing from table")
+}
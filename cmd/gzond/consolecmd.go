@@ -107,7 +107,7 @@ func remoteConsole(ctx *cli.Context) error {
 		utils.SetDataDir(ctx, &cfg)
 		endpoint = cfg.IPCEndpoint()
 	}
-	client, err := utils.DialRPCWithHeaders(endpoint, ctx.StringSlice(utils.HttpHeaderFlag.Name))
+	client, err := utils.DialRPCWithHeaders(endpoint, ctx.StringSlice(utils.HttpHeaderFlag.Name), 0, 0)
 	if err != nil {
 		utils.Fatalf("Unable to attach to remote gzond: %v", err)
 	}
@@ -18,6 +18,7 @@ package main
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
@@ -32,11 +33,15 @@ import (
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/common/hexutil"
 	"github.com/theQRL/go-zond/console/prompt"
+	"github.com/theQRL/go-zond/core"
 	"github.com/theQRL/go-zond/core/rawdb"
 	"github.com/theQRL/go-zond/core/state/snapshot"
+	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/crypto"
 	"github.com/theQRL/go-zond/internal/flags"
 	"github.com/theQRL/go-zond/log"
+	"github.com/theQRL/go-zond/rlp"
+	"github.com/theQRL/go-zond/rpc"
 	"github.com/theQRL/go-zond/trie"
 	"github.com/theQRL/go-zond/zonddb"
 	"github.com/urfave/cli/v2"
@@ -69,6 +74,7 @@ Remove blockchain and state databases`,
 			dbExportCmd,
 			dbMetadataCmd,
 			dbCheckStateContentCmd,
+			dbConvertSchemeCmd,
 		},
 	}
 	dbInspectCmd = &cli.Command{
@@ -90,6 +96,16 @@ Remove blockchain and state databases`,
 		Description: `This command iterates the entire database for 32-byte keys, looking for rlp-encoded trie nodes.
 For each trie node encountered, it checks that the key corresponds to the keccak256(value). If this is not true, this indicates
 a data corruption.`,
+	}
+	dbConvertSchemeCmd = &cli.Command{
+		Action:    convertScheme,
+		Name:      "convert-scheme",
+		ArgsUsage: "<hash|path>",
+		Flags:     flags.Merge(utils.NetworkFlags, utils.DatabasePathFlags),
+		Usage:     "Convert the persisted state to the given state scheme",
+		Description: `This command copies the state of the head block into the given state scheme,
+then deletes the trie nodes stored in the previous scheme. Historical states are not
+converted. The node must be stopped while the conversion is running.`,
 	}
 	dbStatCmd = &cli.Command{
 		Action: dbStats,
@@ -714,3 +730,178 @@ func showMetaData(ctx *cli.Context) error {
 	table.Render()
 	return nil
 }
+
+func convertScheme(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("required arguments: %v", ctx.Command.ArgsUsage)
+	}
+	scheme := ctx.Args().First()
+	if scheme != rawdb.HashScheme && scheme != rawdb.PathScheme {
+		return fmt.Errorf("unknown state scheme %q", scheme)
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	// Refuse to run against a live node. Its database lock would reject the
+	// conversion too, but with a far less obvious error.
+	if client, err := rpc.Dial(stack.IPCEndpoint()); err == nil {
+		client.Close()
+		return errors.New("node is running, stop it before converting the state scheme")
+	}
+	db := utils.MakeChainDatabase(ctx, stack, false)
+	defer db.Close()
+
+	stored := rawdb.ReadStateScheme(db)
+	if stored == "" {
+		return errors.New("no state present in the database")
+	}
+	if stored == scheme {
+		log.Info("State is already stored in the requested scheme", "scheme", scheme)
+		return nil
+	}
+	head := rawdb.ReadHeadBlock(db)
+	if head == nil {
+		return errors.New("no head block")
+	}
+	triedb := utils.MakeTrieDatabase(ctx, db, false, true)
+	defer triedb.Close()
+
+	log.Info("Converting state scheme", "from", stored, "to", scheme, "number", head.NumberU64(), "root", head.Root())
+	return convertStateScheme(db, triedb, head.Root(), scheme)
+}
+
+// convertStateScheme copies the state with the given root from the source trie
+// database into the given scheme, then deletes the trie nodes persisted in the
+// previous scheme. Converting to the hash scheme also persists the genesis state,
+// which the hash scheme is detected by.
+func convertStateScheme(db zonddb.Database, triedb *trie.Database, root common.Hash, scheme string) error {
+	reader, err := triedb.Reader(root)
+	if err != nil {
+		return fmt.Errorf("state %x is not available: %v", root, err)
+	}
+	var (
+		batch    = db.NewBatch()
+		nodes    int
+		rootBlob []byte
+		start    = time.Now()
+	)
+	// copyTrie writes the nodes of the trie with the given id in the new scheme.
+	copyTrie := func(id *trie.ID, onLeaf func(it trie.NodeIterator) error) error {
+		tr, err := trie.New(id, triedb)
+		if err != nil {
+			return err
+		}
+		it, err := tr.NodeIterator(nil)
+		if err != nil {
+			return err
+		}
+		for it.Next(true) {
+			// Embedded nodes are stored as part of their parents
+			if hash := it.Hash(); hash != (common.Hash{}) {
+				blob, err := reader.Node(id.Owner, it.Path(), hash)
+				if err != nil {
+					return err
+				}
+				// The account trie root marks the scheme of the database, so
+				// hold it back until everything else is flushed.
+				if id.Owner == (common.Hash{}) && len(it.Path()) == 0 {
+					rootBlob = blob
+				} else {
+					rawdb.WriteTrieNode(batch, id.Owner, it.Path(), hash, blob, scheme)
+				}
+				nodes++
+			}
+			if it.Leaf() && onLeaf != nil {
+				if err := onLeaf(it); err != nil {
+					return err
+				}
+			}
+			if batch.ValueSize() > zonddb.IdealBatchSize {
+				if err := batch.Write(); err != nil {
+					return err
+				}
+				batch.Reset()
+			}
+		}
+		return it.Error()
+	}
+	err = copyTrie(trie.StateTrieID(root), func(it trie.NodeIterator) error {
+		var acc types.StateAccount
+		if err := rlp.DecodeBytes(it.LeafBlob(), &acc); err != nil {
+			return err
+		}
+		if acc.Root == types.EmptyRootHash {
+			return nil
+		}
+		return copyTrie(trie.StorageTrieID(root, common.BytesToHash(it.LeafKey()), acc.Root), nil)
+	})
+	if err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	batch.Reset()
+
+	// Only write the state root once all other nodes are persisted, so that an
+	// interrupted conversion is never detected as a complete one.
+	if rootBlob != nil {
+		rawdb.WriteTrieNode(batch, common.Hash{}, nil, root, rootBlob, scheme)
+	}
+	if scheme == rawdb.PathScheme {
+		rawdb.WritePersistentStateID(batch, 0)
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	batch.Reset()
+	log.Info("Copied state trie nodes", "nodes", nodes, "elapsed", common.PrettyDuration(time.Since(start)))
+
+	if scheme == rawdb.HashScheme {
+		genesisdb := trie.NewDatabase(db, trie.HashDefaults)
+		err := core.CommitGenesisState(db, genesisdb, rawdb.ReadCanonicalHash(db, 0))
+		genesisdb.Close()
+		if err != nil {
+			return fmt.Errorf("failed to persist genesis state: %v", err)
+		}
+	}
+	// Delete the trie nodes of the previous scheme
+	var (
+		deleted int
+		it      = db.NewIterator(nil, nil)
+	)
+	defer it.Release()
+
+	for it.Next() {
+		var stale bool
+		if scheme == rawdb.PathScheme {
+			stale = rawdb.IsLegacyTrieNode(it.Key(), it.Value())
+		} else {
+			stale = rawdb.IsAccountTrieNode(it.Key()) || rawdb.IsStorageTrieNode(it.Key())
+		}
+		if !stale {
+			continue
+		}
+		if err := batch.Delete(it.Key()); err != nil {
+			return err
+		}
+		deleted++
+		if batch.ValueSize() > zonddb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	if scheme == rawdb.HashScheme {
+		rawdb.DeleteTrieJournal(batch)
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	log.Info("Converted state scheme", "scheme", scheme, "nodes", nodes, "deleted", deleted, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/state"
+	"github.com/theQRL/go-zond/params"
+	"github.com/theQRL/go-zond/trie"
+	"github.com/theQRL/go-zond/trie/triedb/pathdb"
+	"github.com/theQRL/go-zond/zonddb"
+)
+
+func TestConvertStateScheme(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db       = rawdb.NewMemoryDatabase()
+		alloc    = make(core.GenesisAlloc)
+		contract = common.Address{0xc0, 0xde}
+	)
+	for i := 0; i < 50; i++ {
+		alloc[common.Address{byte(i + 1)}] = core.GenesisAccount{Balance: big.NewInt(int64(i + 1))}
+	}
+	alloc[contract] = core.GenesisAccount{
+		Balance: new(big.Int),
+		Code:    []byte{0x0},
+		Storage: map[common.Hash]common.Hash{{0x1}: {0x1}, {0x2}: {0x2}},
+	}
+	genesis := &core.Genesis{Config: params.TestChainConfig, Alloc: alloc}
+	hashdb := trie.NewDatabase(db, trie.HashDefaults)
+	root := genesis.MustCommit(db, hashdb).Root()
+	hashdb.Close()
+
+	// checkState verifies that the state is stored in the given scheme and
+	// matches the genesis allocation.
+	checkState := func(scheme string, triedb *trie.Database) {
+		t.Helper()
+
+		if have := rawdb.ReadStateScheme(db); have != scheme {
+			t.Fatalf("state scheme mismatch: have %q, want %q", have, scheme)
+		}
+		statedb, err := state.New(root, state.NewDatabaseWithNodeDB(db, triedb), nil)
+		if err != nil {
+			t.Fatalf("%s: failed to open state: %v", scheme, err)
+		}
+		for addr, account := range alloc {
+			if have := statedb.GetBalance(addr); have.Cmp(account.Balance) != 0 {
+				t.Fatalf("%s: balance mismatch for %x: have %v, want %v", scheme, addr, have, account.Balance)
+			}
+			for key, want := range account.Storage {
+				if have := statedb.GetState(addr, key); have != want {
+					t.Fatalf("%s: storage mismatch for %x: have %x, want %x", scheme, key, have, want)
+				}
+			}
+		}
+	}
+	// Convert the hash based state to the path scheme, the legacy nodes must
+	// be gone afterwards.
+	hashdb = trie.NewDatabase(db, trie.HashDefaults)
+	if err := convertStateScheme(db, hashdb, root, rawdb.PathScheme); err != nil {
+		t.Fatalf("failed to convert to path scheme: %v", err)
+	}
+	hashdb.Close()
+	if rawdb.HasLegacyTrieNode(db, root) {
+		t.Fatal("legacy state root retained after conversion")
+	}
+	pdb := trie.NewDatabase(db, &trie.Config{PathDB: pathdb.Defaults})
+	checkState(rawdb.PathScheme, pdb)
+	pdb.Close()
+
+	// Convert it back to the hash scheme
+	pdb = trie.NewDatabase(db, &trie.Config{PathDB: pathdb.ReadOnly})
+	if err := convertStateScheme(db, pdb, root, rawdb.HashScheme); err != nil {
+		t.Fatalf("failed to convert to hash scheme: %v", err)
+	}
+	pdb.Close()
+	if blob, _ := rawdb.ReadAccountTrieNode(db, nil); len(blob) != 0 {
+		t.Fatal("path state root retained after conversion")
+	}
+	hashdb = trie.NewDatabase(db, trie.HashDefaults)
+	checkState(rawdb.HashScheme, hashdb)
+	hashdb.Close()
+}
+
+// failingDatabase is a database whose batches fail to write after a number of
+// successful flushes.
+type failingDatabase struct {
+	zonddb.Database
+	writes int
+}
+
+func (db *failingDatabase) NewBatch() zonddb.Batch {
+	return &failingBatch{Batch: db.Database.NewBatch(), db: db}
+}
+
+type failingBatch struct {
+	zonddb.Batch
+	db *failingDatabase
+}
+
+func (b *failingBatch) Write() error {
+	if b.db.writes == 0 {
+		return errors.New("write failed")
+	}
+	b.db.writes--
+	return b.Batch.Write()
+}
+
+// Tests that an interrupted conversion to the path scheme is not detected as
+// a complete one.
+func TestConvertStateSchemeInterrupted(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db    = rawdb.NewMemoryDatabase()
+		alloc = make(core.GenesisAlloc)
+	)
+	for i := 0; i < 5000; i++ {
+		alloc[common.BigToAddress(big.NewInt(int64(i+1)))] = core.GenesisAccount{Balance: big.NewInt(int64(i + 1))}
+	}
+	genesis := &core.Genesis{Config: params.TestChainConfig, Alloc: alloc}
+	hashdb := trie.NewDatabase(db, trie.HashDefaults)
+	root := genesis.MustCommit(db, hashdb).Root()
+	hashdb.Close()
+
+	// Fail the conversion after the first flush of trie nodes
+	hashdb = trie.NewDatabase(db, trie.HashDefaults)
+	if err := convertStateScheme(&failingDatabase{Database: db, writes: 1}, hashdb, root, rawdb.PathScheme); err == nil {
+		t.Fatal("interrupted conversion succeeded")
+	}
+	hashdb.Close()
+
+	if blob, _ := rawdb.ReadAccountTrieNode(db, nil); len(blob) != 0 {
+		t.Fatal("path state root written by interrupted conversion")
+	}
+	if have := rawdb.ReadStateScheme(db); have != rawdb.HashScheme {
+		t.Fatalf("state scheme mismatch: have %q, want %q", have, rawdb.HashScheme)
+	}
+}
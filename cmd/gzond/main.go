@@ -55,6 +55,8 @@ var (
 	nodeFlags = flags.Merge([]cli.Flag{
 		utils.IdentityFlag,
 		utils.UnlockedAccountFlag,
+		utils.UnlockTimeoutFlag,
+		utils.UnlockMaxFlag,
 		utils.PasswordFileFlag,
 		utils.BootnodesFlag,
 		utils.MinFreeDiskSpaceFlag,
@@ -68,28 +70,39 @@ var (
 		utils.TxPoolNoLocalsFlag,
 		utils.TxPoolJournalFlag,
 		utils.TxPoolRejournalFlag,
+		utils.TxPoolJournalMaxSizeFlag,
+		utils.TxPoolJournalStrictFlag,
 		utils.TxPoolPriceLimitFlag,
 		utils.TxPoolPriceBumpFlag,
+		utils.TxPoolMinFeeCapRatioFlag,
 		utils.TxPoolAccountSlotsFlag,
 		utils.TxPoolGlobalSlotsFlag,
 		utils.TxPoolAccountQueueFlag,
 		utils.TxPoolGlobalQueueFlag,
 		utils.TxPoolLifetimeFlag,
+		utils.TxPoolMaxFutureNonceFlag,
 		utils.SyncModeFlag,
+		utils.SnapRangeSizeFlag,
 		utils.SyncTargetFlag,
 		utils.ExitWhenSyncedFlag,
 		utils.GCModeFlag,
 		utils.SnapshotFlag,
+		utils.SnapshotVerifyFlag,
 		utils.TransactionHistoryFlag,
+		utils.SenderNonceIndexFlag,
 		utils.StateSchemeFlag,
 		utils.StateHistoryFlag,
 		utils.LightKDFFlag,
 		utils.ZondRequiredBlocksFlag,
+		utils.CheckpointHashFlag,
+		utils.CheckpointNumberFlag,
 		utils.BloomFilterSizeFlag,
 		utils.CacheFlag,
 		utils.CacheDatabaseFlag,
 		utils.CacheTrieFlag,
 		utils.CacheGCFlag,
+		utils.CacheGCBlockIntervalFlag,
+		utils.CacheGCFlushOnExitFlag,
 		utils.CacheSnapshotFlag,
 		utils.CacheNoPrefetchFlag,
 		utils.CachePreimagesFlag,
@@ -99,10 +112,14 @@ var (
 		utils.DiscoveryPortFlag,
 		utils.MaxPeersFlag,
 		utils.MaxPendingPeersFlag,
+		utils.MaxStaticBackoffFlag,
 		utils.MinerGasLimitFlag,
 		utils.MinerGasPriceFlag,
 		utils.MinerExtraDataFlag,
 		utils.MinerRecommitIntervalFlag,
+		utils.MinerPendingBlocksFlag,
+		utils.MinerMaxBuildsFlag,
+		utils.MinerPendingRefreshFlag,
 		utils.NATFlag,
 		utils.NoDiscoverFlag,
 		utils.DiscoveryV4Flag,
@@ -111,6 +128,8 @@ var (
 		utils.NodeKeyFileFlag,
 		utils.NodeKeyHexFlag,
 		utils.DNSDiscoveryFlag,
+		utils.DialFailuresFlag,
+		utils.DialCooldownFlag,
 		utils.DeveloperFlag,
 		utils.DeveloperGasLimitFlag,
 		utils.DeveloperPeriodFlag,
@@ -138,8 +157,10 @@ var (
 		utils.GraphQLEnabledFlag,
 		utils.GraphQLCORSDomainFlag,
 		utils.GraphQLVirtualHostsFlag,
+		utils.GraphQLIntrospectionFlag,
 		utils.HTTPApiFlag,
 		utils.HTTPPathPrefixFlag,
+		utils.HTTPCompressionFlag,
 		utils.WSEnabledFlag,
 		utils.WSListenAddrFlag,
 		utils.WSPortFlag,
@@ -150,10 +171,16 @@ var (
 		utils.IPCPathFlag,
 		utils.InsecureUnlockAllowedFlag,
 		utils.RPCGlobalGasCapFlag,
+		utils.RPCTraceGasCapFlag,
 		utils.RPCGlobalEVMTimeoutFlag,
 		utils.RPCGlobalTxFeeCapFlag,
+		utils.RPCMaxTxSizeFlag,
+		utils.RPCGetLogsMaxAddressesFlag,
+		utils.RPCGetLogsMaxRangeFlag,
 		utils.BatchRequestLimit,
 		utils.BatchResponseMaxSize,
+		utils.RPCMethodTimeoutsFlag,
+		utils.RPCMinPeersFlag,
 	}
 
 	metricsFlags = []cli.Flag{
@@ -161,6 +188,7 @@ var (
 		utils.MetricsEnabledExpensiveFlag,
 		utils.MetricsHTTPFlag,
 		utils.MetricsPortFlag,
+		utils.MetricsIntervalFlag,
 		utils.MetricsEnableInfluxDBFlag,
 		utils.MetricsInfluxDBEndpointFlag,
 		utils.MetricsInfluxDBDatabaseFlag,
@@ -283,7 +311,7 @@ func prepare(ctx *cli.Context) {
 	utils.SetupMetrics(ctx)
 
 	// Start system runtime metrics collection
-	go metrics.CollectProcessMetrics(3 * time.Second)
+	go metrics.CollectProcessMetrics(ctx.Duration(utils.MetricsIntervalFlag.Name))
 }
 
 // gzond is the main entry point into the system if no special subcommand is run.
@@ -394,6 +422,9 @@ func unlockAccounts(ctx *cli.Context, stack *node.Node) {
 	if len(unlocks) == 0 {
 		return
 	}
+	if limit := ctx.Int(utils.UnlockMaxFlag.Name); limit > 0 && len(unlocks) > limit {
+		utils.Fatalf("Too many accounts to unlock: %d requested, maximum is %d", len(unlocks), limit)
+	}
 	// If insecure account unlocking is not allowed if node's APIs are exposed to external.
 	// Print warning log to user and skip unlocking.
 	if !stack.Config().InsecureUnlockAllowed && stack.Config().ExtRPCEnabled() {
@@ -406,7 +437,8 @@ func unlockAccounts(ctx *cli.Context, stack *node.Node) {
 	}
 	ks := backends[0].(*keystore.KeyStore)
 	passwords := utils.MakePasswordList(ctx)
+	timeout := ctx.Duration(utils.UnlockTimeoutFlag.Name)
 	for i, account := range unlocks {
-		unlockAccount(ks, account, i, passwords)
+		unlockAccount(ks, account, i, passwords, timeout)
 	}
 }
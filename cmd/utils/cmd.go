@@ -138,7 +138,14 @@ func monitorFreeDiskSpace(sigc chan os.Signal, path string, freeDiskSpaceCritica
 	}
 }
 
-func ImportChain(chain *core.BlockChain, fn string) error {
+// ImportChain imports the RLP-encoded blocks stored in the given file into the
+// chain, inserting batchSize blocks at a time. A non-positive batch size falls
+// back to the default. A file name of "-" reads the blocks from stdin, which is
+// transparently decompressed if it carries a gzip stream.
+func ImportChain(chain *core.BlockChain, fn string, batchSize int) error {
+	if batchSize <= 0 {
+		batchSize = importBatchSize
+	}
 	// Watch for Ctrl-C while the import is running.
 	// If a signal is received, the import will stop at the next batch.
 	interrupt := make(chan os.Signal, 1)
@@ -161,25 +168,43 @@ func ImportChain(chain *core.BlockChain, fn string) error {
 		}
 	}
 
-	log.Info("Importing blockchain", "file", fn)
-
-	// Open the file handle and potentially unwrap the gzip stream
-	fh, err := os.Open(fn)
-	if err != nil {
-		return err
+	if fn == "-" {
+		log.Info("Importing blockchain", "file", "stdin")
+	} else {
+		log.Info("Importing blockchain", "file", fn)
 	}
-	defer fh.Close()
 
-	var reader io.Reader = fh
-	if strings.HasSuffix(fn, ".gz") {
-		if reader, err = gzip.NewReader(reader); err != nil {
+	// Open the file handle and potentially unwrap the gzip stream
+	var reader io.Reader
+	if fn == "-" {
+		// Stdin can't be inspected by name, sniff the gzip magic instead
+		buffered := bufio.NewReader(os.Stdin)
+		reader = buffered
+		if magic, _ := buffered.Peek(2); len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+			gz, err := gzip.NewReader(buffered)
+			if err != nil {
+				return err
+			}
+			reader = gz
+		}
+	} else {
+		fh, err := os.Open(fn)
+		if err != nil {
 			return err
 		}
+		defer fh.Close()
+
+		reader = fh
+		if strings.HasSuffix(fn, ".gz") {
+			if reader, err = gzip.NewReader(reader); err != nil {
+				return err
+			}
+		}
 	}
 	stream := rlp.NewStream(reader, 0)
 
 	// Run actual the import.
-	blocks := make(types.Blocks, importBatchSize)
+	blocks := make(types.Blocks, batchSize)
 	n := 0
 	for batch := 0; ; batch++ {
 		// Load a batch of RLP blocks.
@@ -187,7 +212,7 @@ func ImportChain(chain *core.BlockChain, fn string) error {
 			return errors.New("interrupted")
 		}
 		i := 0
-		for ; i < importBatchSize; i++ {
+		for ; i < batchSize; i++ {
 			var b types.Block
 			if err := stream.Decode(&b); err == io.EOF {
 				break
@@ -17,13 +17,18 @@
 package utils
 
 import (
+	"compress/gzip"
 	"fmt"
 	"os"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/theQRL/go-zond/consensus/beacon"
+	"github.com/theQRL/go-zond/core"
 	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/vm"
+	"github.com/theQRL/go-zond/params"
 	"github.com/theQRL/go-zond/rlp"
 )
 
@@ -196,3 +201,79 @@ func TestImportFutureFormat(t *testing.T) {
 		t.Fatalf("wrong error: %v", err)
 	}
 }
+
+// TestImportChainBatchSize tests that a chain exported to a file can be imported
+// back with a custom batch size that does not evenly divide the chain length.
+func TestImportChainBatchSize(t *testing.T) {
+	f := fmt.Sprintf("%v/tempchain", os.TempDir())
+	defer func() {
+		os.Remove(f)
+	}()
+	gspec := &core.Genesis{Config: params.TestChainConfig}
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, beacon.NewFaker(), 10, nil)
+
+	src, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, beacon.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Stop()
+	if _, err := src.InsertChain(blocks); err != nil {
+		t.Fatal(err)
+	}
+	if err := ExportChain(src, f); err != nil {
+		t.Fatal(err)
+	}
+	dst, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, beacon.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.Stop()
+	if err := ImportChain(dst, f, 3); err != nil {
+		t.Fatal(err)
+	}
+	if have, want := dst.CurrentBlock().Hash(), blocks[len(blocks)-1].Hash(); have != want {
+		t.Fatalf("head mismatch: have %x, want %x", have, want)
+	}
+	for _, block := range blocks {
+		if !dst.HasBlockAndState(block.Hash(), block.NumberU64()) {
+			t.Fatalf("block %d missing after import", block.NumberU64())
+		}
+	}
+}
+
+// TestImportChainStdin tests that a gzipped RLP block stream piped through stdin
+// is detected and imported.
+func TestImportChainStdin(t *testing.T) {
+	gspec := &core.Genesis{Config: params.TestChainConfig}
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, beacon.NewFaker(), 5, nil)
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdin := os.Stdin
+	os.Stdin = r
+	defer func() {
+		os.Stdin = stdin
+		r.Close()
+	}()
+	go func() {
+		gz := gzip.NewWriter(w)
+		for _, block := range blocks {
+			rlp.Encode(gz, block)
+		}
+		gz.Close()
+		w.Close()
+	}()
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, beacon.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer chain.Stop()
+	if err := ImportChain(chain, "-", 0); err != nil {
+		t.Fatal(err)
+	}
+	if have, want := chain.CurrentBlock().Number.Uint64(), blocks[len(blocks)-1].NumberU64(); have != want {
+		t.Fatalf("head mismatch: have %d, want %d", have, want)
+	}
+}
@@ -100,6 +100,17 @@ var (
 		Usage:    "URL for remote database",
 		Category: flags.LoggingCategory,
 	}
+	RemoteDBRetriesFlag = &cli.IntFlag{
+		Name:     "remotedb.retries",
+		Usage:    "Number of times to retry dialing the remote database before giving up",
+		Category: flags.LoggingCategory,
+	}
+	RemoteDBRetryIntervalFlag = &cli.DurationFlag{
+		Name:     "remotedb.retry-interval",
+		Usage:    "Initial delay between remote database dial attempts, doubled after each failure",
+		Value:    time.Second,
+		Category: flags.LoggingCategory,
+	}
 	DBEngineFlag = &cli.StringFlag{
 		Name:     "db.engine",
 		Usage:    "Backing database implementation to use ('pebble' or 'leveldb')",
@@ -111,6 +122,11 @@ var (
 		Usage:    "Root directory for ancient data (default = inside chaindata)",
 		Category: flags.ZondCategory,
 	}
+	AncientThresholdFlag = &cli.Uint64Flag{
+		Name:     "datadir.ancient.threshold",
+		Usage:    "Number of recent blocks kept in the live database before moving to the ancient store (0 = default, minimum 90000)",
+		Category: flags.ZondCategory,
+	}
 	MinFreeDiskSpaceFlag = &flags.DirectoryFlag{
 		Name:     "datadir.minfreedisk",
 		Usage:    "Minimum free disk space in MB, once reached triggers auto shut down (default = --cache.gc converted to MB, 0 = disabled)",
@@ -156,7 +172,7 @@ var (
 	}
 	DeveloperPeriodFlag = &cli.Uint64Flag{
 		Name:     "dev.period",
-		Usage:    "Block period to use in developer mode (0 = mine only if transaction pending)",
+		Usage:    "Block period to use in developer mode, sealing empty blocks if nothing is pending (0 = mine only if transaction pending)",
 		Category: flags.DevCategory,
 	}
 	DeveloperGasLimitFlag = &cli.Uint64Flag{
@@ -183,6 +199,13 @@ var (
 		Category: flags.ZondCategory,
 	}
 
+	// Import command options.
+	ImportBatchSizeFlag = &cli.IntFlag{
+		Name:  "import.batchsize",
+		Usage: "Number of blocks to insert into the chain per batch during import",
+		Value: importBatchSize,
+	}
+
 	// Dump command options.
 	IterativeOutputFlag = &cli.BoolFlag{
 		Name:  "iterative",
@@ -219,6 +242,11 @@ var (
 		Value:    true,
 		Category: flags.ZondCategory,
 	}
+	SnapshotVerifyFlag = &cli.IntFlag{
+		Name:     "snapshot.verify",
+		Usage:    "Number of snapshot accounts to check against the state trie on startup, rebuilding the snapshot on mismatch (0 = disabled)",
+		Category: flags.ZondCategory,
+	}
 	LightKDFFlag = &cli.BoolFlag{
 		Name:     "lightkdf",
 		Usage:    "Reduce key-derivation RAM & CPU usage at some expense of KDF strength",
@@ -229,6 +257,16 @@ var (
 		Usage:    "Comma separated block number-to-hash mappings to require for peering (<number>=<hash>)",
 		Category: flags.ZondCategory,
 	}
+	CheckpointHashFlag = &cli.StringFlag{
+		Name:     "checkpoint.hash",
+		Usage:    "Hash of a trusted block, its ancestor headers are not re-verified during sync",
+		Category: flags.ZondCategory,
+	}
+	CheckpointNumberFlag = &cli.Uint64Flag{
+		Name:     "checkpoint.number",
+		Usage:    "Number of the trusted block set by --checkpoint.hash",
+		Category: flags.ZondCategory,
+	}
 	BloomFilterSizeFlag = &cli.Uint64Flag{
 		Name:     "bloomfilter.size",
 		Usage:    "Megabytes of memory allocated to bloom-filter for pruning",
@@ -241,6 +279,11 @@ var (
 		Value:    &defaultSyncMode,
 		Category: flags.StateCategory,
 	}
+	SnapRangeSizeFlag = &cli.Uint64Flag{
+		Name:     "snap.rangesize",
+		Usage:    "Maximum number of bytes to request per snap sync account range (0 = protocol default)",
+		Category: flags.StateCategory,
+	}
 	GCModeFlag = &cli.StringFlag{
 		Name:     "gcmode",
 		Usage:    `Blockchain garbage collection mode, only relevant in state.scheme=hash ("full", "archive")`,
@@ -265,6 +308,11 @@ var (
 		Value:    zondconfig.Defaults.TransactionHistory,
 		Category: flags.StateCategory,
 	}
+	SenderNonceIndexFlag = &cli.BoolFlag{
+		Name:     "history.sendernonce",
+		Usage:    "Index imported transactions by sender and nonce (required by zond_getTransactionBySenderAndNonce)",
+		Category: flags.StateCategory,
+	}
 	// Transaction pool settings
 	TxPoolLocalsFlag = &cli.StringFlag{
 		Name:     "txpool.locals",
@@ -288,6 +336,17 @@ var (
 		Value:    zondconfig.Defaults.TxPool.Rejournal,
 		Category: flags.TxPoolCategory,
 	}
+	TxPoolJournalMaxSizeFlag = &cli.Uint64Flag{
+		Name:     "txpool.journal.maxsize",
+		Usage:    "Size in bytes above which the local transaction journal is regenerated early (0 = unlimited)",
+		Value:    zondconfig.Defaults.TxPool.JournalMaxSize,
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolJournalStrictFlag = &cli.BoolFlag{
+		Name:     "txpool.journal.strict",
+		Usage:    "Drop journaled local transactions that fail current pricing rules at startup instead of re-queuing them",
+		Category: flags.TxPoolCategory,
+	}
 	TxPoolPriceLimitFlag = &cli.Uint64Flag{
 		Name:     "txpool.pricelimit",
 		Usage:    "Minimum gas price tip to enforce for acceptance into the pool",
@@ -300,6 +359,12 @@ var (
 		Value:    zondconfig.Defaults.TxPool.PriceBump,
 		Category: flags.TxPoolCategory,
 	}
+	TxPoolMinFeeCapRatioFlag = &cli.Uint64Flag{
+		Name:     "txpool.minfeecapratio",
+		Usage:    "Minimum fee cap of remote transactions as a percentage of the pending base fee (0 = disabled)",
+		Value:    zondconfig.Defaults.TxPool.MinFeeCapRatio,
+		Category: flags.TxPoolCategory,
+	}
 	TxPoolAccountSlotsFlag = &cli.Uint64Flag{
 		Name:     "txpool.accountslots",
 		Usage:    "Minimum number of executable transaction slots guaranteed per account",
@@ -330,6 +395,11 @@ var (
 		Value:    zondconfig.Defaults.TxPool.Lifetime,
 		Category: flags.TxPoolCategory,
 	}
+	TxPoolMaxFutureNonceFlag = &cli.Uint64Flag{
+		Name:     "txpool.maxfuturenonce",
+		Usage:    "Maximum gap between the nonce of a raw transaction submitted via RPC and the sender's next nonce (0 = no limit)",
+		Category: flags.TxPoolCategory,
+	}
 	// Performance tuning settings
 	CacheFlag = &cli.IntFlag{
 		Name:     "cache",
@@ -355,6 +425,17 @@ var (
 		Value:    25,
 		Category: flags.PerfCategory,
 	}
+	CacheGCBlockIntervalFlag = &cli.Uint64Flag{
+		Name:     "cache.gc.blockinterval",
+		Usage:    "Number of blocks after which to flush the in-memory trie to disk regardless of processing time (0 = disabled)",
+		Category: flags.PerfCategory,
+	}
+	CacheGCFlushOnExitFlag = &cli.BoolFlag{
+		Name:     "cache.gc.flushonexit",
+		Usage:    "Persist several recent states on shutdown instead of only the head state (hash scheme only, path scheme always journals)",
+		Value:    true,
+		Category: flags.PerfCategory,
+	}
 	CacheSnapshotFlag = &cli.IntFlag{
 		Name:     "cache.snapshot",
 		Usage:    "Percentage of cache memory allowance to use for snapshot caching (default = 10% full mode, 20% archive mode)",
@@ -412,6 +493,24 @@ var (
 		Value:    zondconfig.Defaults.Miner.Recommit,
 		Category: flags.MinerCategory,
 	}
+	MinerPendingBlocksFlag = &cli.IntFlag{
+		Name:     "miner.pendingblocks",
+		Usage:    "Number of recent pending blocks to retain for consistent pending queries across head changes",
+		Value:    zondconfig.Defaults.Miner.PendingBlocks,
+		Category: flags.MinerCategory,
+	}
+	MinerMaxBuildsFlag = &cli.IntFlag{
+		Name:     "miner.maxbuilds",
+		Usage:    "Maximum number of payloads built simultaneously, evicting the oldest build when exceeded (0=unlimited)",
+		Value:    zondconfig.Defaults.Miner.MaxBuilds,
+		Category: flags.MinerCategory,
+	}
+	MinerPendingRefreshFlag = &cli.DurationFlag{
+		Name:     "miner.pending.refresh",
+		Usage:    "Time interval to rebuild the pending block served over RPC, independent of the recommit interval (0=disabled)",
+		Value:    zondconfig.Defaults.Miner.PendingRefresh,
+		Category: flags.MinerCategory,
+	}
 
 	// Account settings
 	UnlockedAccountFlag = &cli.StringFlag{
@@ -420,6 +519,16 @@ var (
 		Value:    "",
 		Category: flags.AccountCategory,
 	}
+	UnlockTimeoutFlag = &cli.DurationFlag{
+		Name:     "unlock.timeout",
+		Usage:    "Duration after which accounts unlocked via --unlock are locked again (0 = never)",
+		Category: flags.AccountCategory,
+	}
+	UnlockMaxFlag = &cli.IntFlag{
+		Name:     "unlock.max",
+		Usage:    "Maximum number of accounts that can be unlocked via --unlock (0 = unlimited)",
+		Category: flags.AccountCategory,
+	}
 	PasswordFileFlag = &cli.PathFlag{
 		Name:      "password",
 		Usage:     "Password file to use for non-interactive password input",
@@ -452,6 +561,12 @@ var (
 		Value:    zondconfig.Defaults.RPCGasCap,
 		Category: flags.APICategory,
 	}
+	RPCTraceGasCapFlag = &cli.Uint64Flag{
+		Name:     "rpc.trace.gascap",
+		Usage:    "Sets a cap on gas that can be requested by debug_traceCall, rejecting calls above it (0=use rpc.gascap)",
+		Value:    zondconfig.Defaults.RPCTraceGasCap,
+		Category: flags.APICategory,
+	}
 	RPCGlobalEVMTimeoutFlag = &cli.DurationFlag{
 		Name:     "rpc.evmtimeout",
 		Usage:    "Sets a timeout used for zond_call (0=infinite)",
@@ -464,6 +579,24 @@ var (
 		Value:    zondconfig.Defaults.RPCTxFeeCap,
 		Category: flags.APICategory,
 	}
+	RPCMaxTxSizeFlag = &cli.Uint64Flag{
+		Name:     "rpc.maxtxsize",
+		Usage:    "Sets a limit on the size (in bytes) of raw transactions that can be sent via the RPC APIs (0 = no limit)",
+		Value:    zondconfig.Defaults.RPCMaxTxSize,
+		Category: flags.APICategory,
+	}
+	RPCGetLogsMaxAddressesFlag = &cli.IntFlag{
+		Name:     "rpc.getlogs.maxaddresses",
+		Usage:    "Maximum number of addresses allowed in a single log filter query (0 = no limit)",
+		Value:    zondconfig.Defaults.FilterMaxAddresses,
+		Category: flags.APICategory,
+	}
+	RPCGetLogsMaxRangeFlag = &cli.Uint64Flag{
+		Name:     "rpc.getlogs.maxrange",
+		Usage:    "Maximum number of blocks a single log query may span (0 = no limit)",
+		Value:    zondconfig.Defaults.FilterMaxBlockRange,
+		Category: flags.APICategory,
+	}
 	// Authenticated RPC HTTP settings
 	AuthListenFlag = &cli.StringFlag{
 		Name:     "authrpc.addr",
@@ -561,6 +694,12 @@ var (
 		Value:    "",
 		Category: flags.APICategory,
 	}
+	HTTPCompressionFlag = &cli.BoolFlag{
+		Name:     "http.compression",
+		Usage:    "Enable gzip/deflate compression of HTTP-RPC responses, negotiated via the Accept-Encoding header",
+		Value:    true,
+		Category: flags.APICategory,
+	}
 	GraphQLEnabledFlag = &cli.BoolFlag{
 		Name:     "graphql",
 		Usage:    "Enable GraphQL on the HTTP-RPC server. Note that GraphQL can only be started if an HTTP server is started as well.",
@@ -578,6 +717,12 @@ var (
 		Value:    strings.Join(node.DefaultConfig.GraphQLVirtualHosts, ","),
 		Category: flags.APICategory,
 	}
+	GraphQLIntrospectionFlag = &cli.BoolFlag{
+		Name:     "graphql.introspection",
+		Usage:    "Answer schema introspection queries on the GraphQL endpoint",
+		Value:    true,
+		Category: flags.APICategory,
+	}
 	WSEnabledFlag = &cli.BoolFlag{
 		Name:     "ws",
 		Usage:    "Enable the WS-RPC server",
@@ -635,6 +780,17 @@ var (
 		Value:    node.DefaultConfig.BatchResponseMaxSize,
 		Category: flags.APICategory,
 	}
+	RPCMethodTimeoutsFlag = &cli.StringFlag{
+		Name:     "rpc.method-timeouts",
+		Usage:    "Comma separated list of per-method request timeouts, capped by the HTTP write timeout (e.g. zond_call=5s,debug_traceTransaction=20s)",
+		Category: flags.APICategory,
+	}
+	RPCMinPeersFlag = &cli.IntFlag{
+		Name:     "rpc.minpeers",
+		Usage:    "Minimum number of connected peers before the HTTP and WebSocket RPC endpoints are enabled",
+		Value:    node.DefaultConfig.RPCMinPeers,
+		Category: flags.APICategory,
+	}
 
 	// Network Settings
 	MaxPeersFlag = &cli.IntFlag{
@@ -649,6 +805,12 @@ var (
 		Value:    node.DefaultConfig.P2P.MaxPendingPeers,
 		Category: flags.NetworkingCategory,
 	}
+	MaxStaticBackoffFlag = &cli.DurationFlag{
+		Name:     "maxstaticbackoff",
+		Usage:    "Maximum reconnection backoff for failing static peers (fixed redial interval if set to 0)",
+		Value:    node.DefaultConfig.P2P.MaxStaticBackoff,
+		Category: flags.NetworkingCategory,
+	}
 	ListenPortFlag = &cli.IntFlag{
 		Name:     "port",
 		Usage:    "Network listening port",
@@ -705,6 +867,18 @@ var (
 		Usage:    "Sets DNS discovery entry points (use \"\" to disable DNS)",
 		Category: flags.NetworkingCategory,
 	}
+	DialFailuresFlag = &cli.IntFlag{
+		Name:     "discovery.dialfailures",
+		Usage:    "Number of consecutive failed dials after which a discovered node is skipped (0 = disabled)",
+		Value:    zondconfig.Defaults.DialFailures,
+		Category: flags.NetworkingCategory,
+	}
+	DialCooldownFlag = &cli.DurationFlag{
+		Name:     "discovery.dialcooldown",
+		Usage:    "Time after which a skipped node is dialed again",
+		Value:    zondconfig.Defaults.DialCooldown,
+		Category: flags.NetworkingCategory,
+	}
 	DiscoveryPortFlag = &cli.IntFlag{
 		Name:     "discovery.port",
 		Usage:    "Use a custom UDP port for P2P discovery",
@@ -780,6 +954,12 @@ Please note that --` + MetricsHTTPFlag.Name + ` must be set to start the server.
 		Value:    metrics.DefaultConfig.Port,
 		Category: flags.MetricsCategory,
 	}
+	MetricsIntervalFlag = &cli.DurationFlag{
+		Name:     "metrics.interval",
+		Usage:    "Interval at which process metrics are sampled and metrics are pushed to InfluxDB",
+		Value:    metrics.DefaultConfig.Interval,
+		Category: flags.MetricsCategory,
+	}
 	MetricsEnableInfluxDBFlag = &cli.BoolFlag{
 		Name:     "metrics.influxdb",
 		Usage:    "Enable metrics export/push to an external InfluxDB database",
@@ -860,7 +1040,10 @@ var (
 	DatabasePathFlags = []cli.Flag{
 		DataDirFlag,
 		AncientFlag,
+		AncientThresholdFlag,
 		RemoteDBFlag,
+		RemoteDBRetriesFlag,
+		RemoteDBRetryIntervalFlag,
 		HttpHeaderFlag,
 	}
 )
@@ -993,6 +1176,27 @@ func setNAT(ctx *cli.Context, cfg *p2p.Config) {
 	}
 }
 
+// parseMethodTimeouts parses a comma separated list of method=duration entries.
+func parseMethodTimeouts(input string) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration)
+	for _, entry := range SplitAndTrim(input) {
+		method, value, ok := strings.Cut(entry, "=")
+		method, value = strings.TrimSpace(method), strings.TrimSpace(value)
+		if !ok || method == "" {
+			return nil, fmt.Errorf("invalid entry %q, want method=duration", entry)
+		}
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout for %s: %v", method, err)
+		}
+		if timeout <= 0 {
+			return nil, fmt.Errorf("non-positive timeout for %s: %v", method, timeout)
+		}
+		timeouts[method] = timeout
+	}
+	return timeouts, nil
+}
+
 // SplitAndTrim splits input separated by a comma
 // and trims excessive white space from the substrings.
 func SplitAndTrim(input string) (ret []string) {
@@ -1047,6 +1251,10 @@ func setHTTP(ctx *cli.Context, cfg *node.Config) {
 		cfg.HTTPPathPrefix = ctx.String(HTTPPathPrefixFlag.Name)
 	}
 
+	if ctx.IsSet(HTTPCompressionFlag.Name) {
+		cfg.HTTPNoCompression = !ctx.Bool(HTTPCompressionFlag.Name)
+	}
+
 	if ctx.IsSet(BatchRequestLimit.Name) {
 		cfg.BatchRequestLimit = ctx.Int(BatchRequestLimit.Name)
 	}
@@ -1054,6 +1262,18 @@ func setHTTP(ctx *cli.Context, cfg *node.Config) {
 	if ctx.IsSet(BatchResponseMaxSize.Name) {
 		cfg.BatchResponseMaxSize = ctx.Int(BatchResponseMaxSize.Name)
 	}
+
+	if ctx.IsSet(RPCMethodTimeoutsFlag.Name) {
+		timeouts, err := parseMethodTimeouts(ctx.String(RPCMethodTimeoutsFlag.Name))
+		if err != nil {
+			Fatalf("Invalid --%s: %v", RPCMethodTimeoutsFlag.Name, err)
+		}
+		cfg.RPCMethodTimeouts = timeouts
+	}
+
+	if ctx.IsSet(RPCMinPeersFlag.Name) {
+		cfg.RPCMinPeers = ctx.Int(RPCMinPeersFlag.Name)
+	}
 }
 
 // setGraphQL creates the GraphQL listener interface string from the set
@@ -1065,6 +1285,9 @@ func setGraphQL(ctx *cli.Context, cfg *node.Config) {
 	if ctx.IsSet(GraphQLVirtualHostsFlag.Name) {
 		cfg.GraphQLVirtualHosts = SplitAndTrim(ctx.String(GraphQLVirtualHostsFlag.Name))
 	}
+	if ctx.IsSet(GraphQLIntrospectionFlag.Name) {
+		cfg.GraphQLNoIntrospection = !ctx.Bool(GraphQLIntrospectionFlag.Name)
+	}
 }
 
 // setWS creates the WebSocket RPC listener interface string from the set
@@ -1208,6 +1431,9 @@ func SetP2PConfig(ctx *cli.Context, cfg *p2p.Config) {
 	if ctx.IsSet(MaxPendingPeersFlag.Name) {
 		cfg.MaxPendingPeers = ctx.Int(MaxPendingPeersFlag.Name)
 	}
+	if ctx.IsSet(MaxStaticBackoffFlag.Name) {
+		cfg.MaxStaticBackoff = ctx.Duration(MaxStaticBackoffFlag.Name)
+	}
 	if ctx.IsSet(NoDiscoverFlag.Name) {
 		cfg.NoDiscovery = true
 	}
@@ -1345,12 +1571,21 @@ func setTxPool(ctx *cli.Context, cfg *legacypool.Config) {
 	if ctx.IsSet(TxPoolRejournalFlag.Name) {
 		cfg.Rejournal = ctx.Duration(TxPoolRejournalFlag.Name)
 	}
+	if ctx.IsSet(TxPoolJournalMaxSizeFlag.Name) {
+		cfg.JournalMaxSize = ctx.Uint64(TxPoolJournalMaxSizeFlag.Name)
+	}
+	if ctx.IsSet(TxPoolJournalStrictFlag.Name) {
+		cfg.JournalStrict = ctx.Bool(TxPoolJournalStrictFlag.Name)
+	}
 	if ctx.IsSet(TxPoolPriceLimitFlag.Name) {
 		cfg.PriceLimit = ctx.Uint64(TxPoolPriceLimitFlag.Name)
 	}
 	if ctx.IsSet(TxPoolPriceBumpFlag.Name) {
 		cfg.PriceBump = ctx.Uint64(TxPoolPriceBumpFlag.Name)
 	}
+	if ctx.IsSet(TxPoolMinFeeCapRatioFlag.Name) {
+		cfg.MinFeeCapRatio = ctx.Uint64(TxPoolMinFeeCapRatioFlag.Name)
+	}
 	if ctx.IsSet(TxPoolAccountSlotsFlag.Name) {
 		cfg.AccountSlots = ctx.Uint64(TxPoolAccountSlotsFlag.Name)
 	}
@@ -1381,6 +1616,15 @@ func setMiner(ctx *cli.Context, cfg *miner.Config) {
 	if ctx.IsSet(MinerRecommitIntervalFlag.Name) {
 		cfg.Recommit = ctx.Duration(MinerRecommitIntervalFlag.Name)
 	}
+	if ctx.IsSet(MinerPendingBlocksFlag.Name) {
+		cfg.PendingBlocks = ctx.Int(MinerPendingBlocksFlag.Name)
+	}
+	if ctx.IsSet(MinerMaxBuildsFlag.Name) {
+		cfg.MaxBuilds = ctx.Int(MinerMaxBuildsFlag.Name)
+	}
+	if ctx.IsSet(MinerPendingRefreshFlag.Name) {
+		cfg.PendingRefresh = ctx.Duration(MinerPendingRefreshFlag.Name)
+	}
 }
 
 func setRequiredBlocks(ctx *cli.Context, cfg *zondconfig.Config) {
@@ -1406,6 +1650,30 @@ func setRequiredBlocks(ctx *cli.Context, cfg *zondconfig.Config) {
 	}
 }
 
+// disableSnapshot turns off the state snapshot, folding the cache allowance it
+// would have used into the trie clean cache.
+func disableSnapshot(cfg *zondconfig.Config) {
+	log.Info("Disabling state snapshot, folding its cache into the trie clean cache",
+		"snapshot", cfg.SnapshotCache, "trieclean", cfg.TrieCleanCache+cfg.SnapshotCache)
+	cfg.TrieCleanCache += cfg.SnapshotCache
+	cfg.SnapshotCache = 0 // Disabled
+}
+
+func setCheckpoint(ctx *cli.Context, cfg *zondconfig.Config) {
+	if !ctx.IsSet(CheckpointHashFlag.Name) && !ctx.IsSet(CheckpointNumberFlag.Name) {
+		return
+	}
+	if !ctx.IsSet(CheckpointHashFlag.Name) || !ctx.IsSet(CheckpointNumberFlag.Name) {
+		Fatalf("Flags --%s and --%s must be set together", CheckpointHashFlag.Name, CheckpointNumberFlag.Name)
+	}
+	var hash common.Hash
+	if err := hash.UnmarshalText([]byte(ctx.String(CheckpointHashFlag.Name))); err != nil {
+		Fatalf("Invalid checkpoint hash %s: %v", ctx.String(CheckpointHashFlag.Name), err)
+	}
+	cfg.CheckpointNumber = ctx.Uint64(CheckpointNumberFlag.Name)
+	cfg.CheckpointHash = hash
+}
+
 // CheckExclusive verifies that only a single instance of the provided flags was
 // set by the user. Each flag might optionally be followed by a string type to
 // specialize it further.
@@ -1459,6 +1727,7 @@ func SetZondConfig(ctx *cli.Context, stack *node.Node, cfg *zondconfig.Config) {
 	setTxPool(ctx, &cfg.TxPool)
 	setMiner(ctx, &cfg.Miner)
 	setRequiredBlocks(ctx, cfg)
+	setCheckpoint(ctx, cfg)
 
 	// Cap the cache allowance and tune the garbage collector
 	mem, err := gopsutil.VirtualMemory()
@@ -1483,6 +1752,9 @@ func SetZondConfig(ctx *cli.Context, stack *node.Node, cfg *zondconfig.Config) {
 	if ctx.IsSet(SyncModeFlag.Name) {
 		cfg.SyncMode = *flags.GlobalTextMarshaler(ctx, SyncModeFlag.Name).(*downloader.SyncMode)
 	}
+	if ctx.IsSet(SnapRangeSizeFlag.Name) {
+		cfg.SnapRangeSize = ctx.Uint64(SnapRangeSizeFlag.Name)
+	}
 	if ctx.IsSet(NetworkIdFlag.Name) {
 		cfg.NetworkId = ctx.Uint64(NetworkIdFlag.Name)
 	}
@@ -1493,6 +1765,13 @@ func SetZondConfig(ctx *cli.Context, stack *node.Node, cfg *zondconfig.Config) {
 	if ctx.IsSet(AncientFlag.Name) {
 		cfg.DatabaseFreezer = ctx.String(AncientFlag.Name)
 	}
+	if ctx.IsSet(AncientThresholdFlag.Name) {
+		threshold := ctx.Uint64(AncientThresholdFlag.Name)
+		if threshold != 0 && threshold < params.FullImmutabilityThreshold {
+			Fatalf("--%s must be at least %d (the immutability threshold)", AncientThresholdFlag.Name, params.FullImmutabilityThreshold)
+		}
+		cfg.DatabaseFreezerThreshold = threshold
+	}
 
 	if gcmode := ctx.String(GCModeFlag.Name); gcmode != "full" && gcmode != "archive" {
 		Fatalf("--%s must be either 'full' or 'archive'", GCModeFlag.Name)
@@ -1524,6 +1803,9 @@ func SetZondConfig(ctx *cli.Context, stack *node.Node, cfg *zondconfig.Config) {
 	if ctx.IsSet(TransactionHistoryFlag.Name) {
 		cfg.TransactionHistory = ctx.Uint64(TransactionHistoryFlag.Name)
 	}
+	if ctx.IsSet(SenderNonceIndexFlag.Name) {
+		cfg.SenderNonceIndex = ctx.Bool(SenderNonceIndexFlag.Name)
+	}
 	if ctx.String(GCModeFlag.Name) == "archive" && cfg.TransactionHistory != 0 {
 		cfg.TransactionHistory = 0
 		log.Warn("Disabled transaction unindexing for archive node")
@@ -1534,19 +1816,27 @@ func SetZondConfig(ctx *cli.Context, stack *node.Node, cfg *zondconfig.Config) {
 	if ctx.IsSet(CacheFlag.Name) || ctx.IsSet(CacheGCFlag.Name) {
 		cfg.TrieDirtyCache = ctx.Int(CacheFlag.Name) * ctx.Int(CacheGCFlag.Name) / 100
 	}
+	if ctx.IsSet(CacheGCBlockIntervalFlag.Name) {
+		cfg.TrieBlockInterval = ctx.Uint64(CacheGCBlockIntervalFlag.Name)
+	}
+	if ctx.IsSet(CacheGCFlushOnExitFlag.Name) {
+		cfg.TriePartialFlush = !ctx.Bool(CacheGCFlushOnExitFlag.Name)
+	}
 	if ctx.IsSet(CacheFlag.Name) || ctx.IsSet(CacheSnapshotFlag.Name) {
 		cfg.SnapshotCache = ctx.Int(CacheFlag.Name) * ctx.Int(CacheSnapshotFlag.Name) / 100
 	}
 	if ctx.IsSet(CacheLogSizeFlag.Name) {
 		cfg.FilterLogCacheSize = ctx.Int(CacheLogSizeFlag.Name)
 	}
+	if ctx.IsSet(SnapshotVerifyFlag.Name) {
+		cfg.SnapshotVerify = ctx.Int(SnapshotVerifyFlag.Name)
+	}
 	if !ctx.Bool(SnapshotFlag.Name) {
 		// If snap-sync is requested, this flag is also required
 		if cfg.SyncMode == downloader.SnapSync {
 			log.Info("Snap sync requested, enabling --snapshot")
 		} else {
-			cfg.TrieCleanCache += cfg.SnapshotCache
-			cfg.SnapshotCache = 0 // Disabled
+			disableSnapshot(cfg)
 		}
 	}
 	if ctx.IsSet(DocRootFlag.Name) {
@@ -1565,12 +1855,27 @@ func SetZondConfig(ctx *cli.Context, stack *node.Node, cfg *zondconfig.Config) {
 	} else {
 		log.Info("Global gas cap disabled")
 	}
+	if ctx.IsSet(RPCTraceGasCapFlag.Name) {
+		cfg.RPCTraceGasCap = ctx.Uint64(RPCTraceGasCapFlag.Name)
+	}
 	if ctx.IsSet(RPCGlobalEVMTimeoutFlag.Name) {
 		cfg.RPCEVMTimeout = ctx.Duration(RPCGlobalEVMTimeoutFlag.Name)
 	}
 	if ctx.IsSet(RPCGlobalTxFeeCapFlag.Name) {
 		cfg.RPCTxFeeCap = ctx.Float64(RPCGlobalTxFeeCapFlag.Name)
 	}
+	if ctx.IsSet(RPCMaxTxSizeFlag.Name) {
+		cfg.RPCMaxTxSize = ctx.Uint64(RPCMaxTxSizeFlag.Name)
+	}
+	if ctx.IsSet(TxPoolMaxFutureNonceFlag.Name) {
+		cfg.TxPoolMaxFutureNonce = ctx.Uint64(TxPoolMaxFutureNonceFlag.Name)
+	}
+	if ctx.IsSet(RPCGetLogsMaxAddressesFlag.Name) {
+		cfg.FilterMaxAddresses = ctx.Int(RPCGetLogsMaxAddressesFlag.Name)
+	}
+	if ctx.IsSet(RPCGetLogsMaxRangeFlag.Name) {
+		cfg.FilterMaxBlockRange = ctx.Uint64(RPCGetLogsMaxRangeFlag.Name)
+	}
 	if ctx.IsSet(NoDiscoverFlag.Name) {
 		cfg.ZondDiscoveryURLs, cfg.SnapDiscoveryURLs = []string{}, []string{}
 	} else if ctx.IsSet(DNSDiscoveryFlag.Name) {
@@ -1581,17 +1886,23 @@ func SetZondConfig(ctx *cli.Context, stack *node.Node, cfg *zondconfig.Config) {
 			cfg.ZondDiscoveryURLs = SplitAndTrim(urls)
 		}
 	}
+	if ctx.IsSet(DialFailuresFlag.Name) {
+		cfg.DialFailures = ctx.Int(DialFailuresFlag.Name)
+	}
+	if ctx.IsSet(DialCooldownFlag.Name) {
+		cfg.DialCooldown = ctx.Duration(DialCooldownFlag.Name)
+	}
 	// Override any default configs for hard coded networks.
 	switch {
 	case ctx.Bool(MainnetFlag.Name):
 		if !ctx.IsSet(NetworkIdFlag.Name) {
-			cfg.NetworkId = 1
+			cfg.NetworkId = params.MainnetNetworkID
 		}
 		cfg.Genesis = core.DefaultGenesisBlock()
 		SetDNSDiscoveryDefaults(cfg, params.MainnetGenesisHash)
 	case ctx.Bool(BetaNetFlag.Name):
 		if !ctx.IsSet(NetworkIdFlag.Name) {
-			cfg.NetworkId = 2
+			cfg.NetworkId = params.BetaNetNetworkID
 		}
 		cfg.Genesis = core.DefaultBetaNetGenesisBlock()
 		SetDNSDiscoveryDefaults(cfg, params.BetaNetGenesisHash)
@@ -1694,7 +2005,7 @@ func RegisterZondStatsService(stack *node.Node, backend zondapi.Backend, url str
 
 // RegisterGraphQLService adds the GraphQL API to the node.
 func RegisterGraphQLService(stack *node.Node, backend zondapi.Backend, filterSystem *filters.FilterSystem, cfg *node.Config) {
-	err := graphql.New(stack, backend, filterSystem, cfg.GraphQLCors, cfg.GraphQLVirtualHosts)
+	err := graphql.New(stack, backend, filterSystem, cfg.GraphQLCors, cfg.GraphQLVirtualHosts, cfg.GraphQLNoIntrospection)
 	if err != nil {
 		Fatalf("Failed to register the GraphQL service: %v", err)
 	}
@@ -1703,11 +2014,17 @@ func RegisterGraphQLService(stack *node.Node, backend zondapi.Backend, filterSys
 // RegisterFilterAPI adds the zond log filtering RPC API to the node.
 func RegisterFilterAPI(stack *node.Node, backend zondapi.Backend, zondcfg *zondconfig.Config) *filters.FilterSystem {
 	filterSystem := filters.NewFilterSystem(backend, filters.Config{
-		LogCacheSize: zondcfg.FilterLogCacheSize,
+		LogCacheSize:  zondcfg.FilterLogCacheSize,
+		MaxAddresses:  zondcfg.FilterMaxAddresses,
+		MaxBlockRange: zondcfg.FilterMaxBlockRange,
 	})
+	filterAPI := filters.NewFilterAPI(filterSystem)
 	stack.RegisterAPIs([]rpc.API{{
 		Namespace: "zond",
-		Service:   filters.NewFilterAPI(filterSystem),
+		Service:   filterAPI,
+	}, {
+		Namespace: "admin",
+		Service:   filters.NewAdminAPI(filterAPI),
 	}})
 	return filterSystem
 }
@@ -1765,20 +2082,25 @@ func SetupMetrics(ctx *cli.Context) {
 			token        = ctx.String(MetricsInfluxDBTokenFlag.Name)
 			bucket       = ctx.String(MetricsInfluxDBBucketFlag.Name)
 			organization = ctx.String(MetricsInfluxDBOrganizationFlag.Name)
+
+			interval = ctx.Duration(MetricsIntervalFlag.Name)
 		)
+		if interval <= 0 {
+			Fatalf("Invalid --%s %v, must be positive", MetricsIntervalFlag.Name, interval)
+		}
 
 		if enableExport {
 			tagsMap := SplitTagsFlag(ctx.String(MetricsInfluxDBTagsFlag.Name))
 
 			log.Info("Enabling metrics export to InfluxDB")
 
-			go influxdb.InfluxDBWithTags(metrics.DefaultRegistry, 10*time.Second, endpoint, database, username, password, "gzond.", tagsMap)
+			go influxdb.InfluxDBWithTags(metrics.DefaultRegistry, interval, endpoint, database, username, password, "gzond.", tagsMap)
 		} else if enableExportV2 {
 			tagsMap := SplitTagsFlag(ctx.String(MetricsInfluxDBTagsFlag.Name))
 
 			log.Info("Enabling metrics export to InfluxDB (v2)")
 
-			go influxdb.InfluxDBV2WithTags(metrics.DefaultRegistry, 10*time.Second, endpoint, token, bucket, organization, "gzond.", tagsMap)
+			go influxdb.InfluxDBV2WithTags(metrics.DefaultRegistry, interval, endpoint, token, bucket, organization, "gzond.", tagsMap)
 		}
 
 		if ctx.IsSet(MetricsHTTPFlag.Name) {
@@ -1820,13 +2142,14 @@ func MakeChainDatabase(ctx *cli.Context, stack *node.Node, readonly bool) zonddb
 	switch {
 	case ctx.IsSet(RemoteDBFlag.Name):
 		log.Info("Using remote db", "url", ctx.String(RemoteDBFlag.Name), "headers", len(ctx.StringSlice(HttpHeaderFlag.Name)))
-		client, err := DialRPCWithHeaders(ctx.String(RemoteDBFlag.Name), ctx.StringSlice(HttpHeaderFlag.Name))
+		var client *rpc.Client
+		client, err = DialRPCWithHeaders(ctx.String(RemoteDBFlag.Name), ctx.StringSlice(HttpHeaderFlag.Name), ctx.Int(RemoteDBRetriesFlag.Name), ctx.Duration(RemoteDBRetryIntervalFlag.Name))
 		if err != nil {
 			break
 		}
 		chainDb = remotedb.New(client)
 	default:
-		chainDb, err = stack.OpenDatabaseWithFreezer("chaindata", cache, handles, ctx.String(AncientFlag.Name), "", readonly)
+		chainDb, err = stack.OpenDatabaseWithFreezer("chaindata", cache, handles, ctx.String(AncientFlag.Name), ctx.Uint64(AncientThresholdFlag.Name), "", readonly)
 	}
 	if err != nil {
 		Fatalf("Could not open database: %v", err)
@@ -1856,7 +2179,12 @@ func IsNetworkPreset(ctx *cli.Context) bool {
 	return false
 }
 
-func DialRPCWithHeaders(endpoint string, headers []string) (*rpc.Client, error) {
+// DialRPCWithHeaders connects to the given RPC endpoint, sending the custom HTTP
+// headers along with the requests. As HTTP clients don't connect on dial, the
+// endpoint is probed with an rpc_modules call. Failed dials or probes are retried
+// up to the given number of times, waiting the given interval before the first
+// retry and doubling it after each subsequent failure.
+func DialRPCWithHeaders(endpoint string, headers []string, retries int, interval time.Duration) (*rpc.Client, error) {
 	if endpoint == "" {
 		return nil, errors.New("endpoint must be specified")
 	}
@@ -1877,7 +2205,21 @@ func DialRPCWithHeaders(endpoint string, headers []string) (*rpc.Client, error)
 		}
 		opts = append(opts, rpc.WithHeaders(customHeaders))
 	}
-	return rpc.DialOptions(context.Background(), endpoint, opts...)
+	for attempt := 0; ; attempt++ {
+		client, err := rpc.DialOptions(context.Background(), endpoint, opts...)
+		if err == nil {
+			if _, err = client.SupportedModules(); err == nil {
+				return client, nil
+			}
+			client.Close()
+		}
+		if attempt >= retries {
+			return nil, err
+		}
+		log.Warn("Failed to dial RPC endpoint, retrying", "endpoint", endpoint, "attempt", attempt+1, "retries", retries, "wait", interval, "err", err)
+		time.Sleep(interval)
+		interval *= 2
+	}
 }
 
 func MakeGenesis(ctx *cli.Context) *core.Genesis {
@@ -1903,7 +2245,9 @@ func MakeChain(ctx *cli.Context, stack *node.Node, readonly bool) (*core.BlockCh
 	if err != nil {
 		Fatalf("%v", err)
 	}
-	engine, err := zondconfig.CreateConsensusEngine(config, chainDb)
+	// Chains opened by the offline commands always verify with the beacon engine,
+	// zondconfig.Config.ConsensusEngine only applies to in-process test nodes.
+	engine, err := zondconfig.CreateConsensusEngine(zondconfig.BeaconEngine, config, chainDb)
 	if err != nil {
 		Fatalf("%v", err)
 	}
@@ -1920,6 +2264,8 @@ func MakeChain(ctx *cli.Context, stack *node.Node, readonly bool) (*core.BlockCh
 		TrieDirtyLimit:      zondconfig.Defaults.TrieDirtyCache,
 		TrieDirtyDisabled:   ctx.String(GCModeFlag.Name) == "archive",
 		TrieTimeLimit:       zondconfig.Defaults.TrieTimeout,
+		TrieBlockInterval:   ctx.Uint64(CacheGCBlockIntervalFlag.Name),
+		TriePartialFlush:    !ctx.Bool(CacheGCFlushOnExitFlag.Name),
 		SnapshotLimit:       zondconfig.Defaults.SnapshotCache,
 		Preimages:           ctx.Bool(CachePreimagesFlag.Name),
 		StateScheme:         scheme,
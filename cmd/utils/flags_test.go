@@ -18,8 +18,15 @@
 package utils
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/theQRL/go-zond/rpc"
+	"github.com/theQRL/go-zond/zond/zondconfig"
 )
 
 func Test_SplitTagsFlag(t *testing.T) {
@@ -62,3 +69,143 @@ func Test_SplitTagsFlag(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseMethodTimeouts(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    string
+		want    map[string]time.Duration
+		wantErr bool
+	}{
+		{
+			"2 methods case",
+			"zond_call=5s, debug_traceTransaction = 1m",
+			map[string]time.Duration{
+				"zond_call":              5 * time.Second,
+				"debug_traceTransaction": time.Minute,
+			},
+			false,
+		},
+		{
+			"empty case",
+			"",
+			map[string]time.Duration{},
+			false,
+		},
+		{
+			"missing duration",
+			"zond_call",
+			nil,
+			true,
+		},
+		{
+			"invalid duration",
+			"zond_call=soon",
+			nil,
+			true,
+		},
+		{
+			"zero duration",
+			"zond_call=0s",
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMethodTimeouts(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseMethodTimeouts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseMethodTimeouts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_disableSnapshot(t *testing.T) {
+	cfg := &zondconfig.Config{
+		TrieCleanCache: 154,
+		SnapshotCache:  102,
+	}
+	disableSnapshot(cfg)
+	if cfg.SnapshotCache != 0 {
+		t.Errorf("snapshot cache not disabled: have %d, want 0", cfg.SnapshotCache)
+	}
+	if cfg.TrieCleanCache != 256 {
+		t.Errorf("trie clean cache mismatch: have %d, want 256", cfg.TrieCleanCache)
+	}
+}
+
+func TestDialRPCWithHeadersRetry(t *testing.T) {
+	t.Parallel()
+
+	// Reject the first connection attempt, accept the ones after
+	var (
+		srv      = rpc.NewServer()
+		attempts atomic.Int32
+	)
+	defer srv.Stop()
+
+	ws := srv.WebsocketHandler([]string{"*"})
+	httpsrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			http.Error(w, "not ready", http.StatusServiceUnavailable)
+			return
+		}
+		ws.ServeHTTP(w, r)
+	}))
+	defer httpsrv.Close()
+
+	endpoint := "ws://" + httpsrv.Listener.Addr().String()
+	if _, err := DialRPCWithHeaders(endpoint, nil, 0, time.Millisecond); err == nil {
+		t.Fatal("dial without retries succeeded on rejecting server")
+	}
+	attempts.Store(0)
+
+	client, err := DialRPCWithHeaders(endpoint, nil, 2, time.Millisecond)
+	if err != nil {
+		t.Fatalf("failed to dial with retries: %v", err)
+	}
+	defer client.Close()
+
+	if have := attempts.Load(); have != 2 {
+		t.Fatalf("dial attempts mismatch: have %d, want 2", have)
+	}
+}
+
+func TestDialRPCWithHeadersRetryHTTP(t *testing.T) {
+	t.Parallel()
+
+	// Fail the first few requests, serve the ones after
+	var (
+		srv      = rpc.NewServer()
+		requests atomic.Int32
+	)
+	defer srv.Stop()
+
+	httpsrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= 2 {
+			http.Error(w, "not ready", http.StatusServiceUnavailable)
+			return
+		}
+		srv.ServeHTTP(w, r)
+	}))
+	defer httpsrv.Close()
+
+	if _, err := DialRPCWithHeaders(httpsrv.URL, nil, 1, time.Millisecond); err == nil {
+		t.Fatal("dial with too few retries succeeded on failing server")
+	}
+	requests.Store(0)
+
+	client, err := DialRPCWithHeaders(httpsrv.URL, nil, 2, time.Millisecond)
+	if err != nil {
+		t.Fatalf("failed to dial with retries: %v", err)
+	}
+	defer client.Close()
+
+	if have := requests.Load(); have != 3 {
+		t.Fatalf("request count mismatch: have %d, want 3", have)
+	}
+}
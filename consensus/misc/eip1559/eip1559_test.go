@@ -88,6 +88,47 @@ func TestBlockGasLimits(t *testing.T) {
 	}
 }
 
+// TestBlockGasLimitsCustomDivisor tests that the gasLimit checks honour the
+// bound divisor configured in the chain config.
+func TestBlockGasLimitsCustomDivisor(t *testing.T) {
+	initial := new(big.Int).SetUint64(params.InitialBaseFee)
+
+	config := config()
+	config.GasLimitBoundDivisor = 256
+
+	for i, tc := range []struct {
+		pGasLimit uint64
+		gasLimit  uint64
+		ok        bool
+	}{
+		{20000000, 20019531, true},  // Upper limit +1 with the default divisor
+		{20000000, 20078124, true},  // Upper limit
+		{20000000, 20078125, false}, // Upper limit +1
+		{20000000, 19921876, true},  // Lower limit
+		{20000000, 19921875, false}, // Lower limit -1
+	} {
+		parent := &types.Header{
+			GasUsed:  tc.pGasLimit / 2,
+			GasLimit: tc.pGasLimit,
+			BaseFee:  initial,
+			Number:   big.NewInt(5),
+		}
+		header := &types.Header{
+			GasUsed:  tc.gasLimit / 2,
+			GasLimit: tc.gasLimit,
+			BaseFee:  initial,
+			Number:   big.NewInt(6),
+		}
+		err := VerifyEIP1559Header(config, parent, header)
+		if tc.ok && err != nil {
+			t.Errorf("test %d: Expected valid header: %s", i, err)
+		}
+		if !tc.ok && err == nil {
+			t.Errorf("test %d: Expected invalid header", i)
+		}
+	}
+}
+
 // TestCalcBaseFee assumes all blocks are 1559-blocks
 func TestCalcBaseFee(t *testing.T) {
 	tests := []struct {
@@ -24,13 +24,13 @@ import (
 
 // VerifyGaslimit verifies the header gas limit according increase/decrease
 // in relation to the parent gas limit.
-func VerifyGaslimit(parentGasLimit, headerGasLimit uint64) error {
+func VerifyGaslimit(config *params.ChainConfig, parentGasLimit, headerGasLimit uint64) error {
 	// Verify that the gas limit remains within allowed bounds
 	diff := int64(parentGasLimit) - int64(headerGasLimit)
 	if diff < 0 {
 		diff *= -1
 	}
-	limit := parentGasLimit / params.GasLimitBoundDivisor
+	limit := parentGasLimit / config.GasLimitDivisor()
 	if uint64(diff) >= limit {
 		return fmt.Errorf("invalid gas limit: have %d, want %d +-= %d", headerGasLimit, parentGasLimit, limit-1)
 	}
@@ -91,6 +91,7 @@ var (
 	errChainStopped         = errors.New("blockchain is stopped")
 	errInvalidOldChain      = errors.New("invalid old chain")
 	errInvalidNewChain      = errors.New("invalid new chain")
+	errCheckpointMismatch   = errors.New("block hash mismatches trusted checkpoint")
 )
 
 const (
@@ -134,10 +135,14 @@ type CacheConfig struct {
 	TrieDirtyLimit      int           // Memory limit (MB) at which to start flushing dirty trie nodes to disk
 	TrieDirtyDisabled   bool          // Whether to disable trie write caching and GC altogether (archive node)
 	TrieTimeLimit       time.Duration // Time limit after which to flush the current in-memory trie to disk
+	TrieBlockInterval   uint64        // Number of blocks after which to flush the current in-memory trie to disk (0 = disabled)
+	TriePartialFlush    bool          // Whether to only persist the head state on shutdown instead of several recent ones (hash scheme)
 	SnapshotLimit       int           // Memory allowance (MB) to use for caching snapshot entries in memory
+	SnapshotVerify      int           // Number of snapshot accounts to check against the state trie on startup (0 = disabled)
 	Preimages           bool          // Whether to store preimage of trie key to the disk
 	StateHistory        uint64        // Number of blocks from head whose state histories are reserved.
 	StateScheme         string        // Scheme used to store ethereum states and merkle tree nodes on top
+	SenderNonceIndex    bool          // Whether to index imported transactions by sender and nonce
 
 	SnapshotNoBuild bool // Whether the background generation is allowed
 	SnapshotWait    bool // Wait for snapshot construction on startup. TODO(karalabe): This is a dirty hack for testing, nuke it
@@ -432,10 +437,11 @@ func NewBlockChain(db zonddb.Database, cacheConfig *CacheConfig, genesis *Genesi
 			recover = true
 		}
 		snapconfig := snapshot.Config{
-			CacheSize:  bc.cacheConfig.SnapshotLimit,
-			Recovery:   recover,
-			NoBuild:    bc.cacheConfig.SnapshotNoBuild,
-			AsyncBuild: !bc.cacheConfig.SnapshotWait,
+			CacheSize:     bc.cacheConfig.SnapshotLimit,
+			Recovery:      recover,
+			NoBuild:       bc.cacheConfig.SnapshotNoBuild,
+			AsyncBuild:    !bc.cacheConfig.SnapshotWait,
+			VerifySamples: bc.cacheConfig.SnapshotVerify,
 		}
 		bc.snaps, _ = snapshot.New(snapconfig, bc.db, bc.triedb, head.Root)
 	}
@@ -612,6 +618,14 @@ func (bc *BlockChain) SetSafe(header *types.Header) {
 	}
 }
 
+// SetCheckpoint sets a trusted checkpoint. Imported headers proven to be
+// ancestors of it skip the consensus engine verification, and any header at
+// the checkpoint height with a different hash is rejected.
+func (bc *BlockChain) SetCheckpoint(number uint64, hash common.Hash) {
+	bc.hc.checkpoint.Store(newCheckpoint(number, hash))
+	log.Info("Configured trusted checkpoint", "number", number, "hash", hash)
+}
+
 // resetState resets the persistent state to genesis state if it's not present.
 func (bc *BlockChain) resetState() {
 	// Short circuit if the genesis state is already present.
@@ -929,6 +943,9 @@ func (bc *BlockChain) writeHeadBlock(block *types.Block) {
 	rawdb.WriteHeadFastBlockHash(batch, block.Hash())
 	rawdb.WriteCanonicalHash(batch, block.Hash(), block.NumberU64())
 	rawdb.WriteTxLookupEntriesByBlock(batch, block)
+	if bc.cacheConfig.SenderNonceIndex {
+		rawdb.WriteTxSenderNonceEntriesByBlock(batch, types.MakeSigner(bc.chainConfig), block)
+	}
 	rawdb.WriteHeadBlockHash(batch, block.Hash())
 
 	// Flush the whole batch into the disk, exit the node if failed
@@ -997,10 +1014,17 @@ func (bc *BlockChain) Stop() {
 		//  - HEAD:     So we don't need to reprocess any blocks in the general case
 		//  - HEAD-1:   So we don't do large reorgs if our HEAD becomes an uncle
 		//  - HEAD-127: So we have a hard limit on the number of blocks reexecuted
+		//
+		// A partial flush only writes HEAD for faster shutdowns, at the cost of
+		// more reprocessing if the head becomes an uncle.
 		if !bc.cacheConfig.TrieDirtyDisabled {
 			triedb := bc.triedb
 
-			for _, offset := range []uint64{0, 1, TriesInMemory - 1} {
+			offsets := []uint64{0, 1, TriesInMemory - 1}
+			if bc.cacheConfig.TriePartialFlush {
+				offsets = offsets[:1]
+			}
+			for _, offset := range offsets {
 				if number := bc.CurrentBlock().Number.Uint64(); number > offset {
 					recent := bc.GetBlockByNumber(number - offset)
 
@@ -1398,8 +1422,9 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 	// Find the next state trie we need to commit
 	chosen := current - TriesInMemory
 	flushInterval := time.Duration(bc.flushInterval.Load())
-	// If we exceeded time allowance, flush an entire trie to disk
-	if bc.gcproc > flushInterval {
+	// If we exceeded time or block allowance, flush an entire trie to disk
+	blockInterval := bc.cacheConfig.TrieBlockInterval
+	if bc.gcproc > flushInterval || (blockInterval > 0 && chosen >= bc.lastWrite+blockInterval) {
 		// If the header is missing (canonical chain behind), we're reorging a low
 		// diff sidechain. Suspend committing until this operation is completed.
 		header := bc.GetHeaderByNumber(chosen)
@@ -1552,7 +1577,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool) (int, error)
 	for i, block := range chain {
 		headers[i] = block.Header()
 	}
-	abort, results := bc.engine.VerifyHeaders(bc, headers)
+	abort, results := bc.hc.verifyHeaders(headers)
 	defer close(abort)
 
 	// Peek the error for the first block to decide the directing import logic
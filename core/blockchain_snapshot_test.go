@@ -27,11 +27,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/consensus"
 	"github.com/theQRL/go-zond/consensus/beacon"
 	"github.com/theQRL/go-zond/core/rawdb"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/core/vm"
+	"github.com/theQRL/go-zond/crypto"
 	"github.com/theQRL/go-zond/params"
 	"github.com/theQRL/go-zond/zonddb"
 )
@@ -712,3 +714,59 @@ func TestRecoverSnapshotFromWipingCrash(t *testing.T) {
 		test.teardown()
 	}
 }
+
+// Tests that a snapshot which went out of sync with the state trie (e.g. due to
+// an unclean shutdown) is detected on startup when verification is enabled, and
+// rebuilt from the trie.
+func TestSnapshotVerifyRebuild(t *testing.T) {
+	var (
+		db    = rawdb.NewMemoryDatabase()
+		alloc = make(GenesisAlloc)
+		gspec = &Genesis{
+			BaseFee: big.NewInt(params.InitialBaseFee),
+			Config:  params.AllBeaconProtocolChanges,
+			Alloc:   alloc,
+		}
+		engine = beacon.NewFullFaker()
+	)
+	for i := byte(1); i <= 8; i++ {
+		alloc[common.Address{i}] = GenesisAccount{Balance: big.NewInt(int64(i))}
+	}
+	chain, err := NewBlockChain(db, DefaultCacheConfigWithScheme(rawdb.HashScheme), gspec, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create chain: %v", err)
+	}
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 4, func(i int, b *BlockGen) {})
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("Failed to import chain: %v", err)
+	}
+	chain.Stop()
+
+	// Corrupt the snapshot entry of one of the accounts
+	hash := crypto.Keccak256Hash(common.Address{4}.Bytes())
+	want := rawdb.ReadAccountSnapshot(db, hash)
+	if len(want) == 0 {
+		t.Fatal("Account missing from snapshot")
+	}
+	rawdb.WriteAccountSnapshot(db, hash, types.SlimAccountRLP(types.StateAccount{
+		Balance:  big.NewInt(1000),
+		Root:     types.EmptyRootHash,
+		CodeHash: types.EmptyCodeHash.Bytes(),
+	}))
+
+	// Restart with verification enabled and ensure the snapshot is rebuilt
+	config := DefaultCacheConfigWithScheme(rawdb.HashScheme)
+	config.SnapshotVerify = len(alloc)
+	chain, err = NewBlockChain(db, config, gspec, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to recreate chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if have := rawdb.ReadAccountSnapshot(db, hash); !bytes.Equal(have, want) {
+		t.Fatalf("Snapshot entry not repaired: have %x, want %x", have, want)
+	}
+	if err := chain.snaps.Verify(chain.CurrentBlock().Root); err != nil {
+		t.Fatalf("Rebuilt snapshot invalid: %v", err)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"math/big"
 	"math/rand"
 	"os"
+	"reflect"
 	"sync"
 	"testing"
 	"time"
@@ -1681,6 +1682,282 @@ func TestTrieForkGC(t *testing.T) {
 	}
 }
 
+// Tests that the in-memory trie is flushed to disk once the configured number of
+// blocks has been processed, even if the time allowance has not been exceeded.
+func TestTrieBlockIntervalCommit(t *testing.T) {
+	var (
+		engine   = beacon.NewFaker()
+		interval = uint64(16)
+		genesis  = &Genesis{
+			Config:  params.TestChainConfig,
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+	)
+	_, blocks, _ := GenerateChainWithGenesis(genesis, engine, TriesInMemory+int(interval), func(i int, b *BlockGen) {
+		b.AddWithdrawal(&types.Withdrawal{Address: common.Address{1}, Amount: 1})
+	})
+	db := rawdb.NewMemoryDatabase()
+	config := DefaultCacheConfigWithScheme(rawdb.HashScheme)
+	config.TrieTimeLimit = time.Hour
+	config.TrieBlockInterval = interval
+
+	chain, err := NewBlockChain(db, config, genesis, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	// Import all but the last block, the block interval should not be reached yet
+	if _, err := chain.InsertChain(blocks[:len(blocks)-1]); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	root := blocks[interval-1].Root()
+	if rawdb.HasLegacyTrieNode(db, root) {
+		t.Fatalf("state of block %d flushed before reaching the interval", interval)
+	}
+	// Import the last block and ensure the state at the interval is persisted
+	if _, err := chain.InsertChain(blocks[len(blocks)-1:]); err != nil {
+		t.Fatalf("failed to insert last block: %v", err)
+	}
+	if !rawdb.HasLegacyTrieNode(db, root) {
+		t.Fatalf("state of block %d not flushed after reaching the interval", interval)
+	}
+}
+
+// verifyCountingEngine wraps a consensus engine, recording the numbers of all the
+// headers passed to it for verification.
+type verifyCountingEngine struct {
+	consensus.Engine
+	verified []uint64
+}
+
+func (e *verifyCountingEngine) VerifyHeaders(chain consensus.ChainHeaderReader, headers []*types.Header) (chan<- struct{}, <-chan error) {
+	for _, header := range headers {
+		e.verified = append(e.verified, header.Number.Uint64())
+	}
+	return e.Engine.VerifyHeaders(chain, headers)
+}
+
+// Tests that headers at or below a trusted checkpoint are not handed to the
+// consensus engine for verification, and that blocks conflicting with the
+// checkpoint are rejected.
+func TestCheckpointSkipsVerification(t *testing.T) {
+	genesis := &Genesis{
+		Config:  params.TestChainConfig,
+		BaseFee: big.NewInt(params.InitialBaseFee),
+	}
+	_, blocks, _ := GenerateChainWithGenesis(genesis, beacon.NewFaker(), 10, func(i int, b *BlockGen) {})
+
+	engine := &verifyCountingEngine{Engine: beacon.NewFaker()}
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, genesis, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	chain.SetCheckpoint(blocks[4].NumberU64(), blocks[4].Hash())
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	if have := chain.CurrentBlock().Number.Uint64(); have != blocks[9].NumberU64() {
+		t.Fatalf("head mismatch: have %d, want %d", have, blocks[9].NumberU64())
+	}
+	for _, number := range engine.verified {
+		if number <= blocks[4].NumberU64() {
+			t.Errorf("block %d below checkpoint was verified", number)
+		}
+	}
+	if have, want := len(engine.verified), 5; have != want {
+		t.Errorf("verified header count mismatch: have %d, want %d", have, want)
+	}
+	// A chain conflicting with the checkpoint must be rejected
+	chain, err = NewBlockChain(rawdb.NewMemoryDatabase(), nil, genesis, beacon.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	chain.SetCheckpoint(blocks[4].NumberU64(), common.Hash{0x01})
+	if n, err := chain.InsertChain(blocks); !errors.Is(err, errCheckpointMismatch) {
+		t.Fatalf("unexpected error: have %v, want %v", err, errCheckpointMismatch)
+	} else if n != 4 {
+		t.Fatalf("failed block index mismatch: have %d, want 4", n)
+	}
+}
+
+// Tests that only ancestors of a trusted checkpoint skip verification, so that a
+// side chain staying below the checkpoint is still verified, both when importing
+// blocks and headers.
+func TestCheckpointVerifiesSideChain(t *testing.T) {
+	genesis := &Genesis{
+		Config:  params.TestChainConfig,
+		BaseFee: big.NewInt(params.InitialBaseFee),
+	}
+	_, blocks, _ := GenerateChainWithGenesis(genesis, beacon.NewFaker(), 10, func(i int, b *BlockGen) {})
+	_, forks, _ := GenerateChainWithGenesis(genesis, beacon.NewFaker(), 5, func(i int, b *BlockGen) {
+		if i >= 2 {
+			b.SetCoinbase(common.Address{0x01})
+		}
+	})
+	if forks[1].Hash() != blocks[1].Hash() || forks[2].Hash() == blocks[2].Hash() {
+		t.Fatalf("side chain does not fork off after block 2")
+	}
+	var (
+		headers     = make([]*types.Header, len(blocks))
+		forkHeaders = make([]*types.Header, len(forks))
+	)
+	for i, block := range blocks {
+		headers[i] = block.Header()
+	}
+	for i, block := range forks {
+		forkHeaders[i] = block.Header()
+	}
+	// Headers at height 4 fail the engine verification, unless skipped
+	newChain := func() (*BlockChain, *verifyCountingEngine) {
+		engine := &verifyCountingEngine{Engine: beacon.NewFakeFailer(4)}
+		chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, genesis, engine, vm.Config{}, nil, nil)
+		if err != nil {
+			t.Fatalf("failed to create tester chain: %v", err)
+		}
+		chain.SetCheckpoint(blocks[7].NumberU64(), blocks[7].Hash())
+		return chain, engine
+	}
+	chain, engine := newChain()
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	if n, err := chain.InsertChain(forks[2:]); err == nil {
+		t.Fatal("side chain below the checkpoint accepted")
+	} else if n != 1 {
+		t.Fatalf("failed block index mismatch: have %d, want 1", n)
+	}
+	if have, want := engine.verified, []uint64{9, 10, 3, 4, 5}; !reflect.DeepEqual(have, want) {
+		t.Errorf("verified headers mismatch: have %v, want %v", have, want)
+	}
+	// Same rules apply to header-only imports
+	headerChain, engine := newChain()
+	defer headerChain.Stop()
+
+	if _, err := headerChain.InsertHeaderChain(headers); err != nil {
+		t.Fatalf("failed to insert header chain: %v", err)
+	}
+	if n, err := headerChain.InsertHeaderChain(forkHeaders[2:]); err == nil {
+		t.Fatal("side header chain below the checkpoint accepted")
+	} else if n != 1 {
+		t.Fatalf("failed header index mismatch: have %d, want 1", n)
+	}
+	if have, want := engine.verified, []uint64{9, 10, 3, 4, 5}; !reflect.DeepEqual(have, want) {
+		t.Errorf("verified headers mismatch: have %v, want %v", have, want)
+	}
+}
+
+// Tests that ancestors of a trusted checkpoint skip verification even if they are
+// imported in multiple batches below it, resolving the ancestry from the skeleton
+// headers downloaded by the beacon sync.
+func TestCheckpointSkeletonAncestors(t *testing.T) {
+	genesis := &Genesis{
+		Config:  params.TestChainConfig,
+		BaseFee: big.NewInt(params.InitialBaseFee),
+	}
+	_, blocks, _ := GenerateChainWithGenesis(genesis, beacon.NewFaker(), 10, func(i int, b *BlockGen) {})
+
+	headers := make([]*types.Header, len(blocks))
+	for i, block := range blocks {
+		headers[i] = block.Header()
+	}
+	// Headers at height 4 fail the engine verification, unless skipped
+	newChain := func(skeleton bool) (*BlockChain, *verifyCountingEngine) {
+		db := rawdb.NewMemoryDatabase()
+		if skeleton {
+			for _, header := range headers {
+				rawdb.WriteSkeletonHeader(db, header)
+			}
+		}
+		engine := &verifyCountingEngine{Engine: beacon.NewFakeFailer(4)}
+		chain, err := NewBlockChain(db, nil, genesis, engine, vm.Config{}, nil, nil)
+		if err != nil {
+			t.Fatalf("failed to create tester chain: %v", err)
+		}
+		chain.SetCheckpoint(blocks[7].NumberU64(), blocks[7].Hash())
+		return chain, engine
+	}
+	chain, engine := newChain(true)
+	defer chain.Stop()
+
+	for _, batch := range [][]*types.Header{headers[:3], headers[3:6], headers[6:]} {
+		if _, err := chain.InsertHeaderChain(batch); err != nil {
+			t.Fatalf("failed to insert header batch %d-%d: %v", batch[0].Number, batch[len(batch)-1].Number, err)
+		}
+	}
+	if have, want := engine.verified, []uint64{9, 10}; !reflect.DeepEqual(have, want) {
+		t.Errorf("verified headers mismatch: have %v, want %v", have, want)
+	}
+	// Without the skeleton, the ancestry of the checkpoint is unknown
+	chain, engine = newChain(false)
+	defer chain.Stop()
+
+	if _, err := chain.InsertHeaderChain(headers[:3]); err != nil {
+		t.Fatalf("failed to insert header batch: %v", err)
+	}
+	if _, err := chain.InsertHeaderChain(headers[3:6]); err == nil {
+		t.Fatal("unverified header batch accepted")
+	}
+	if have, want := engine.verified, []uint64{1, 2, 3, 4, 5, 6}; !reflect.DeepEqual(have, want) {
+		t.Errorf("verified headers mismatch: have %v, want %v", have, want)
+	}
+}
+
+// Tests that on shutdown either several recent states or only the head state are
+// persisted, depending on the configured flush behavior.
+func TestTriePartialFlushOnStop(t *testing.T) {
+	testTriePartialFlushOnStop(t, false)
+	testTriePartialFlushOnStop(t, true)
+}
+
+func testTriePartialFlushOnStop(t *testing.T, partial bool) {
+	var (
+		engine  = beacon.NewFaker()
+		genesis = &Genesis{
+			Config:  params.TestChainConfig,
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+	)
+	_, blocks, _ := GenerateChainWithGenesis(genesis, engine, TriesInMemory+2, func(i int, b *BlockGen) {
+		b.AddWithdrawal(&types.Withdrawal{Address: common.Address{1}, Amount: 1})
+	})
+	db := rawdb.NewMemoryDatabase()
+	config := DefaultCacheConfigWithScheme(rawdb.HashScheme)
+	config.TrieTimeLimit = time.Hour
+	config.SnapshotLimit = 0
+	config.TriePartialFlush = partial
+
+	chain, err := NewBlockChain(db, config, genesis, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	head := len(blocks) - 1
+	for _, offset := range []int{0, 1, TriesInMemory - 1} {
+		if rawdb.HasLegacyTrieNode(db, blocks[head-offset].Root()) {
+			t.Fatalf("partial %v: state of HEAD-%d flushed before shutdown", partial, offset)
+		}
+	}
+	chain.Stop()
+
+	if !rawdb.HasLegacyTrieNode(db, blocks[head].Root()) {
+		t.Errorf("partial %v: head state not flushed on shutdown", partial)
+	}
+	for _, offset := range []int{1, TriesInMemory - 1} {
+		if have := rawdb.HasLegacyTrieNode(db, blocks[head-offset].Root()); have == partial {
+			t.Errorf("partial %v: state of HEAD-%d flushed: have %v, want %v", partial, offset, have, !partial)
+		}
+	}
+}
+
 // Tests that doing large reorgs works even if the state associated with the
 // forking point is not available any more.
 func TestLargeReorgTrieGC(t *testing.T) {
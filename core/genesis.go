@@ -43,6 +43,10 @@ import (
 
 var errGenesisNoConfig = errors.New("genesis has no chain configuration")
 
+// errGenesisConfigChanged is returned if a chain parameter in effect since the
+// genesis block is changed on a chain which is already past it.
+var errGenesisConfigChanged = errors.New("genesis chain parameter changed, resync required")
+
 // Genesis specifies the header fields, state of a genesis block. It also defines hard
 // fork switch-over blocks through the chain configuration.
 type Genesis struct {
@@ -270,6 +274,8 @@ func (e *GenesisMismatchError) Error() string {
 // The stored chain configuration will be updated if it is compatible (i.e. does not
 // specify a fork block below the local head block). In case of a conflict, the
 // error is a *params.ConfigCompatError and the new, unwritten config is returned.
+// Changes to parameters in effect since genesis are rejected outright once the
+// chain has advanced past the genesis block.
 //
 // The returned chain configuration is never nil.
 func SetupGenesisBlock(db zonddb.Database, triedb *trie.Database, genesis *Genesis) (*params.ChainConfig, common.Hash, error) {
@@ -349,6 +355,9 @@ func SetupGenesisBlockWithOverride(db zonddb.Database, triedb *trie.Database, ge
 		return newcfg, stored, errors.New("missing head header")
 	}
 	compatErr := storedcfg.CheckCompatible(newcfg, head.Number.Uint64(), head.Time)
+	if compatErr != nil && compatErr.Genesis && head.Number.Uint64() != 0 {
+		return newcfg, stored, fmt.Errorf("%w: %v", errGenesisConfigChanged, compatErr)
+	}
 	if compatErr != nil && ((head.Number.Uint64() != 0 && compatErr.RewindToBlock != 0) || (head.Time != 0 && compatErr.RewindToTime != 0)) {
 		return newcfg, stored, compatErr
 	}
@@ -18,6 +18,7 @@ package core
 
 import (
 	"encoding/json"
+	"errors"
 	"math/big"
 	"reflect"
 	"testing"
@@ -179,6 +180,50 @@ func testSetupGenesis(t *testing.T, scheme string) {
 	}
 }
 
+// Tests that changing a chain parameter in effect since genesis is rejected on
+// a chain which already advanced past the genesis block.
+func TestSetupGenesisGenesisParamChange(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *params.ChainConfig
+	}{
+		{"gas limit bound divisor", &params.ChainConfig{GasLimitBoundDivisor: 2048}},
+		{"EF code prefix allowance", &params.ChainConfig{AllowEFCodePrefix: true}},
+		{"block hash window", &params.ChainConfig{BlockHashWindow: 8192}},
+	}
+	for _, test := range tests {
+		var (
+			db      = rawdb.NewMemoryDatabase()
+			tdb     = trie.NewDatabase(db, newDbConfig(rawdb.HashScheme))
+			genesis = &Genesis{Config: &params.ChainConfig{}}
+		)
+		genesis.MustCommit(db, tdb)
+
+		bc, _ := NewBlockChain(db, DefaultCacheConfigWithScheme(rawdb.HashScheme), genesis, beacon.NewFullFaker(), vm.Config{}, nil, nil)
+		_, blocks, _ := GenerateChainWithGenesis(genesis, beacon.NewFaker(), 4, nil)
+		if _, err := bc.InsertChain(blocks); err != nil {
+			t.Fatalf("%s: failed to insert chain: %v", test.name, err)
+		}
+		bc.Stop()
+
+		// Reopen the database with the changed parameter
+		changed := &Genesis{Config: test.config}
+		if _, _, err := SetupGenesisBlock(db, tdb, changed); !errors.Is(err, errGenesisConfigChanged) {
+			t.Errorf("%s: error mismatch: have %v, want %v", test.name, err, errGenesisConfigChanged)
+		}
+		if stored := rawdb.ReadChainConfig(db, genesis.ToBlock().Hash()); !reflect.DeepEqual(stored, genesis.Config) {
+			t.Errorf("%s: stored config overwritten: have %v, want %v", test.name, stored, genesis.Config)
+		}
+		// A database still at genesis may switch parameters freely
+		fresh := rawdb.NewMemoryDatabase()
+		freshdb := trie.NewDatabase(fresh, newDbConfig(rawdb.HashScheme))
+		genesis.MustCommit(fresh, freshdb)
+		if _, _, err := SetupGenesisBlock(fresh, freshdb, changed); err != nil {
+			t.Errorf("%s: failed to change parameter at genesis: %v", test.name, err)
+		}
+	}
+}
+
 // TestGenesisHashes checks the congruity of default genesis data to
 // corresponding hardcoded genesis hash values.
 func TestGenesisHashes(t *testing.T) {
@@ -23,6 +23,7 @@ import (
 	"math"
 	"math/big"
 	mrand "math/rand"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -40,6 +41,10 @@ import (
 const (
 	headerCacheLimit = 512
 	numberCacheLimit = 2048
+
+	// checkpointAnchorInterval is the number of blocks between two proven
+	// checkpoint ancestors remembered to shortcut subsequent ancestry checks.
+	checkpointAnchorInterval = 1024
 )
 
 // HeaderChain implements the basic block header chain logic. It is not usable in itself, only as
@@ -67,8 +72,28 @@ type HeaderChain struct {
 
 	procInterrupt func() bool
 
-	rand   *mrand.Rand
-	engine consensus.Engine
+	rand       *mrand.Rand
+	engine     consensus.Engine
+	checkpoint atomic.Pointer[checkpoint] // Trusted block whose ancestors are not verified
+}
+
+// checkpoint is a trusted block number -> hash mapping. Headers proven to be its
+// ancestors are accepted without running the consensus engine's verification.
+type checkpoint struct {
+	number uint64
+	hash   common.Hash
+
+	anchors map[uint64]common.Hash // Proven ancestors at every checkpointAnchorInterval'th block
+	lock    sync.Mutex             // Lock protecting the anchors
+}
+
+// newCheckpoint creates a trusted checkpoint with no proven ancestors yet.
+func newCheckpoint(number uint64, hash common.Hash) *checkpoint {
+	return &checkpoint{
+		number:  number,
+		hash:    hash,
+		anchors: make(map[uint64]common.Hash),
+	}
 }
 
 // NewHeaderChain creates a new HeaderChain structure. ProcInterrupt points
@@ -309,7 +334,7 @@ func (hc *HeaderChain) ValidateHeaderChain(chain []*types.Header) (int, error) {
 		}
 	}
 	// Start the parallel verifier
-	abort, results := hc.engine.VerifyHeaders(hc, chain)
+	abort, results := hc.verifyHeaders(chain)
 	defer close(abort)
 
 	// Iterate over the headers and ensure they all check out
@@ -328,6 +353,114 @@ func (hc *HeaderChain) ValidateHeaderChain(chain []*types.Header) (int, error) {
 	return 0, nil
 }
 
+// verifyHeaders starts the parallel header verification of a contiguous batch
+// of headers. Headers proven to be ancestors of the trusted checkpoint are not
+// verified, the rest is handed to the consensus engine. Headers at the height of
+// the checkpoint with a different hash are rejected.
+func (hc *HeaderChain) verifyHeaders(headers []*types.Header) (chan<- struct{}, <-chan error) {
+	cp := hc.checkpoint.Load()
+	if cp == nil || headers[0].Number.Uint64() > cp.number {
+		return hc.engine.VerifyHeaders(hc, headers)
+	}
+	var (
+		abort   = make(chan struct{})
+		results = make(chan error, len(headers))
+		trusted = hc.checkpointAncestors(cp, headers)
+	)
+	for i := 0; i < trusted; i++ {
+		results <- nil
+	}
+	if trusted == len(headers) {
+		return abort, results
+	}
+	// Verify the rest of the batch, serving the last trusted header as the
+	// parent as it may not be in the database yet
+	var (
+		rest   = headers[trusted:]
+		reader = consensus.ChainHeaderReader(hc)
+	)
+	if trusted > 0 {
+		reader = &parentHeaderReader{ChainHeaderReader: hc, parent: headers[trusted-1]}
+	}
+	engineAbort, engineResults := hc.engine.VerifyHeaders(reader, rest)
+	go func() {
+		defer close(engineAbort)
+		for _, header := range rest {
+			select {
+			case err := <-engineResults:
+				if header.Number.Uint64() == cp.number {
+					err = errCheckpointMismatch
+				}
+				results <- err
+			case <-abort:
+				return
+			}
+		}
+	}()
+	return abort, results
+}
+
+// checkpointAncestors returns the number of leading headers of the contiguous
+// batch which are ancestors of the checkpoint (or the checkpoint itself). The
+// ancestry is resolved from the local chain, falling back to the skeleton headers
+// downloaded by the beacon sync. If it is not known locally, no header is trusted.
+func (hc *HeaderChain) checkpointAncestors(cp *checkpoint, headers []*types.Header) int {
+	var (
+		first = headers[0].Number.Uint64()
+		last  = headers[len(headers)-1].Number.Uint64()
+
+		hash, number = cp.hash, cp.number
+	)
+	// Resolve the ancestor of the checkpoint at the height of the batch's last
+	// header, taking the canonical shortcut when possible, or otherwise starting
+	// from the closest ancestor proven by a previous batch
+	if number > last {
+		cp.lock.Lock()
+		defer cp.lock.Unlock()
+
+		if hc.GetCanonicalHash(number) == hash {
+			hash, number = hc.GetCanonicalHash(last), last
+		} else if anchor := (last + checkpointAnchorInterval - 1) / checkpointAnchorInterval * checkpointAnchorInterval; anchor < number {
+			if anchorHash, ok := cp.anchors[anchor]; ok {
+				hash, number = anchorHash, anchor
+			}
+		}
+		for number > last {
+			header := hc.GetHeader(hash, number)
+			if header == nil {
+				if header = rawdb.ReadSkeletonHeader(hc.chainDb, number); header == nil || header.Hash() != hash {
+					return 0
+				}
+			}
+			hash, number = header.ParentHash, number-1
+			if number%checkpointAnchorInterval == 0 {
+				cp.anchors[number] = hash
+			}
+		}
+	}
+	// The batch is contiguous, so every header before an ancestor is one too
+	if headers[number-first].Hash() != hash {
+		return 0
+	}
+	return int(number-first) + 1
+}
+
+// parentHeaderReader is a header reader additionally serving a parent header
+// which is not necessarily in the database yet.
+type parentHeaderReader struct {
+	consensus.ChainHeaderReader
+	parent *types.Header
+}
+
+// GetHeader retrieves a block header from the database by hash and number,
+// or the parent header if it matches.
+func (r *parentHeaderReader) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if number == r.parent.Number.Uint64() && hash == r.parent.Hash() {
+		return r.parent
+	}
+	return r.ChainHeaderReader.GetHeader(hash, number)
+}
+
 // InsertHeaderChain inserts the given headers and does the reorganisations.
 //
 // The validity of the headers is NOT CHECKED by this method, i.e. they need to be
@@ -93,6 +93,32 @@ func DeleteTxLookupEntries(db zonddb.KeyValueWriter, hashes []common.Hash) {
 	}
 }
 
+// ReadTxHashBySenderNonce retrieves the hash of the transaction sent by the given
+// account with the given nonce, if it was indexed.
+func ReadTxHashBySenderNonce(db zonddb.KeyValueReader, sender common.Address, nonce uint64) *common.Hash {
+	data, _ := db.Get(txSenderNonceKey(sender, nonce))
+	if len(data) != common.HashLength {
+		return nil
+	}
+	hash := common.BytesToHash(data)
+	return &hash
+}
+
+// WriteTxSenderNonceEntriesByBlock stores the hash of every transaction from a
+// block keyed by its sender and nonce, enabling lookups of mined transactions
+// by account. Transactions whose sender cannot be derived are skipped.
+func WriteTxSenderNonceEntriesByBlock(db zonddb.KeyValueWriter, signer types.Signer, block *types.Block) {
+	for _, tx := range block.Transactions() {
+		sender, err := types.Sender(signer, tx)
+		if err != nil {
+			continue
+		}
+		if err := db.Put(txSenderNonceKey(sender, tx.Nonce()), tx.Hash().Bytes()); err != nil {
+			log.Crit("Failed to store transaction sender nonce entry", "err", err)
+		}
+	}
+}
+
 // ReadTransaction retrieves a specific transaction from the database, along with
 // its added positional metadata.
 func ReadTransaction(db zonddb.Reader, hash common.Hash) (*types.Transaction, common.Hash, uint64, uint64) {
@@ -0,0 +1,94 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/params"
+)
+
+// Tests that the freezer moves blocks older than the configured threshold into
+// the ancient store, while keeping the recent ones in the key-value database.
+func TestChainFreezerThreshold(t *testing.T) {
+	const (
+		blocks    = 32
+		threshold = 8
+	)
+	db, err := newDatabaseWithFreezer(NewMemoryDatabase(), t.TempDir(), "", false, 0)
+	if err != nil {
+		t.Fatalf("failed to create database with ancient backend: %v", err)
+	}
+	defer db.Close()
+
+	// Configured thresholds are bounded from below, lower the limit directly to
+	// keep the test chain short
+	db.(*freezerdb).AncientStore.(*chainFreezer).threshold.Store(threshold)
+
+	var hashes []common.Hash
+	for i := uint64(0); i < blocks; i++ {
+		block := types.NewBlockWithHeader(&types.Header{
+			Number:      new(big.Int).SetUint64(i),
+			TxHash:      types.EmptyTxsHash,
+			ReceiptHash: types.EmptyReceiptsHash,
+		})
+		WriteBlock(db, block)
+		WriteReceipts(db, block.Hash(), i, nil)
+		WriteCanonicalHash(db, block.Hash(), i)
+		WriteHeadBlockHash(db, block.Hash())
+		hashes = append(hashes, block.Hash())
+	}
+	// Trigger a freeze cycle without overriding the configured threshold
+	trigger := make(chan struct{}, 1)
+	db.(*freezerdb).AncientStore.(*chainFreezer).trigger <- trigger
+	<-trigger
+
+	frozen, err := db.Ancients()
+	if err != nil {
+		t.Fatalf("failed to retrieve frozen item count: %v", err)
+	}
+	if want := uint64(blocks - threshold); frozen != want {
+		t.Fatalf("frozen block count mismatch: have %d, want %d", frozen, want)
+	}
+	for i, hash := range hashes {
+		number := uint64(i)
+		ancient, _ := db.HasAncient(ChainFreezerHashTable, number)
+		if want := number < frozen; ancient != want {
+			t.Errorf("block %d: ancient presence mismatch: have %v, want %v", number, ancient, want)
+		}
+		// Frozen blocks apart from the genesis are removed from the live database
+		live, _ := db.(*freezerdb).KeyValueStore.Has(headerKey(number, hash))
+		if want := number == 0 || number >= frozen; live != want {
+			t.Errorf("block %d: live presence mismatch: have %v, want %v", number, live, want)
+		}
+	}
+}
+
+// Tests that freezer thresholds below the immutability threshold are rejected.
+func TestChainFreezerThresholdMinimum(t *testing.T) {
+	if _, err := newDatabaseWithFreezer(NewMemoryDatabase(), t.TempDir(), "", false, params.FullImmutabilityThreshold-1); err == nil {
+		t.Fatal("freezer threshold below the minimum accepted")
+	}
+	db, err := newDatabaseWithFreezer(NewMemoryDatabase(), t.TempDir(), "", false, params.FullImmutabilityThreshold)
+	if err != nil {
+		t.Fatalf("failed to create database with minimum freezer threshold: %v", err)
+	}
+	db.Close()
+}
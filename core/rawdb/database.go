@@ -29,6 +29,7 @@ import (
 	"github.com/olekukonko/tablewriter"
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/log"
+	"github.com/theQRL/go-zond/params"
 	"github.com/theQRL/go-zond/zonddb"
 	"github.com/theQRL/go-zond/zonddb/leveldb"
 	"github.com/theQRL/go-zond/zonddb/memorydb"
@@ -89,57 +90,57 @@ type nofreezedb struct {
 
 // HasAncient returns an error as we don't have a backing chain freezer.
 func (db *nofreezedb) HasAncient(kind string, number uint64) (bool, error) {
-	return false, errNotSupported
+	return false, ErrNotSupported
 }
 
 // Ancient returns an error as we don't have a backing chain freezer.
 func (db *nofreezedb) Ancient(kind string, number uint64) ([]byte, error) {
-	return nil, errNotSupported
+	return nil, ErrNotSupported
 }
 
 // AncientRange returns an error as we don't have a backing chain freezer.
 func (db *nofreezedb) AncientRange(kind string, start, max, maxByteSize uint64) ([][]byte, error) {
-	return nil, errNotSupported
+	return nil, ErrNotSupported
 }
 
 // Ancients returns an error as we don't have a backing chain freezer.
 func (db *nofreezedb) Ancients() (uint64, error) {
-	return 0, errNotSupported
+	return 0, ErrNotSupported
 }
 
 // Tail returns an error as we don't have a backing chain freezer.
 func (db *nofreezedb) Tail() (uint64, error) {
-	return 0, errNotSupported
+	return 0, ErrNotSupported
 }
 
 // AncientSize returns an error as we don't have a backing chain freezer.
 func (db *nofreezedb) AncientSize(kind string) (uint64, error) {
-	return 0, errNotSupported
+	return 0, ErrNotSupported
 }
 
 // ModifyAncients is not supported.
 func (db *nofreezedb) ModifyAncients(func(zonddb.AncientWriteOp) error) (int64, error) {
-	return 0, errNotSupported
+	return 0, ErrNotSupported
 }
 
 // TruncateHead returns an error as we don't have a backing chain freezer.
 func (db *nofreezedb) TruncateHead(items uint64) (uint64, error) {
-	return 0, errNotSupported
+	return 0, ErrNotSupported
 }
 
 // TruncateTail returns an error as we don't have a backing chain freezer.
 func (db *nofreezedb) TruncateTail(items uint64) (uint64, error) {
-	return 0, errNotSupported
+	return 0, ErrNotSupported
 }
 
 // Sync returns an error as we don't have a backing chain freezer.
 func (db *nofreezedb) Sync() error {
-	return errNotSupported
+	return ErrNotSupported
 }
 
 func (db *nofreezedb) ReadAncients(fn func(reader zonddb.AncientReaderOp) error) (err error) {
 	// Unlike other ancient-related methods, this method does not return
-	// errNotSupported when invoked.
+	// ErrNotSupported when invoked.
 	// The reason for this is that the caller might want to do several things:
 	// 1. Check if something is in freezer,
 	// 2. If not, check leveldb.
@@ -147,7 +148,7 @@ func (db *nofreezedb) ReadAncients(fn func(reader zonddb.AncientReaderOp) error)
 	// This will work, since the ancient-checks inside 'fn' will return errors,
 	// and the leveldb work will continue.
 	//
-	// If we instead were to return errNotSupported here, then the caller would
+	// If we instead were to return ErrNotSupported here, then the caller would
 	// have to explicitly check for that, having an extra clause to do the
 	// non-ancient operations.
 	return fn(db)
@@ -156,12 +157,12 @@ func (db *nofreezedb) ReadAncients(fn func(reader zonddb.AncientReaderOp) error)
 // MigrateTable processes the entries in a given table in sequence
 // converting them to a new format if they're of an old format.
 func (db *nofreezedb) MigrateTable(kind string, convert convertLegacyFn) error {
-	return errNotSupported
+	return ErrNotSupported
 }
 
 // AncientDatadir returns an error as we don't have a backing chain freezer.
 func (db *nofreezedb) AncientDatadir() (string, error) {
-	return "", errNotSupported
+	return "", ErrNotSupported
 }
 
 // NewDatabase creates a high level database on top of a given key-value data
@@ -198,12 +199,26 @@ func resolveChainFreezerDir(ancient string) string {
 // storage. The passed ancient indicates the path of root ancient directory
 // where the chain freezer can be opened.
 func NewDatabaseWithFreezer(db zonddb.KeyValueStore, ancient string, namespace string, readonly bool) (zonddb.Database, error) {
+	return newDatabaseWithFreezer(db, ancient, namespace, readonly, 0)
+}
+
+// newDatabaseWithFreezer creates a high level database on top of a given key-value
+// data store with a freezer keeping the given number of recent blocks out of the
+// ancient store. A zero threshold selects params.FullImmutabilityThreshold, lower
+// non-zero thresholds are rejected as blocks that recent may still be reorged.
+func newDatabaseWithFreezer(db zonddb.KeyValueStore, ancient string, namespace string, readonly bool, threshold uint64) (zonddb.Database, error) {
+	if threshold != 0 && threshold < params.FullImmutabilityThreshold {
+		return nil, fmt.Errorf("freezer threshold %d below minimum %d", threshold, params.FullImmutabilityThreshold)
+	}
 	// Create the idle freezer instance
 	frdb, err := newChainFreezer(resolveChainFreezerDir(ancient), namespace, readonly)
 	if err != nil {
 		printChainMetadata(db)
 		return nil, err
 	}
+	if threshold != 0 {
+		frdb.threshold.Store(threshold)
+	}
 	// Since the freezer can be stored separately from the user's key-value database,
 	// there's a fairly high probability that the user requests invalid combinations
 	// of the freezer and database. Ensure that we don't shoot ourselves in the foot
@@ -352,6 +367,7 @@ type OpenOptions struct {
 	Cache             int    // the capacity(in megabytes) of the data caching
 	Handles           int    // number of files to be open simultaneously
 	ReadOnly          bool
+	FreezerThreshold  uint64 // number of recent blocks kept out of the freezer (0 = params.FullImmutabilityThreshold, lower values are rejected)
 	// Ephemeral means that filesystem sync operations should be avoided: data integrity in the face of
 	// a crash is not important. This option should typically be used in tests.
 	Ephemeral bool
@@ -410,7 +426,7 @@ func Open(o OpenOptions) (zonddb.Database, error) {
 	if len(o.AncientsDirectory) == 0 {
 		return kvdb, nil
 	}
-	frdb, err := NewDatabaseWithFreezer(kvdb, o.AncientsDirectory, o.Namespace, o.ReadOnly)
+	frdb, err := newDatabaseWithFreezer(kvdb, o.AncientsDirectory, o.Namespace, o.ReadOnly, o.FreezerThreshold)
 	if err != nil {
 		kvdb.Close()
 		return nil, err
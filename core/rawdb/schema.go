@@ -106,6 +106,8 @@ var (
 	CodePrefix            = []byte("c") // CodePrefix + code hash -> account code
 	skeletonHeaderPrefix  = []byte("S") // skeletonHeaderPrefix + num (uint64 big endian) -> header
 
+	txSenderNoncePrefix = []byte("sn") // txSenderNoncePrefix + address + nonce (uint64 big endian) -> transaction hash
+
 	// Path-based storage scheme of merkle patricia trie.
 	trieNodeAccountPrefix = []byte("A") // trieNodeAccountPrefix + hexPath -> trie node
 	trieNodeStoragePrefix = []byte("O") // trieNodeStoragePrefix + accountHash + hexPath -> trie node
@@ -180,6 +182,11 @@ func txLookupKey(hash common.Hash) []byte {
 	return append(txLookupPrefix, hash.Bytes()...)
 }
 
+// txSenderNonceKey = txSenderNoncePrefix + address + nonce (uint64 big endian)
+func txSenderNonceKey(sender common.Address, nonce uint64) []byte {
+	return append(append(txSenderNoncePrefix, sender.Bytes()...), encodeBlockNumber(nonce)...)
+}
+
 // accountSnapshotKey = SnapshotAccountPrefix + hash
 func accountSnapshotKey(hash common.Hash) []byte {
 	return append(SnapshotAccountPrefix, hash.Bytes()...)
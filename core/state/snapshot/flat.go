@@ -0,0 +1,203 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package snapshot
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/rlp"
+	"github.com/theQRL/go-zond/trie"
+	"github.com/theQRL/go-zond/zonddb"
+)
+
+// The flat state format is a stream of key/value records, each prefixed by the
+// uvarint encoded length of the key and of the value. Accounts are keyed by
+// their 32 byte hash and carry the slim RLP account, storage slots are keyed by
+// the 64 byte concatenation of the account and slot hashes and carry the RLP
+// encoded slot value. Accounts are emitted in hash order, each one followed by
+// all of its storage slots in hash order.
+
+// maxFlatRecordSize is the maximum length accepted for a single key or value
+// when reading a flat state stream, guarding against corrupted length prefixes.
+const maxFlatRecordSize = 1024 * 1024
+
+// ExportFlat writes the state identified by the given root in the flat format,
+// returning the number of accounts and storage slots written.
+func ExportFlat(t *Tree, root common.Hash, w io.Writer) (accounts uint64, slots uint64, err error) {
+	acctIt, err := t.AccountIterator(root, common.Hash{})
+	if err != nil {
+		return 0, 0, err
+	}
+	defer acctIt.Release()
+
+	out := bufio.NewWriter(w)
+	for acctIt.Next() {
+		hash, blob := acctIt.Hash(), acctIt.Account()
+		if err := writeFlatRecord(out, hash[:], blob); err != nil {
+			return 0, 0, err
+		}
+		accounts++
+
+		account, err := types.FullAccount(blob)
+		if err != nil {
+			return 0, 0, err
+		}
+		if account.Root == types.EmptyRootHash {
+			continue
+		}
+		storageIt, err := t.StorageIterator(root, hash, common.Hash{})
+		if err != nil {
+			return 0, 0, err
+		}
+		for storageIt.Next() {
+			slot := storageIt.Hash()
+			if err := writeFlatRecord(out, append(hash[:], slot[:]...), storageIt.Slot()); err != nil {
+				storageIt.Release()
+				return 0, 0, err
+			}
+			slots++
+		}
+		err = storageIt.Error()
+		storageIt.Release()
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	if err := acctIt.Error(); err != nil {
+		return 0, 0, err
+	}
+	return accounts, slots, out.Flush()
+}
+
+// ImportFlat reads a flat state stream, writes its accounts and storage slots
+// as snapshot entries into db and returns the state root recomputed from them.
+// The storage roots of the accounts are recomputed from the imported slots and
+// checked against the ones recorded in the accounts.
+func ImportFlat(r io.Reader, db zonddb.KeyValueWriter) (common.Hash, error) {
+	var (
+		in       = bufio.NewReader(r)
+		acctTrie = trie.NewStackTrie(nil)
+
+		account *types.StateAccount // Account whose storage slots are being read
+		owner   common.Hash         // Hash of the account being read
+		storage *trie.StackTrie     // Storage trie of the account being read
+	)
+	// flush finalizes the account being read and inserts it into the account trie.
+	flush := func() error {
+		if account == nil {
+			return nil
+		}
+		if root := storage.Hash(); root != account.Root {
+			return fmt.Errorf("storage root mismatch for account %x: have %x, want %x", owner, root, account.Root)
+		}
+		blob, err := rlp.EncodeToBytes(account)
+		if err != nil {
+			return err
+		}
+		account = nil
+		return acctTrie.Update(owner[:], blob)
+	}
+	for {
+		key, value, err := readFlatRecord(in)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return common.Hash{}, err
+		}
+		switch len(key) {
+		case common.HashLength:
+			if err := flush(); err != nil {
+				return common.Hash{}, err
+			}
+			if account, err = types.FullAccount(value); err != nil {
+				return common.Hash{}, err
+			}
+			owner = common.BytesToHash(key)
+			storage = trie.NewStackTrieWithOwner(nil, owner)
+			rawdb.WriteAccountSnapshot(db, owner, value)
+
+		case 2 * common.HashLength:
+			if account == nil || common.BytesToHash(key[:common.HashLength]) != owner {
+				return common.Hash{}, fmt.Errorf("storage slot %x without preceding account", key)
+			}
+			if err := storage.Update(key[common.HashLength:], value); err != nil {
+				return common.Hash{}, err
+			}
+			rawdb.WriteStorageSnapshot(db, owner, common.BytesToHash(key[common.HashLength:]), value)
+
+		default:
+			return common.Hash{}, fmt.Errorf("invalid flat state key length %d", len(key))
+		}
+	}
+	if err := flush(); err != nil {
+		return common.Hash{}, err
+	}
+	return acctTrie.Hash(), nil
+}
+
+// writeFlatRecord writes a single length-prefixed key/value record.
+func writeFlatRecord(w io.Writer, key, value []byte) error {
+	var buf [2 * binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], uint64(len(key)))
+	n += binary.PutUvarint(buf[n:], uint64(len(value)))
+	if _, err := w.Write(buf[:n]); err != nil {
+		return err
+	}
+	if _, err := w.Write(key); err != nil {
+		return err
+	}
+	_, err := w.Write(value)
+	return err
+}
+
+// readFlatRecord reads a single length-prefixed key/value record. It returns
+// io.EOF only if the stream ends cleanly before a new record.
+func readFlatRecord(r *bufio.Reader) ([]byte, []byte, error) {
+	keyLen, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	valLen, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, nil, unexpectedEOF(err)
+	}
+	if keyLen > maxFlatRecordSize || valLen > maxFlatRecordSize {
+		return nil, nil, fmt.Errorf("oversized flat state record: key %d, value %d", keyLen, valLen)
+	}
+	record := make([]byte, keyLen+valLen)
+	if _, err := io.ReadFull(r, record); err != nil {
+		return nil, nil, unexpectedEOF(err)
+	}
+	return record[:keyLen], record[keyLen:], nil
+}
+
+// unexpectedEOF converts a clean end of stream in the middle of a record into
+// io.ErrUnexpectedEOF.
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -19,6 +19,7 @@ package snapshot
 
 import (
 	"bytes"
+	crand "crypto/rand"
 	"errors"
 	"fmt"
 	"sync"
@@ -150,10 +151,11 @@ type snapshot interface {
 
 // Config includes the configurations for snapshots.
 type Config struct {
-	CacheSize  int  // Megabytes permitted to use for read caches
-	Recovery   bool // Indicator that the snapshots is in the recovery mode
-	NoBuild    bool // Indicator that the snapshots generation is disallowed
-	AsyncBuild bool // The snapshot generation is allowed to be constructed asynchronously
+	CacheSize     int  // Megabytes permitted to use for read caches
+	Recovery      bool // Indicator that the snapshots is in the recovery mode
+	NoBuild       bool // Indicator that the snapshots generation is disallowed
+	AsyncBuild    bool // The snapshot generation is allowed to be constructed asynchronously
+	VerifySamples int  // Number of accounts to check against the state trie after loading (0 = disabled)
 }
 
 // Tree is an Ethereum state snapshot tree. It consists of one persistent base
@@ -223,6 +225,16 @@ func New(config Config, diskdb zonddb.KeyValueStore, triedb *trie.Database, root
 		snap.layers[head.Root()] = head
 		head = head.Parent()
 	}
+	// Spot check the loaded snapshot against the state trie if requested, as an
+	// unclean shutdown might have left the two out of sync.
+	if config.VerifySamples > 0 && !config.Recovery {
+		if err := snap.VerifySample(root, config.VerifySamples); err != nil {
+			log.Warn("Snapshot inconsistent with state trie", "err", err)
+			if !config.NoBuild {
+				snap.Rebuild(root)
+			}
+		}
+	}
 	return snap, nil
 }
 
@@ -799,6 +811,59 @@ func (t *Tree) Verify(root common.Hash) error {
 	return nil
 }
 
+// VerifySample compares up to the given number of accounts of the snapshot with
+// the specific root against the state trie, starting at a random position. The
+// first account for which the two disagree is reported as an error. Accounts
+// not yet covered by a running generation are skipped.
+func (t *Tree) VerifySample(root common.Hash, samples int) error {
+	snap := t.Snapshot(root)
+	if snap == nil {
+		return fmt.Errorf("snapshot [%#x] missing", root)
+	}
+	tr, err := trie.NewStateTrie(trie.StateTrieID(root), t.triedb)
+	if err != nil {
+		return err
+	}
+	var start common.Hash
+	crand.Read(start[:])
+
+	// Iterate from the random start to the end of the trie, then wrap around to
+	// the beginning until reaching the start again.
+	var checked int
+	for _, seek := range []common.Hash{start, {}} {
+		nodeIt, err := tr.NodeIterator(seek[:])
+		if err != nil {
+			return err
+		}
+		it := trie.NewIterator(nodeIt)
+		for checked < samples && it.Next() {
+			hash := common.BytesToHash(it.Key)
+			if seek != start && bytes.Compare(hash[:], start[:]) >= 0 {
+				break
+			}
+			var account types.StateAccount
+			if err := rlp.DecodeBytes(it.Value, &account); err != nil {
+				return err
+			}
+			data, err := snap.AccountRLP(hash)
+			if errors.Is(err, ErrNotCoveredYet) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(data, types.SlimAccountRLP(account)) {
+				return fmt.Errorf("account %#x mismatch: snapshot %x, trie %x", hash, data, types.SlimAccountRLP(account))
+			}
+			checked++
+		}
+		if it.Err != nil {
+			return it.Err
+		}
+	}
+	return nil
+}
+
 // disklayer is an internal helper function to return the disk layer.
 // The lock of snapTree is assumed to be held already.
 func (t *Tree) disklayer() *diskLayer {
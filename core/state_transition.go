@@ -398,6 +398,9 @@ func (st *StateTransition) refundGas(refundQuotient uint64) {
 	if refund > st.state.GetRefund() {
 		refund = st.state.GetRefund()
 	}
+	if tracer, ok := st.evm.Config.Tracer.(vm.RefundLogger); ok {
+		tracer.CaptureRefund(st.state.GetRefund(), refund)
+	}
 	st.gasRemaining += refund
 
 	// Return ETH for remaining gas, exchanged at the original rate.
@@ -0,0 +1,82 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package legacypool
+
+import (
+	"sync"
+	"time"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/txpool"
+)
+
+// droppedLimit is the number of recently dropped transactions remembered by the
+// pool for diagnostic purposes.
+const droppedLimit = 1024
+
+// Reasons for transactions being dropped from the pool.
+const (
+	dropUnderpriced     = "underpriced"      // Evicted by better priced transactions or a tip increase
+	dropReplaced        = "replaced"         // Replaced by a transaction with the same nonce
+	dropExpired         = "expired"          // Queued for longer than the configured lifetime
+	dropNonceTooLow     = "nonce-too-low"    // Nonce already used by an included transaction
+	dropUnpayable       = "unpayable"        // Cost exceeds the balance or gas exceeds the block limit
+	dropPendingOverflow = "pending-overflow" // Evicted to keep the pending set within its limits
+	dropQueueOverflow   = "queue-overflow"   // Evicted to keep the queue within its limits
+)
+
+// droppedRing is a fixed size ring buffer of recently dropped transactions,
+// overwriting the oldest entries once full.
+type droppedRing struct {
+	items []*txpool.DroppedTx
+	next  int // Index of the slot to write next
+	size  int // Number of slots filled
+	lock  sync.Mutex
+}
+
+// newDroppedRing creates a ring buffer remembering up to limit transactions.
+func newDroppedRing(limit int) *droppedRing {
+	return &droppedRing{items: make([]*txpool.DroppedTx, limit)}
+}
+
+// add records a dropped transaction along with the reason of its removal.
+func (r *droppedRing) add(hash common.Hash, reason string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.items[r.next] = &txpool.DroppedTx{Hash: hash, Reason: reason, Time: time.Now()}
+	r.next = (r.next + 1) % len(r.items)
+	if r.size < len(r.items) {
+		r.size++
+	}
+}
+
+// last returns up to limit of the most recently dropped transactions, newest
+// first. A non-positive limit returns all remembered transactions.
+func (r *droppedRing) last(limit int) []*txpool.DroppedTx {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if limit <= 0 || limit > r.size {
+		limit = r.size
+	}
+	dropped := make([]*txpool.DroppedTx, 0, limit)
+	for i := 1; i <= limit; i++ {
+		dropped = append(dropped, r.items[(r.next-i+len(r.items))%len(r.items)])
+	}
+	return dropped
+}
@@ -44,14 +44,18 @@ func (*devNull) Close() error                      { return nil }
 // journal is a rotating log of transactions with the aim of storing locally
 // created transactions to allow non-executed ones to survive node restarts.
 type journal struct {
-	path   string         // Filesystem path to store the transactions at
-	writer io.WriteCloser // Output stream to write new transactions into
+	path    string         // Filesystem path to store the transactions at
+	writer  io.WriteCloser // Output stream to write new transactions into
+	size    uint64         // Current size of the journal file in bytes
+	live    uint64         // Size of the journal right after the last regeneration
+	maxSize uint64         // Size above which the journal should be rotated (0 = unlimited)
 }
 
 // newTxJournal creates a new transaction journal to
-func newTxJournal(path string) *journal {
+func newTxJournal(path string, maxSize uint64) *journal {
 	return &journal{
-		path: path,
+		path:    path,
+		maxSize: maxSize,
 	}
 }
 
@@ -122,12 +126,31 @@ func (journal *journal) insert(tx *types.Transaction) error {
 	if journal.writer == nil {
 		return errNoActiveJournal
 	}
-	if err := rlp.Encode(journal.writer, tx); err != nil {
+	blob, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		return err
+	}
+	if _, err := journal.writer.Write(blob); err != nil {
 		return err
 	}
+	journal.size += uint64(len(blob))
 	return nil
 }
 
+// oversized returns whether the live journal has grown beyond its configured
+// size limit and should be regenerated. To avoid rewriting the entire journal
+// on every insert when the live transaction set alone exceeds the limit, the
+// journal is only deemed oversized once it also doubled since the last rotation.
+func (journal *journal) oversized() bool {
+	if _, loading := journal.writer.(*devNull); loading {
+		return false
+	}
+	if journal.maxSize == 0 {
+		return false
+	}
+	return journal.size > journal.maxSize && journal.size > 2*journal.live
+}
+
 // rotate regenerates the transaction journal based on the current contents of
 // the transaction pool.
 func (journal *journal) rotate(all map[common.Address]types.Transactions) error {
@@ -163,7 +186,14 @@ func (journal *journal) rotate(all map[common.Address]types.Transactions) error
 	if err != nil {
 		return err
 	}
+	stat, err := sink.Stat()
+	if err != nil {
+		sink.Close()
+		return err
+	}
 	journal.writer = sink
+	journal.size = uint64(stat.Size())
+	journal.live = journal.size
 	log.Info("Regenerated local transaction journal", "transactions", journaled, "accounts", len(all))
 
 	return nil
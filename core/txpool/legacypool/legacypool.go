@@ -19,6 +19,7 @@ package legacypool
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"math/big"
 	"sort"
@@ -129,9 +130,14 @@ type Config struct {
 	Journal   string           // Journal of local transactions to survive node restarts
 	Rejournal time.Duration    // Time interval to regenerate the local transaction journal
 
+	JournalMaxSize uint64 // Journal size in bytes above which it is regenerated early, once also doubled since the last regeneration (0 = unlimited)
+	JournalStrict  bool   // Whether to drop journaled transactions failing current pricing rules on load
+
 	PriceLimit uint64 // Minimum gas price to enforce for acceptance into the pool
 	PriceBump  uint64 // Minimum price bump percentage to replace an already existing transaction (nonce)
 
+	MinFeeCapRatio uint64 // Minimum fee cap of remote transactions as a percentage of the pending base fee (0 = disabled)
+
 	AccountSlots uint64 // Number of executable transaction slots guaranteed per account
 	GlobalSlots  uint64 // Maximum number of executable transaction slots for all accounts
 	AccountQueue uint64 // Maximum number of non-executable transaction slots permitted per account
@@ -219,12 +225,15 @@ type LegacyPool struct {
 	locals  *accountSet // Set of local transaction to exempt from eviction rules
 	journal *journal    // Journal of local transaction to back up to disk
 
-	reserve txpool.AddressReserver       // Address reserver to ensure exclusivity across subpools
-	pending map[common.Address]*list     // All currently processable transactions
-	queue   map[common.Address]*list     // Queued but non-processable transactions
-	beats   map[common.Address]time.Time // Last heartbeat from each known account
-	all     *lookup                      // All transactions to allow lookups
-	priced  *pricedList                  // All transactions sorted by price
+	reserve  txpool.AddressReserver       // Address reserver to ensure exclusivity across subpools
+	pending  map[common.Address]*list     // All currently processable transactions
+	queue    map[common.Address]*list     // Queued but non-processable transactions
+	beats    map[common.Address]time.Time // Last heartbeat from each known account
+	all      *lookup                      // All transactions to allow lookups
+	priced   *pricedList                  // All transactions sorted by price
+	dropped  *droppedRing                 // Recently dropped transactions for diagnostics
+	replaced *replacedLog                 // Transactions successively occupying replaced slots
+	included map[common.Hash]struct{}     // Transactions included by the head of the running reset
 
 	reqResetCh      chan *txpoolResetRequest
 	reqPromoteCh    chan *accountSet
@@ -257,6 +266,8 @@ func New(config Config, chain BlockChain) *LegacyPool {
 		queue:           make(map[common.Address]*list),
 		beats:           make(map[common.Address]time.Time),
 		all:             newLookup(),
+		dropped:         newDroppedRing(droppedLimit),
+		replaced:        newReplacedLog(replacedSlotLimit),
 		reqResetCh:      make(chan *txpoolResetRequest),
 		reqPromoteCh:    make(chan *accountSet),
 		queueTxEventCh:  make(chan *types.Transaction),
@@ -272,7 +283,7 @@ func New(config Config, chain BlockChain) *LegacyPool {
 	pool.priced = newPricedList(pool.all)
 
 	if !config.NoLocals && config.Journal != "" {
-		pool.journal = newTxJournal(config.Journal)
+		pool.journal = newTxJournal(config.Journal, config.JournalMaxSize)
 	}
 	return pool
 }
@@ -307,7 +318,11 @@ func (pool *LegacyPool) Init(gasTip *big.Int, head *types.Header, reserve txpool
 
 	// If local transactions and journaling is enabled, load from disk
 	if pool.journal != nil {
-		if err := pool.journal.load(pool.addLocals); err != nil {
+		add := pool.addLocals
+		if pool.config.JournalStrict {
+			add = pool.addJournaled
+		}
+		if err := pool.journal.load(add); err != nil {
 			log.Warn("Failed to load transaction journal", "err", err)
 		}
 		if err := pool.journal.rotate(pool.local()); err != nil {
@@ -370,6 +385,7 @@ func (pool *LegacyPool) loop() {
 					list := pool.queue[addr].Flatten()
 					for _, tx := range list {
 						pool.removeTx(tx.Hash(), true, true)
+						pool.dropped.add(tx.Hash(), dropExpired)
 					}
 					queuedEvictionMeter.Mark(int64(len(list)))
 				}
@@ -433,6 +449,7 @@ func (pool *LegacyPool) SetGasTip(tip *big.Int) {
 		drop := pool.all.RemotesBelowTip(tip)
 		for _, tx := range drop {
 			pool.removeTx(tx.Hash(), false, true)
+			pool.dropped.add(tx.Hash(), dropUnderpriced)
 		}
 		pool.priced.Removed(len(drop))
 	}
@@ -555,6 +572,36 @@ func (pool *LegacyPool) Locals() []common.Address {
 	return pool.locals.flatten()
 }
 
+// AddLocal marks an account as local, migrating any of its pooled transactions
+// into the local set.
+func (pool *LegacyPool) AddLocal(addr common.Address) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if pool.locals.contains(addr) {
+		return
+	}
+	log.Info("Setting new local account", "address", addr)
+	pool.locals.add(addr)
+	pool.priced.Removed(pool.all.RemoteToLocals(pool.locals))
+}
+
+// RemoveLocal stops treating an account as local, demoting any of its pooled
+// transactions into the remote set where they are subject to eviction.
+func (pool *LegacyPool) RemoveLocal(addr common.Address) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if !pool.locals.contains(addr) {
+		return
+	}
+	log.Info("Removing local account", "address", addr)
+	pool.locals.remove(addr)
+	for _, tx := range pool.all.LocalsToRemotes(pool.locals) {
+		pool.priced.Put(tx, false)
+	}
+}
+
 // local retrieves all currently known local transactions, grouped by origin
 // account and sorted by nonce. The returned transaction set is a copy and can be
 // freely modified by calling code.
@@ -629,6 +676,15 @@ func (pool *LegacyPool) validateTx(tx *types.Transaction, local bool) error {
 	if err := txpool.ValidateTransactionWithState(tx, pool.signer, opts); err != nil {
 		return err
 	}
+	// Reject remote transactions that are too far below the base fee to be
+	// includable any time soon instead of letting them linger in the pool
+	if !local && pool.config.MinFeeCapRatio > 0 && pool.priced.urgent.baseFee != nil {
+		required := new(big.Int).Mul(pool.priced.urgent.baseFee, new(big.Int).SetUint64(pool.config.MinFeeCapRatio))
+		required.Div(required, big.NewInt(100))
+		if tx.GasFeeCapIntCmp(required) < 0 {
+			return fmt.Errorf("%w: fee cap %v, required %v", core.ErrFeeCapTooLow, tx.GasFeeCap(), required)
+		}
+	}
 	return nil
 }
 
@@ -739,6 +795,7 @@ func (pool *LegacyPool) add(tx *types.Transaction, local bool) (replaced bool, e
 
 			sender, _ := types.Sender(pool.signer, tx)
 			dropped := pool.removeTx(tx.Hash(), false, sender != from) // Don't unreserve the sender of the tx being added if last from the acc
+			pool.dropped.add(tx.Hash(), dropUnderpriced)
 
 			pool.changesSinceReorg += dropped
 		}
@@ -756,6 +813,8 @@ func (pool *LegacyPool) add(tx *types.Transaction, local bool) (replaced bool, e
 		if old != nil {
 			pool.all.Remove(old.Hash())
 			pool.priced.Removed(1)
+			pool.dropped.add(old.Hash(), dropReplaced)
+			pool.replaced.add(from, old, tx)
 			pendingReplaceMeter.Mark(1)
 		}
 		pool.all.Add(tx, isLocal)
@@ -831,6 +890,8 @@ func (pool *LegacyPool) enqueueTx(hash common.Hash, tx *types.Transaction, local
 	if old != nil {
 		pool.all.Remove(old.Hash())
 		pool.priced.Removed(1)
+		pool.dropped.add(old.Hash(), dropReplaced)
+		pool.replaced.add(from, old, tx)
 		queuedReplaceMeter.Mark(1)
 	} else {
 		// Nothing was replaced, bump the queued counter
@@ -862,6 +923,12 @@ func (pool *LegacyPool) journalTx(from common.Address, tx *types.Transaction) {
 	if err := pool.journal.insert(tx); err != nil {
 		log.Warn("Failed to journal local transaction", "err", err)
 	}
+	// Regenerate the journal early if stale entries bloated it past the limit
+	if pool.journal.oversized() {
+		if err := pool.journal.rotate(pool.local()); err != nil {
+			log.Warn("Failed to rotate local tx journal", "err", err)
+		}
+	}
 }
 
 // promoteTx adds a transaction to the pending (processable) list of transactions
@@ -887,6 +954,8 @@ func (pool *LegacyPool) promoteTx(addr common.Address, hash common.Hash, tx *typ
 	if old != nil {
 		pool.all.Remove(old.Hash())
 		pool.priced.Removed(1)
+		pool.dropped.add(old.Hash(), dropReplaced)
+		pool.replaced.add(addr, old, tx)
 		pendingReplaceMeter.Mark(1)
 	} else {
 		// Nothing was replaced, bump the pending counter
@@ -916,6 +985,60 @@ func (pool *LegacyPool) addLocal(tx *types.Transaction) error {
 	return errs[0]
 }
 
+// addJournaled enqueues a batch of journaled local transactions, rejecting the
+// ones that no longer satisfy the pool's current minimum tip or the pending
+// base fee. It is used instead of addLocals when strict journal loading is on.
+func (pool *LegacyPool) addJournaled(txs []*types.Transaction) []error {
+	var (
+		errs = make([]error, len(txs))
+		keep = make([]*types.Transaction, 0, len(txs))
+		idx  = make([]int, 0, len(txs))
+
+		tip     = pool.gasTip.Load()
+		baseFee *big.Int
+	)
+	if head := pool.currentHead.Load(); head.BaseFee != nil {
+		baseFee = eip1559.CalcBaseFee(pool.chainconfig, head)
+	}
+	for i, tx := range txs {
+		if tx.GasTipCapIntCmp(tip) < 0 {
+			errs[i] = fmt.Errorf("%w: tip needed %v, tip permitted %v", txpool.ErrUnderpriced, tip, tx.GasTipCap())
+			continue
+		}
+		if baseFee != nil && tx.GasFeeCapIntCmp(baseFee) < 0 {
+			errs[i] = fmt.Errorf("%w: fee cap %v below pending base fee %v", txpool.ErrUnderpriced, tx.GasFeeCap(), baseFee)
+			continue
+		}
+		keep = append(keep, tx)
+		idx = append(idx, i)
+	}
+	for i, err := range pool.addLocals(keep) {
+		errs[idx[i]] = err
+	}
+	return errs
+}
+
+// Validate checks whether a transaction would currently be accepted into the
+// pool, without adding it. On top of the admission rules, transactions whose fee
+// cap is below the base fee of the next block are rejected too.
+func (pool *LegacyPool) Validate(tx *types.Transaction, local bool) error {
+	if pool.all.Get(tx.Hash()) != nil {
+		return ErrAlreadyKnown
+	}
+	if err := pool.validateTxBasics(tx, local); err != nil {
+		return err
+	}
+	if head := pool.currentHead.Load(); head.BaseFee != nil {
+		if baseFee := eip1559.CalcBaseFee(pool.chainconfig, head); tx.GasFeeCapIntCmp(baseFee) < 0 {
+			return fmt.Errorf("%w: fee cap %v, base fee %v", core.ErrFeeCapTooLow, tx.GasFeeCap(), baseFee)
+		}
+	}
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	return pool.validateTx(tx, local || pool.locals.containsTx(tx))
+}
+
 // addRemotes enqueues a batch of transactions into the pool if they are valid. If the
 // senders are not among the locally tracked ones, full pricing constraints will apply.
 //
@@ -1032,6 +1155,20 @@ func (pool *LegacyPool) Status(hash common.Hash) txpool.TxStatus {
 	return txpool.TxStatusUnknown
 }
 
+// RecentlyDropped returns up to limit of the transactions most recently evicted
+// from the pool, newest first. A non-positive limit returns all the transactions
+// remembered.
+func (pool *LegacyPool) RecentlyDropped(limit int) []*txpool.DroppedTx {
+	return pool.dropped.last(limit)
+}
+
+// ReplacementHistory returns the transactions that successively occupied the
+// given account and nonce slot, oldest first. Nil is returned if no replacement
+// happened in the slot or if it has already been forgotten.
+func (pool *LegacyPool) ReplacementHistory(addr common.Address, nonce uint64) []*txpool.ReplacedTx {
+	return pool.replaced.history(addr, nonce)
+}
+
 // Get returns a transaction if it is contained in the pool and nil otherwise.
 func (pool *LegacyPool) Get(hash common.Hash) *types.Transaction {
 	tx := pool.get(hash)
@@ -1266,6 +1403,7 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 	// because of another transaction (e.g. higher gas price).
 	if reset != nil {
 		pool.demoteUnexecutables()
+		pool.included = nil
 		if reset.newHead != nil {
 			pendingBaseFee := eip1559.CalcBaseFee(pool.chainconfig, reset.newHead)
 			pool.priced.SetBaseFee(pendingBaseFee)
@@ -1307,8 +1445,9 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 // of the transaction pool is valid with regard to the chain state.
 func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 	// If we're reorging an old state, reinject all dropped transactions
-	var reinject types.Transactions
+	var reinject, included types.Transactions
 
+	pool.included = nil
 	if oldHead != nil && oldHead.Hash() != newHead.ParentHash {
 		// If the reorg is too deep, avoid doing it (will happen during fast sync)
 		oldNum := oldHead.Number.Uint64()
@@ -1346,7 +1485,7 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 					log.Warn("Transaction pool reset with missing new head", "number", newHead.Number, "hash", newHead.Hash())
 					return
 				}
-				var discarded, included types.Transactions
+				var discarded types.Transactions
 				for rem.NumberU64() > add.NumberU64() {
 					discarded = append(discarded, rem.Transactions()...)
 					if rem = pool.chain.GetBlock(rem.ParentHash(), rem.NumberU64()-1); rem == nil {
@@ -1382,6 +1521,10 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 				reinject = lost
 			}
 		}
+	} else if oldHead != nil {
+		if block := pool.chain.GetBlock(newHead.Hash(), newHead.Number.Uint64()); block != nil {
+			included = block.Transactions()
+		}
 	}
 	// Initialize the internal state to the current head
 	if newHead == nil {
@@ -1396,12 +1539,27 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 	pool.currentState = statedb
 	pool.pendingNonces = newNoncer(statedb)
 
+	// Remember the transactions included by the new head, so they aren't
+	// reported as dropped when removed from the pool
+	pool.included = make(map[common.Hash]struct{}, len(included))
+	for _, tx := range included {
+		pool.included[tx.Hash()] = struct{}{}
+	}
+
 	// Inject any transactions discarded due to reorgs
 	log.Debug("Reinjecting stale transactions", "count", len(reinject))
 	core.SenderCacher.Recover(pool.signer, reinject)
 	pool.addTxsLocked(reinject, false)
 }
 
+// dropStale records a transaction removed due to its nonce being already used,
+// unless it was included by the head of the running reset.
+func (pool *LegacyPool) dropStale(hash common.Hash) {
+	if _, ok := pool.included[hash]; !ok {
+		pool.dropped.add(hash, dropNonceTooLow)
+	}
+}
+
 // promoteExecutables moves transactions that have become processable from the
 // future queue to the set of pending transactions. During this process, all
 // invalidated transactions (low nonce, low balance) are deleted.
@@ -1421,6 +1579,7 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 		for _, tx := range forwards {
 			hash := tx.Hash()
 			pool.all.Remove(hash)
+			pool.dropStale(hash)
 		}
 		log.Trace("Removed old queued transactions", "count", len(forwards))
 		// Drop all transactions that are too costly (low balance or out of gas)
@@ -1428,6 +1587,7 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 		for _, tx := range drops {
 			hash := tx.Hash()
 			pool.all.Remove(hash)
+			pool.dropped.add(hash, dropUnpayable)
 		}
 		log.Trace("Removed unpayable queued transactions", "count", len(drops))
 		queuedNofundsMeter.Mark(int64(len(drops)))
@@ -1450,6 +1610,7 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 			for _, tx := range caps {
 				hash := tx.Hash()
 				pool.all.Remove(hash)
+				pool.dropped.add(hash, dropQueueOverflow)
 				log.Trace("Removed cap-exceeding queued transaction", "hash", hash)
 			}
 			queuedRateLimitMeter.Mark(int64(len(caps)))
@@ -1515,6 +1676,7 @@ func (pool *LegacyPool) truncatePending() {
 						// Drop the transaction from the global pools too
 						hash := tx.Hash()
 						pool.all.Remove(hash)
+						pool.dropped.add(hash, dropPendingOverflow)
 
 						// Update the account nonce to the dropped transaction
 						pool.pendingNonces.setIfLower(offenders[i], tx.Nonce())
@@ -1542,6 +1704,7 @@ func (pool *LegacyPool) truncatePending() {
 					// Drop the transaction from the global pools too
 					hash := tx.Hash()
 					pool.all.Remove(hash)
+					pool.dropped.add(hash, dropPendingOverflow)
 
 					// Update the account nonce to the dropped transaction
 					pool.pendingNonces.setIfLower(addr, tx.Nonce())
@@ -1589,6 +1752,7 @@ func (pool *LegacyPool) truncateQueue() {
 		if size := uint64(list.Len()); size <= drop {
 			for _, tx := range list.Flatten() {
 				pool.removeTx(tx.Hash(), true, true)
+				pool.dropped.add(tx.Hash(), dropQueueOverflow)
 			}
 			drop -= size
 			queuedRateLimitMeter.Mark(int64(size))
@@ -1598,6 +1762,7 @@ func (pool *LegacyPool) truncateQueue() {
 		txs := list.Flatten()
 		for i := len(txs) - 1; i >= 0 && drop > 0; i-- {
 			pool.removeTx(txs[i].Hash(), true, true)
+			pool.dropped.add(txs[i].Hash(), dropQueueOverflow)
 			drop--
 			queuedRateLimitMeter.Mark(1)
 		}
@@ -1622,6 +1787,7 @@ func (pool *LegacyPool) demoteUnexecutables() {
 		for _, tx := range olds {
 			hash := tx.Hash()
 			pool.all.Remove(hash)
+			pool.dropStale(hash)
 			log.Trace("Removed old pending transaction", "hash", hash)
 		}
 		// Drop all transactions that are too costly (low balance or out of gas), and queue any invalids back for later
@@ -1630,6 +1796,7 @@ func (pool *LegacyPool) demoteUnexecutables() {
 			hash := tx.Hash()
 			log.Trace("Removed unpayable pending transaction", "hash", hash)
 			pool.all.Remove(hash)
+			pool.dropped.add(hash, dropUnpayable)
 		}
 		pendingNofundsMeter.Mark(int64(len(drops)))
 
@@ -1720,6 +1887,12 @@ func (as *accountSet) add(addr common.Address) {
 	as.cache = nil
 }
 
+// remove deletes an address from the set.
+func (as *accountSet) remove(addr common.Address) {
+	delete(as.accounts, addr)
+	as.cache = nil
+}
+
 // addTx adds the sender of tx into the set.
 func (as *accountSet) addTx(tx *types.Transaction) {
 	if addr, err := types.Sender(as.signer, tx); err == nil {
@@ -1909,6 +2082,24 @@ func (t *lookup) RemoteToLocals(locals *accountSet) int {
 	return migrated
 }
 
+// LocalsToRemotes migrates the local transactions whose sender is no longer in
+// the given locals set back to the remote set, returning the moved transactions.
+// The assumption is held the locals set is thread-safe to be used.
+func (t *lookup) LocalsToRemotes(locals *accountSet) types.Transactions {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	var migrated types.Transactions
+	for hash, tx := range t.locals {
+		if !locals.containsTx(tx) {
+			t.remotes[hash] = tx
+			delete(t.locals, hash)
+			migrated = append(migrated, tx)
+		}
+	}
+	return migrated
+}
+
 // RemotesBelowTip finds all remote transactions below the given tip threshold.
 func (t *lookup) RemotesBelowTip(threshold *big.Int) types.Transactions {
 	found := make(types.Transactions, 0, 128)
@@ -38,6 +38,7 @@ import (
 	"github.com/theQRL/go-zond/crypto"
 	"github.com/theQRL/go-zond/event"
 	"github.com/theQRL/go-zond/params"
+	"github.com/theQRL/go-zond/rlp"
 	"github.com/theQRL/go-zond/trie"
 )
 
@@ -2040,6 +2041,36 @@ func TestDualHeapEviction(t *testing.T) {
 	}
 }
 
+// Tests that remote transactions with a fee cap too far below the pending base
+// fee are rejected at admission.
+func TestMinFeeCapRatio(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	testAddBalance(pool, key.GetAddress(), big.NewInt(1000000000))
+
+	pool.mu.Lock()
+	pool.config.MinFeeCapRatio = 50
+	pool.priced.SetBaseFee(big.NewInt(1000))
+	pool.mu.Unlock()
+
+	if err := pool.addRemoteSync(dynamicFeeTx(0, 100000, big.NewInt(1001), big.NewInt(1), key)); err != nil {
+		t.Fatalf("failed to add transaction above base fee: %v", err)
+	}
+	if err := pool.addRemoteSync(dynamicFeeTx(1, 100000, big.NewInt(499), big.NewInt(1), key)); !errors.Is(err, core.ErrFeeCapTooLow) {
+		t.Fatalf("adding transaction below fee cap ratio: have %v, want %v", err, core.ErrFeeCapTooLow)
+	}
+	// Local transactions are exempt from the admission check
+	if err := pool.addLocal(dynamicFeeTx(1, 100000, big.NewInt(499), big.NewInt(1), key)); err != nil {
+		t.Fatalf("failed to add local transaction below fee cap ratio: %v", err)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
 // Tests that the pool rejects duplicate transactions.
 func TestDeduplication(t *testing.T) {
 	t.Parallel()
@@ -2413,6 +2444,184 @@ func testJournaling(t *testing.T, nolocals bool) {
 	pool.Close()
 }
 
+// Tests that the local transaction journal is regenerated as soon as stale
+// entries push it past the configured size limit.
+func TestJournalMaxSize(t *testing.T) {
+	t.Parallel()
+
+	journal := fmt.Sprintf("%s/journal.rlp", t.TempDir())
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	key, _ := crypto.GenerateDilithiumKey()
+	blob, _ := rlp.EncodeToBytes(pricedTransaction(0, 100000, big.NewInt(1), key))
+	size := uint64(len(blob))
+
+	config := testTxPoolConfig
+	config.Journal = journal
+	config.JournalMaxSize = 2*size + size/2
+
+	pool := New(config, blockchain)
+	pool.Init(new(big.Int).SetUint64(config.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver())
+	defer pool.Close()
+
+	testAddBalance(pool, key.GetAddress(), big.NewInt(1000000000))
+
+	// Keep replacing the same local transaction, each replacement appends a new
+	// entry to the journal while the pool only retains the latest one
+	for price := int64(1); price <= 32; price *= 2 {
+		if err := pool.addLocal(pricedTransaction(0, 100000, big.NewInt(price), key)); err != nil {
+			t.Fatalf("failed to add local transaction at price %d: %v", price, err)
+		}
+		stat, err := os.Stat(journal)
+		if err != nil {
+			t.Fatalf("failed to stat journal: %v", err)
+		}
+		if uint64(stat.Size()) > config.JournalMaxSize {
+			t.Fatalf("journal not rotated at price %d: size %d, limit %d", price, stat.Size(), config.JournalMaxSize)
+		}
+	}
+}
+
+// Tests that the local transaction journal is not regenerated on every insert
+// once the live local set alone exceeds the configured size limit.
+func TestJournalMaxSizeHysteresis(t *testing.T) {
+	t.Parallel()
+
+	journal := fmt.Sprintf("%s/journal.rlp", t.TempDir())
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	key, _ := crypto.GenerateDilithiumKey()
+	blob, _ := rlp.EncodeToBytes(pricedTransaction(0, 100000, big.NewInt(1), key))
+	size := uint64(len(blob))
+
+	config := testTxPoolConfig
+	config.Journal = journal
+	config.JournalMaxSize = size / 2
+
+	pool := New(config, blockchain)
+	pool.Init(new(big.Int).SetUint64(config.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver())
+	defer pool.Close()
+
+	testAddBalance(pool, key.GetAddress(), big.NewInt(1000000000))
+
+	// Every transaction is live, so the journal may only be regenerated once it
+	// doubled since the last regeneration: after the 1st, 3rd and 7th insert
+	rotations := 0
+	for nonce := uint64(0); nonce < 8; nonce++ {
+		live := pool.journal.live
+		if err := pool.addLocal(pricedTransaction(nonce, 100000, big.NewInt(1), key)); err != nil {
+			t.Fatalf("failed to add local transaction %d: %v", nonce, err)
+		}
+		if pool.journal.live != live {
+			rotations++
+		}
+	}
+	if rotations != 3 {
+		t.Fatalf("journal rotation count mismatch: have %d, want %d", rotations, 3)
+	}
+}
+
+// Tests that strict journal loading drops journaled transactions which became
+// underpriced across a restart, while the default mode keeps re-queuing them.
+func TestJournalStrict(t *testing.T)    { testJournalStrict(t, true) }
+func TestJournalNonStrict(t *testing.T) { testJournalStrict(t, false) }
+
+func testJournalStrict(t *testing.T, strict bool) {
+	t.Parallel()
+
+	journal := fmt.Sprintf("%s/journal.rlp", t.TempDir())
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	config := testTxPoolConfig
+	config.Journal = journal
+	config.JournalStrict = strict
+
+	// Journal a cheap and an expensive local transaction from different accounts
+	pool := New(config, blockchain)
+	pool.Init(new(big.Int).SetUint64(config.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver())
+
+	cheap, _ := crypto.GenerateDilithiumKey()
+	pricey, _ := crypto.GenerateDilithiumKey()
+	testAddBalance(pool, cheap.GetAddress(), big.NewInt(1000000000))
+	testAddBalance(pool, pricey.GetAddress(), big.NewInt(1000000000))
+
+	underpriced := pricedTransaction(0, 100000, big.NewInt(1), cheap)
+	if err := pool.addLocal(underpriced); err != nil {
+		t.Fatalf("failed to add cheap local transaction: %v", err)
+	}
+	if err := pool.addLocal(pricedTransaction(0, 100000, big.NewInt(10), pricey)); err != nil {
+		t.Fatalf("failed to add pricey local transaction: %v", err)
+	}
+	pool.Close()
+
+	// Restart the pool with a higher minimum tip, making the cheap one underpriced
+	pool = New(config, blockchain)
+	pool.Init(big.NewInt(5), blockchain.CurrentBlock(), makeAddressReserver())
+	defer pool.Close()
+
+	want := 2
+	if strict {
+		want = 1
+	}
+	if pending, _ := pool.Stats(); pending != want {
+		t.Fatalf("pending transactions mismatched: have %d, want %d", pending, want)
+	}
+	if have := pool.all.Count(); have != want {
+		t.Fatalf("pooled transactions mismatched: have %d, want %d", have, want)
+	}
+	if strict && pool.Has(underpriced.Hash()) {
+		t.Fatalf("underpriced journaled transaction retained in strict mode")
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that accounts can be marked and unmarked as local at runtime, migrating
+// their pooled transactions accordingly.
+func TestAddRemoveLocal(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	addr := key.GetAddress()
+	testAddBalance(pool, addr, big.NewInt(1000000000))
+
+	if err := pool.addRemoteSync(transaction(0, 100000, key)); err != nil {
+		t.Fatalf("failed to add remote transaction: %v", err)
+	}
+	if locals := pool.Locals(); len(locals) != 0 {
+		t.Fatalf("unexpected locals: %v", locals)
+	}
+	pool.AddLocal(addr)
+	if locals := pool.Locals(); len(locals) != 1 || locals[0] != addr {
+		t.Fatalf("local account mismatch: have %v, want [%v]", locals, addr)
+	}
+	if have := pool.all.LocalCount(); have != 1 {
+		t.Fatalf("local transaction count mismatch: have %d, want %d", have, 1)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+	pool.RemoveLocal(addr)
+	if locals := pool.Locals(); len(locals) != 0 {
+		t.Fatalf("unexpected locals after removal: %v", locals)
+	}
+	if have := pool.all.RemoteCount(); have != 1 {
+		t.Fatalf("remote transaction count mismatch: have %d, want %d", have, 1)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
 // TestStatusCheck tests that the pool can correctly retrieve the
 // pending status of individual transactions.
 func TestStatusCheck(t *testing.T) {
@@ -2626,3 +2835,272 @@ func BenchmarkMultiAccountBatchInsert(b *testing.B) {
 		pool.addRemotesSync([]*types.Transaction{tx})
 	}
 }
+
+// Tests that validating a transaction reports whether it would be admitted,
+// rejects fee caps below the next base fee and leaves the pool untouched.
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	pool.currentHead.Store(&types.Header{
+		Number:   new(big.Int),
+		GasLimit: 10000000,
+		BaseFee:  big.NewInt(1000),
+	})
+	testAddBalance(pool, key.GetAddress(), big.NewInt(1000000000))
+
+	if err := pool.Validate(dynamicFeeTx(0, 100000, big.NewInt(1000), big.NewInt(1), key), false); err != nil {
+		t.Fatalf("valid transaction rejected: %v", err)
+	}
+	if err := pool.Validate(dynamicFeeTx(0, 100000, big.NewInt(100), big.NewInt(1), key), false); !errors.Is(err, core.ErrFeeCapTooLow) {
+		t.Fatalf("underpriced transaction error mismatch: have %v, want %v", err, core.ErrFeeCapTooLow)
+	}
+	if count := pool.all.Count(); count != 0 {
+		t.Fatalf("validated transactions added to the pool: have %d, want 0", count)
+	}
+}
+
+// Tests that transactions evicted from the pool are remembered along with the
+// reason of their removal.
+func TestRecentlyDropped(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	testAddBalance(pool, key.GetAddress(), big.NewInt(1000000000))
+
+	// Replace both a pending and a queued transaction
+	pending := pricedTransaction(0, 100000, big.NewInt(1), key)
+	if err := pool.addRemoteSync(pending); err != nil {
+		t.Fatalf("failed to add pending transaction: %v", err)
+	}
+	if err := pool.addRemoteSync(pricedTransaction(0, 100000, big.NewInt(2), key)); err != nil {
+		t.Fatalf("failed to replace pending transaction: %v", err)
+	}
+	queued := pricedTransaction(5, 100000, big.NewInt(1), key)
+	if err := pool.addRemoteSync(queued); err != nil {
+		t.Fatalf("failed to add queued transaction: %v", err)
+	}
+	if err := pool.addRemoteSync(pricedTransaction(5, 100000, big.NewInt(2), key)); err != nil {
+		t.Fatalf("failed to replace queued transaction: %v", err)
+	}
+	dropped := pool.RecentlyDropped(0)
+	if len(dropped) != 2 {
+		t.Fatalf("dropped transaction count mismatch: have %d, want 2", len(dropped))
+	}
+	for i, want := range []common.Hash{queued.Hash(), pending.Hash()} {
+		if dropped[i].Hash != want {
+			t.Errorf("dropped transaction %d: hash mismatch: have %x, want %x", i, dropped[i].Hash, want)
+		}
+		if dropped[i].Reason != dropReplaced {
+			t.Errorf("dropped transaction %d: reason mismatch: have %s, want %s", i, dropped[i].Reason, dropReplaced)
+		}
+	}
+	if dropped := pool.RecentlyDropped(1); len(dropped) != 1 || dropped[0].Hash != queued.Hash() {
+		t.Fatalf("limited dropped transactions mismatch: have %v", dropped)
+	}
+	// Ensure the oldest entries are overwritten once the buffer is full
+	ring := newDroppedRing(2)
+	for i := byte(1); i <= 3; i++ {
+		ring.add(common.Hash{i}, dropExpired)
+	}
+	if have := ring.last(0); len(have) != 2 || have[0].Hash != (common.Hash{3}) || have[1].Hash != (common.Hash{2}) {
+		t.Fatalf("ring buffer content mismatch: have %v", have)
+	}
+}
+
+// Tests that transactions evicted due to pool overflows or insufficient funds
+// are remembered along with the reason of their removal.
+func TestRecentlyDroppedEvictions(t *testing.T) {
+	t.Parallel()
+
+	// Create a pool with tight global limits
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	config := testTxPoolConfig
+	config.GlobalSlots = config.AccountSlots
+	config.GlobalQueue = 4
+
+	pool := New(config, blockchain)
+	pool.Init(new(big.Int).SetUint64(config.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver())
+	defer pool.Close()
+
+	keys := make([]*dilithium.Dilithium, 3)
+	for i := 0; i < len(keys); i++ {
+		keys[i], _ = crypto.GenerateDilithiumKey()
+		testAddBalance(pool, keys[i].GetAddress(), big.NewInt(10000000))
+	}
+	// Overflow the pending slots from a single account
+	txs := types.Transactions{}
+	for i := uint64(0); i < config.AccountSlots+2; i++ {
+		txs = append(txs, transaction(i, 100000, keys[0]))
+	}
+	pool.addRemotesSync(txs)
+
+	// Overflow the global queue from another account
+	txs = types.Transactions{}
+	for i := uint64(1); i <= config.GlobalQueue+2; i++ {
+		txs = append(txs, transaction(i, 100000, keys[1]))
+	}
+	pool.addRemotesSync(txs)
+
+	// Drain the balance of an account with a pending transaction
+	unpayable := transaction(0, 100000, keys[2])
+	if err := pool.addRemoteSync(unpayable); err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+	testAddBalance(pool, keys[2].GetAddress(), big.NewInt(-10000000))
+	<-pool.requestReset(nil, nil)
+
+	reasons := make(map[string]int)
+	for _, tx := range pool.RecentlyDropped(0) {
+		reasons[tx.Reason]++
+	}
+	if have := reasons[dropPendingOverflow]; have != 2 {
+		t.Errorf("pending overflow drop count mismatch: have %d, want %d", have, 2)
+	}
+	if have := reasons[dropQueueOverflow]; have != 2 {
+		t.Errorf("queue overflow drop count mismatch: have %d, want %d", have, 2)
+	}
+	if have := reasons[dropUnpayable]; have != 1 {
+		t.Errorf("unpayable drop count mismatch: have %d, want %d", have, 1)
+	}
+	if dropped := pool.RecentlyDropped(1); len(dropped) != 1 || dropped[0].Hash != unpayable.Hash() {
+		t.Errorf("latest dropped transaction mismatch: have %v, want %x", dropped, unpayable.Hash())
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// includingBlockChain is a test chain whose every block contains the same set
+// of transactions.
+type includingBlockChain struct {
+	*testBlockChain
+	txs types.Transactions
+}
+
+func (bc *includingBlockChain) GetBlock(hash common.Hash, number uint64) *types.Block {
+	return types.NewBlock(bc.CurrentBlock(), bc.txs, nil, trie.NewStackTrie(nil))
+}
+
+// Tests that transactions included by a new chain head are not reported as
+// dropped, while those invalidated by a foreign transaction are.
+func TestRecentlyDroppedIncluded(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := &includingBlockChain{testBlockChain: newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))}
+
+	pool := New(testTxPoolConfig, blockchain)
+	pool.Init(new(big.Int).SetUint64(testTxPoolConfig.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver())
+	defer pool.Close()
+
+	key, _ := crypto.GenerateDilithiumKey()
+	addr := key.GetAddress()
+	testAddBalance(pool, addr, big.NewInt(1000000))
+
+	included := transaction(0, 100000, key)
+	stale := transaction(1, 100000, key)
+	pool.addRemotesSync([]*types.Transaction{included, stale})
+	if pending, _ := pool.Stats(); pending != 2 {
+		t.Fatalf("pending transactions mismatched: have %d, want %d", pending, 2)
+	}
+	// Include the first transaction in a new head, along with a foreign one
+	// using up the nonce of the second
+	blockchain.txs = types.Transactions{included}
+	testSetNonce(pool, addr, 2)
+
+	oldHead := blockchain.CurrentBlock()
+	newHead := &types.Header{
+		ParentHash: oldHead.Hash(),
+		Number:     big.NewInt(1),
+		GasLimit:   oldHead.GasLimit,
+		BaseFee:    new(big.Int),
+	}
+	<-pool.requestReset(oldHead, newHead)
+
+	if pending, _ := pool.Stats(); pending != 0 {
+		t.Fatalf("pending transactions mismatched: have %d, want %d", pending, 0)
+	}
+	dropped := pool.RecentlyDropped(0)
+	if len(dropped) != 1 {
+		t.Fatalf("dropped transaction count mismatch: have %d, want 1", len(dropped))
+	}
+	if dropped[0].Hash != stale.Hash() || dropped[0].Reason != dropNonceTooLow {
+		t.Errorf("dropped transaction mismatch: have %x (%s), want %x (%s)", dropped[0].Hash, dropped[0].Reason, stale.Hash(), dropNonceTooLow)
+	}
+}
+
+// Tests that every transaction occupying a nonce slot is recorded in the slot's
+// replacement history, ordered by the time it entered the pool.
+func TestReplacementHistory(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	addr := key.GetAddress()
+	testAddBalance(pool, addr, big.NewInt(1000000000))
+
+	// Replace a pending transaction twice
+	txs := []*types.Transaction{
+		pricedTransaction(0, 100000, big.NewInt(1), key),
+		pricedTransaction(0, 100000, big.NewInt(2), key),
+		pricedTransaction(0, 100000, big.NewInt(3), key),
+	}
+	for i, tx := range txs {
+		if err := pool.addRemoteSync(tx); err != nil {
+			t.Fatalf("failed to add transaction %d: %v", i, err)
+		}
+	}
+	history := pool.ReplacementHistory(addr, 0)
+	if len(history) != len(txs) {
+		t.Fatalf("replacement history length mismatch: have %d, want %d", len(history), len(txs))
+	}
+	for i, tx := range txs {
+		if history[i].Hash != tx.Hash() {
+			t.Errorf("replacement %d: hash mismatch: have %x, want %x", i, history[i].Hash, tx.Hash())
+		}
+		if i > 0 && history[i].Time.Before(history[i-1].Time) {
+			t.Errorf("replacement %d: time %v before previous %v", i, history[i].Time, history[i-1].Time)
+		}
+	}
+	// Slots without replacements have no history
+	if history := pool.ReplacementHistory(addr, 1); history != nil {
+		t.Errorf("unexpected history for unreplaced slot: %v", history)
+	}
+}
+
+// Tests that the per-account content of the pool is returned ordered by nonce,
+// regardless of the order the transactions arrived in.
+func TestContentFromOrdering(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	testAddBalance(pool, key.GetAddress(), big.NewInt(1000000000))
+
+	for _, nonce := range []uint64{2, 0, 5, 3, 1} {
+		if err := pool.addRemoteSync(transaction(nonce, 100000, key)); err != nil {
+			t.Fatalf("failed to add transaction %d: %v", nonce, err)
+		}
+	}
+	pending, queued := pool.ContentFrom(key.GetAddress())
+	if len(pending) != 4 {
+		t.Fatalf("pending transaction count mismatch: have %d, want 4", len(pending))
+	}
+	for i, tx := range pending {
+		if tx.Nonce() != uint64(i) {
+			t.Errorf("pending transaction %d: nonce mismatch: have %d, want %d", i, tx.Nonce(), i)
+		}
+	}
+	if len(queued) != 1 || queued[0].Nonce() != 5 {
+		t.Fatalf("queued transactions mismatch: have %v", queued)
+	}
+}
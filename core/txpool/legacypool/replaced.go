@@ -0,0 +1,81 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package legacypool
+
+import (
+	"sync"
+	"time"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/common/lru"
+	"github.com/theQRL/go-zond/core/txpool"
+	"github.com/theQRL/go-zond/core/types"
+)
+
+const (
+	// replacedSlotLimit is the number of (account, nonce) slots whose replacement
+	// history is remembered by the pool.
+	replacedSlotLimit = 1024
+
+	// replacedDepthLimit is the maximum number of transactions remembered for a
+	// single slot, the oldest ones being forgotten first.
+	replacedDepthLimit = 16
+)
+
+// replacedSlot identifies a transaction slot by its sender and nonce.
+type replacedSlot struct {
+	addr  common.Address
+	nonce uint64
+}
+
+// replacedLog is a bounded record of the transactions that successively occupied
+// the (account, nonce) slots in which replacements happened.
+type replacedLog struct {
+	slots lru.BasicLRU[replacedSlot, []*txpool.ReplacedTx]
+	lock  sync.Mutex
+}
+
+// newReplacedLog creates a log remembering the history of up to limit slots.
+func newReplacedLog(limit int) *replacedLog {
+	return &replacedLog{slots: lru.NewBasicLRU[replacedSlot, []*txpool.ReplacedTx](limit)}
+}
+
+// add records that tx replaced old in the slot of the given account.
+func (l *replacedLog) add(addr common.Address, old, tx *types.Transaction) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	slot := replacedSlot{addr: addr, nonce: tx.Nonce()}
+	history, ok := l.slots.Get(slot)
+	if !ok {
+		history = []*txpool.ReplacedTx{{Hash: old.Hash(), Time: old.Time()}}
+	}
+	history = append(history, &txpool.ReplacedTx{Hash: tx.Hash(), Time: time.Now()})
+	if len(history) > replacedDepthLimit {
+		history = history[len(history)-replacedDepthLimit:]
+	}
+	l.slots.Add(slot, history)
+}
+
+// history returns the transactions that occupied the given slot, oldest first.
+func (l *replacedLog) history(addr common.Address, nonce uint64) []*txpool.ReplacedTx {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	history, _ := l.slots.Peek(replacedSlot{addr: addr, nonce: nonce})
+	return append([]*txpool.ReplacedTx(nil), history...)
+}
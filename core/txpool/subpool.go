@@ -48,6 +48,21 @@ func (ltx *LazyTransaction) Resolve() *types.Transaction {
 	return ltx.Tx
 }
 
+// DroppedTx is a transaction recently evicted from a subpool, along with the
+// reason of its removal.
+type DroppedTx struct {
+	Hash   common.Hash `json:"hash"`
+	Reason string      `json:"reason"`
+	Time   time.Time   `json:"time"`
+}
+
+// ReplacedTx is a transaction which occupied an (account, nonce) slot of a
+// subpool, along with the time it took over the slot.
+type ReplacedTx struct {
+	Hash common.Hash `json:"hash"`
+	Time time.Time   `json:"time"`
+}
+
 // AddressReserver is passed by the main transaction pool to subpools, so they
 // may request (and relinquish) exclusive access to certain addresses.
 type AddressReserver func(addr common.Address, reserve bool) error
@@ -95,6 +110,10 @@ type SubPool interface {
 	// to a later point to batch multiple ones together.
 	Add(txs []*types.Transaction, local bool, sync bool) []error
 
+	// Validate checks whether a transaction would currently be accepted by the
+	// subpool, without adding it.
+	Validate(tx *types.Transaction, local bool) error
+
 	// Pending retrieves all currently processable transactions, grouped by origin
 	// account and sorted by nonce.
 	Pending(enforceTips bool) map[common.Address][]*LazyTransaction
@@ -121,7 +140,22 @@ type SubPool interface {
 	// Locals retrieves the accounts currently considered local by the pool.
 	Locals() []common.Address
 
+	// AddLocal marks an account as local, exempting its transactions from
+	// pricing constraints and eviction.
+	AddLocal(addr common.Address)
+
+	// RemoveLocal stops treating an account as local.
+	RemoveLocal(addr common.Address)
+
 	// Status returns the known status (unknown/pending/queued) of a transaction
 	// identified by their hashes.
 	Status(hash common.Hash) TxStatus
+
+	// RecentlyDropped returns up to limit of the transactions most recently
+	// evicted from the pool, newest first.
+	RecentlyDropped(limit int) []*DroppedTx
+
+	// ReplacementHistory returns the transactions that successively occupied
+	// the given account and nonce slot, oldest first.
+	ReplacementHistory(addr common.Address, nonce uint64) []*ReplacedTx
 }
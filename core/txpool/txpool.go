@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 	"sync"
 
 	"github.com/theQRL/go-zond/common"
@@ -304,6 +305,17 @@ func (p *TxPool) Add(txs []*types.Transaction, local bool, sync bool) []error {
 	return errs
 }
 
+// Validate checks whether a transaction would currently be accepted by the
+// subpool handling its type, without adding it to the pool.
+func (p *TxPool) Validate(tx *types.Transaction, local bool) error {
+	for _, subpool := range p.subpools {
+		if subpool.Filter(tx) {
+			return subpool.Validate(tx, local)
+		}
+	}
+	return core.ErrTxTypeNotSupported
+}
+
 // Pending retrieves all currently processable transactions, grouped by origin
 // account and sorted by nonce.
 func (p *TxPool) Pending(enforceTips bool) map[common.Address][]*LazyTransaction {
@@ -403,6 +415,20 @@ func (p *TxPool) Locals() []common.Address {
 	return flat
 }
 
+// AddLocal marks an account as local in all subpools.
+func (p *TxPool) AddLocal(addr common.Address) {
+	for _, subpool := range p.subpools {
+		subpool.AddLocal(addr)
+	}
+}
+
+// RemoveLocal stops treating an account as local in all subpools.
+func (p *TxPool) RemoveLocal(addr common.Address) {
+	for _, subpool := range p.subpools {
+		subpool.RemoveLocal(addr)
+	}
+}
+
 // Status returns the known status (unknown/pending/queued) of a transaction
 // identified by their hashes.
 func (p *TxPool) Status(hash common.Hash) TxStatus {
@@ -413,3 +439,31 @@ func (p *TxPool) Status(hash common.Hash) TxStatus {
 	}
 	return TxStatusUnknown
 }
+
+// RecentlyDropped returns up to limit of the transactions most recently evicted
+// from any of the subpools, newest first. A non-positive limit returns all the
+// transactions remembered.
+func (p *TxPool) RecentlyDropped(limit int) []*DroppedTx {
+	var dropped []*DroppedTx
+	for _, subpool := range p.subpools {
+		dropped = append(dropped, subpool.RecentlyDropped(limit)...)
+	}
+	sort.SliceStable(dropped, func(i, j int) bool {
+		return dropped[i].Time.After(dropped[j].Time)
+	})
+	if limit > 0 && len(dropped) > limit {
+		dropped = dropped[:limit]
+	}
+	return dropped
+}
+
+// ReplacementHistory returns the transactions that successively occupied the
+// given account and nonce slot in any of the subpools, oldest first.
+func (p *TxPool) ReplacementHistory(addr common.Address, nonce uint64) []*ReplacedTx {
+	for _, subpool := range p.subpools {
+		if history := subpool.ReplacementHistory(addr, nonce); len(history) > 0 {
+			return history
+		}
+	}
+	return nil
+}
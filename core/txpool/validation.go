@@ -17,6 +17,7 @@
 package txpool
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -80,6 +81,9 @@ func ValidateTransaction(tx *types.Transaction, head *types.Header, signer types
 	}
 	// Make sure the transaction is signed properly
 	if _, err := types.Sender(signer, tx); err != nil {
+		if errors.Is(err, types.ErrInvalidChainId) {
+			return fmt.Errorf("%w: %w", ErrInvalidSender, err)
+		}
 		return ErrInvalidSender
 	}
 	// Ensure the transaction has more gas than the bare minimum needed to cover
@@ -50,12 +50,22 @@ type Transaction struct {
 	inner TxData    // Consensus contents of a transaction
 	time  time.Time // Time first seen locally (spam avoidance)
 
-	// caches
+	// caches, derived from inner. Whenever inner is set on an existing object,
+	// the caches must be reset with ClearCaches so that no stale hash, size or
+	// sender is returned.
 	hash atomic.Value
 	size atomic.Value
 	from atomic.Value
 }
 
+// ClearCaches drops the cached hash, size and sender of the transaction. It is
+// not safe for concurrent use with any other method of the transaction.
+func (tx *Transaction) ClearCaches() {
+	tx.hash = atomic.Value{}
+	tx.size = atomic.Value{}
+	tx.from = atomic.Value{}
+}
+
 // NewTx creates a new transaction.
 func NewTx(inner TxData) *Transaction {
 	tx := new(Transaction)
@@ -210,6 +220,7 @@ func (tx *Transaction) decodeTyped(b []byte) (TxData, error) {
 func (tx *Transaction) setDecoded(inner TxData, size uint64) {
 	tx.inner = inner
 	tx.time = time.Now()
+	tx.ClearCaches()
 	if size > 0 {
 		tx.size.Store(size)
 	}
@@ -258,6 +269,12 @@ func (tx *Transaction) Type() uint8 {
 	return tx.inner.txType()
 }
 
+// IsTyped reports whether the transaction uses an EIP-2718 typed envelope, as
+// opposed to the legacy untyped RLP list encoding.
+func (tx *Transaction) IsTyped() bool {
+	return tx.Type() != LegacyTxType
+}
+
 // ChainId returns the EIP155 chain ID of the transaction. The return value will always be
 // non-nil. For legacy transactions which are not replay-protected, the return value is
 // zero.
@@ -431,7 +448,11 @@ func (tx *Transaction) WithSignatureAndPublicKey(signer Signer, sig, pk []byte)
 	}
 	cpy := tx.inner.copy()
 	cpy.setSignatureAndPublicKeyValues(signer.ChainID(), signature, publicKey)
-	return &Transaction{inner: cpy, time: tx.time}, nil
+
+	// The signed transaction must not inherit any cache of the original.
+	signed := &Transaction{inner: cpy, time: tx.time}
+	signed.ClearCaches()
+	return signed, nil
 }
 
 // Transactions implements DerivableList for transactions.
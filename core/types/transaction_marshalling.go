@@ -19,6 +19,8 @@ package types
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"math/big"
 
 	"github.com/theQRL/go-zond/common"
@@ -63,8 +65,8 @@ func (tx *Transaction) MarshalJSON() ([]byte, error) {
 		enc.GasPrice = (*hexutil.Big)(itx.GasPrice)
 		enc.Value = (*hexutil.Big)(itx.Value)
 		enc.Input = (*hexutil.Bytes)(&itx.Data)
-		enc.Signature = (*hexutil.Bytes)(&itx.PublicKey)
-		enc.PublicKey = (*hexutil.Bytes)(&itx.Signature)
+		enc.PublicKey = (*hexutil.Bytes)(&itx.PublicKey)
+		enc.Signature = (*hexutil.Bytes)(&itx.Signature)
 		enc.ChainID = (*hexutil.Big)(tx.ChainId())
 
 	case *AccessListTx:
@@ -260,3 +262,19 @@ func (tx *Transaction) UnmarshalJSON(input []byte) error {
 	// TODO: check hash here?
 	return nil
 }
+
+// EncodeJSONStream writes the JSON encoding of each transaction to w as newline
+// delimited JSON, without holding the encoding of the whole list in memory. The
+// first marshalling or write error aborts the stream and is returned.
+func (s Transactions) EncodeJSONStream(w io.Writer) error {
+	for i, tx := range s {
+		enc, err := tx.MarshalJSON()
+		if err != nil {
+			return fmt.Errorf("failed to encode transaction %d: %w", i, err)
+		}
+		if _, err := w.Write(append(enc, '\n')); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -20,6 +20,9 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"runtime"
+	"sync"
+	"sync/atomic"
 
 	"github.com/theQRL/go-qrllib/dilithium"
 	"github.com/theQRL/go-zond/common"
@@ -29,6 +32,21 @@ import (
 
 var ErrInvalidChainId = errors.New("invalid chain id for signer")
 
+// ChainIdMismatchError is returned when a transaction is signed for a chain ID
+// other than the one of the signer. It unwraps to ErrInvalidChainId.
+type ChainIdMismatchError struct {
+	Have *big.Int // Chain ID of the transaction
+	Want *big.Int // Chain ID expected by the signer
+}
+
+func (e *ChainIdMismatchError) Error() string {
+	return fmt.Sprintf("%v: have %d want %d", ErrInvalidChainId, e.Have, e.Want)
+}
+
+func (e *ChainIdMismatchError) Unwrap() error {
+	return ErrInvalidChainId
+}
+
 // sigCache is used to cache the derived sender and contains
 // the signer used to derive it.
 type sigCache struct {
@@ -123,6 +141,40 @@ func Sender(signer Signer, tx *Transaction) (common.Address, error) {
 	return addr, nil
 }
 
+// SenderBatch derives the senders of all the given transactions concurrently,
+// caching each of them in its transaction the same way Sender does. The returned
+// addresses are in the order of the transactions. If any derivation fails, the
+// error of the lowest indexed failing transaction is returned.
+func SenderBatch(signer Signer, txs Transactions) ([]common.Address, error) {
+	var (
+		senders = make([]common.Address, len(txs))
+		errs    = make([]error, len(txs))
+		next    atomic.Int64
+		workers = runtime.GOMAXPROCS(0)
+		wg      sync.WaitGroup
+	)
+	if workers > len(txs) {
+		workers = len(txs)
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := int(next.Add(1) - 1); i < len(txs); i = int(next.Add(1) - 1) {
+				senders[i], errs[i] = Sender(signer, txs[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i, err)
+		}
+	}
+	return senders, nil
+}
+
 // Signer encapsulates transaction signature handling. The name of this type is slightly
 // misleading because Signers don't actually sign, they're just for validating and
 // processing of signatures.
@@ -164,7 +216,7 @@ func (s ShanghaiSigner) ChainID() *big.Int {
 
 func (s ShanghaiSigner) Sender(tx *Transaction) (common.Address, error) {
 	if tx.ChainId().Cmp(s.ChainId) != 0 {
-		return common.Address{}, fmt.Errorf("%w: have %d want %d", ErrInvalidChainId, tx.ChainId(), s.ChainId)
+		return common.Address{}, &ChainIdMismatchError{Have: tx.ChainId(), Want: s.ChainId}
 	}
 	return pqcrypto.DilithiumPKToAddress(tx.RawPublicKeyValue()), nil
 }
@@ -179,7 +231,7 @@ func (s ShanghaiSigner) SignatureAndPublicKeyValues(tx *Transaction, sig, pk []b
 	// because it indicates that the chain ID was not specified in the tx.
 	chainID := tx.inner.chainID()
 	if chainID.Sign() != 0 && chainID.Cmp(s.ChainId) != 0 {
-		return nil, nil, fmt.Errorf("%w: have %d want %d", ErrInvalidChainId, chainID, s.ChainId)
+		return nil, nil, &ChainIdMismatchError{Have: chainID, Want: s.ChainId}
 	}
 	Signature = decodeSignature(sig)
 	PublicKey = decodePublicKey(pk)
@@ -119,3 +119,125 @@ func TestChainId(t *testing.T) {
 		t.Error("expected no error")
 	}
 }
+
+// Tests that the cached sender is never returned for a different signer, nor
+// after the transaction object is reused for decoding another transaction.
+func TestSenderCacheInvalidation(t *testing.T) {
+	key, _ := crypto.GenerateDilithiumKey()
+	other, _ := crypto.GenerateDilithiumKey()
+
+	signer := NewShanghaiSigner(big.NewInt(1))
+	tx, err := SignTx(NewTx(&DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 0, Gas: 21000}), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from, err := Sender(signer, tx); err != nil || from != key.GetAddress() {
+		t.Fatalf("sender mismatch: have %x (%v), want %x", from, err, key.GetAddress())
+	}
+	// Deriving with another chain ID must not hit the cache
+	if _, err := Sender(NewShanghaiSigner(big.NewInt(2)), tx); !errors.Is(err, ErrInvalidChainId) {
+		t.Fatalf("sender error mismatch: have %v, want %v", err, ErrInvalidChainId)
+	}
+	// Reusing the object for another transaction must drop the cached sender
+	otherTx, err := SignTx(NewTx(&DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 1, Gas: 21000}), signer, other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc, err := otherTx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.UnmarshalBinary(enc); err != nil {
+		t.Fatal(err)
+	}
+	if from, err := Sender(signer, tx); err != nil || from != other.GetAddress() {
+		t.Fatalf("sender mismatch after reuse: have %x (%v), want %x", from, err, other.GetAddress())
+	}
+	if tx.Hash() != otherTx.Hash() {
+		t.Fatalf("hash mismatch after reuse: have %x, want %x", tx.Hash(), otherTx.Hash())
+	}
+	tx.ClearCaches()
+	if tx.from.Load() != nil || tx.hash.Load() != nil || tx.size.Load() != nil {
+		t.Fatal("caches not cleared")
+	}
+}
+
+func TestSenderBatch(t *testing.T) {
+	signer := NewShanghaiSigner(big.NewInt(1))
+
+	txs := make(Transactions, 16)
+	keys := make([]common.Address, len(txs))
+	for i := range txs {
+		key, _ := crypto.GenerateDilithiumKey()
+		tx, err := SignTx(NewTx(&DynamicFeeTx{ChainID: big.NewInt(1), Nonce: uint64(i), Gas: 21000}), signer, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		txs[i], keys[i] = tx, key.GetAddress()
+	}
+	// Pre-cache some of the senders to ensure they are reused
+	for i := 0; i < len(txs); i += 3 {
+		if _, err := Sender(signer, txs[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	senders, err := SenderBatch(signer, txs)
+	if err != nil {
+		t.Fatalf("failed to derive senders: %v", err)
+	}
+	for i, sender := range senders {
+		if sender != keys[i] {
+			t.Errorf("transaction %d: sender mismatch: have %x, want %x", i, sender, keys[i])
+		}
+		if sc := txs[i].from.Load(); sc == nil || sc.(sigCache).from != keys[i] {
+			t.Errorf("transaction %d: sender not cached", i)
+		}
+	}
+	// The error of the first failing transaction is returned
+	txs[5] = NewTx(&DynamicFeeTx{ChainID: big.NewInt(2), Nonce: 5, Gas: 21000})
+	txs[9] = NewTx(&DynamicFeeTx{ChainID: big.NewInt(3), Nonce: 9, Gas: 21000})
+	_, err = SenderBatch(signer, txs)
+	var mismatch *ChainIdMismatchError
+	if !errors.As(err, &mismatch) || mismatch.Have.Cmp(big.NewInt(2)) != 0 {
+		t.Fatalf("error mismatch: have %v, want chain id mismatch of transaction 5", err)
+	}
+}
+
+func BenchmarkSenderBatch(b *testing.B) {
+	signer := NewShanghaiSigner(big.NewInt(1))
+	key, _ := crypto.GenerateDilithiumKey()
+
+	txs := make(Transactions, 200)
+	for i := range txs {
+		tx, err := SignTx(NewTx(&DynamicFeeTx{ChainID: big.NewInt(1), Nonce: uint64(i), Gas: 21000}), signer, key)
+		if err != nil {
+			b.Fatal(err)
+		}
+		txs[i] = tx
+	}
+	reset := func(b *testing.B) {
+		b.StopTimer()
+		for _, tx := range txs {
+			tx.ClearCaches()
+		}
+		b.StartTimer()
+	}
+	b.Run("serial", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			reset(b)
+			for _, tx := range txs {
+				if _, err := Sender(signer, tx); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			reset(b)
+			if _, err := SenderBatch(signer, txs); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
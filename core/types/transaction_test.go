@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
 	"reflect"
 	"testing"
@@ -43,18 +44,16 @@ var (
 		nil,
 	)
 
-	rightvrsTx, _ = NewTransaction(
+	testKey, _ = pqcrypto.HexToDilithium("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+
+	rightvrsTx, _ = SignTx(NewTransaction(
 		3,
 		testAddr,
 		big.NewInt(10),
 		2000,
 		big.NewInt(1),
 		common.FromHex("5544"),
-	).WithSignatureAndPublicKey(
-		ShanghaiSigner{},
-		common.Hex2Bytes("98ff921201554726367d2be8c804a7ff89ccf285ebc57dff8ae4c44b9c19ac4a8887321be575c8095f789dd4c743dfe42c1820f9231f98a962b210e3ac2452a301"),
-		nil,
-	)
+	), NewShanghaiSigner(big.NewInt(1)), testKey)
 
 	emptyEip2718Tx = NewTx(&AccessListTx{
 		ChainID:  big.NewInt(1),
@@ -66,11 +65,7 @@ var (
 		Data:     common.FromHex("5544"),
 	})
 
-	signedEip2718Tx, _ = emptyEip2718Tx.WithSignatureAndPublicKey(
-		NewShanghaiSigner(big.NewInt(1)),
-		common.Hex2Bytes("c9519f4f2b30335884581971573fadf60c6204f59a911df35ee8a540456b266032f1e8e2c5dd761f9e4f88f41c8310aeaba26a8bfcdacfedfa12ec3862d3752101"),
-		nil,
-	)
+	signedEip2718Tx, _ = SignTx(emptyEip2718Tx, NewShanghaiSigner(big.NewInt(1)), testKey)
 )
 
 func TestDecodeEmptyTypedTx(t *testing.T) {
@@ -82,6 +77,34 @@ func TestDecodeEmptyTypedTx(t *testing.T) {
 	}
 }
 
+// Tests that legacy untyped transactions are decoded from both the binary and
+// the RLP list encodings, and are told apart from typed ones.
+func TestDecodeLegacyTx(t *testing.T) {
+	enc, err := emptyTx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var bintx Transaction
+	if err := bintx.UnmarshalBinary(enc); err != nil {
+		t.Fatalf("failed to decode legacy binary encoding: %v", err)
+	}
+	var rlptx Transaction
+	if err := rlp.DecodeBytes(enc, &rlptx); err != nil {
+		t.Fatalf("failed to decode legacy RLP encoding: %v", err)
+	}
+	for _, tx := range []*Transaction{&bintx, &rlptx} {
+		if tx.IsTyped() {
+			t.Errorf("legacy transaction reported as typed")
+		}
+		if tx.Hash() != emptyTx.Hash() {
+			t.Errorf("hash mismatch: have %x, want %x", tx.Hash(), emptyTx.Hash())
+		}
+	}
+	if !signedEip2718Tx.IsTyped() {
+		t.Errorf("typed transaction reported as legacy")
+	}
+}
+
 func TestTransactionSigHash(t *testing.T) {
 	var shanghai ShanghaiSigner
 	if shanghai.Hash(emptyTx) != common.HexToHash("c775b99e7ad12f50d819fcd602390467e28141316969f4b57f0626f74fe3b386") {
@@ -453,3 +476,89 @@ func TestTransactionSizes(t *testing.T) {
 		}
 	}
 }
+
+// failingWriter is a writer failing after a given number of writes.
+type failingWriter struct {
+	writes int
+	limit  int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.writes == w.limit {
+		return 0, errors.New("write failed")
+	}
+	w.writes++
+	return len(p), nil
+}
+
+func TestTransactionsEncodeJSONStream(t *testing.T) {
+	txs := Transactions{emptyTx, rightvrsTx, signedEip2718Tx}
+
+	var buf bytes.Buffer
+	if err := txs.EncodeJSONStream(&buf); err != nil {
+		t.Fatalf("failed to stream transactions: %v", err)
+	}
+	lines := bytes.Split(bytes.TrimSuffix(buf.Bytes(), []byte("\n")), []byte("\n"))
+	if len(lines) != len(txs) {
+		t.Fatalf("line count mismatch: have %d, want %d", len(lines), len(txs))
+	}
+	for i, line := range lines {
+		var tx Transaction
+		if err := json.Unmarshal(line, &tx); err != nil {
+			t.Fatalf("line %d: invalid JSON: %v", i, err)
+		}
+		if tx.Hash() != txs[i].Hash() {
+			t.Errorf("line %d: hash mismatch: have %x, want %x", i, tx.Hash(), txs[i].Hash())
+		}
+	}
+	// Ensure write errors abort the stream
+	w := &failingWriter{limit: 1}
+	if err := txs.EncodeJSONStream(w); err == nil {
+		t.Fatal("expected error from failing writer")
+	}
+	if w.writes != 1 {
+		t.Errorf("write count mismatch: have %d, want 1", w.writes)
+	}
+}
+
+func benchmarkTransactions(n int) Transactions {
+	to := common.Address{0x01}
+	txs := make(Transactions, n)
+	for i := range txs {
+		txs[i] = NewTx(&DynamicFeeTx{
+			ChainID:   big.NewInt(1),
+			Nonce:     uint64(i),
+			To:        &to,
+			Gas:       21000,
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: big.NewInt(1),
+			Value:     big.NewInt(1),
+		})
+	}
+	return txs
+}
+
+func BenchmarkTransactionsEncodeJSON(b *testing.B) {
+	txs := benchmarkTransactions(10000)
+
+	b.Run("stream", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := txs.EncodeJSONStream(io.Discard); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("marshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			enc, err := json.Marshal(txs)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if _, err := io.Discard.Write(enc); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
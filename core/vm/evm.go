@@ -449,7 +449,7 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gas uint64,
 	}
 
 	// Reject code starting with 0xEF if EIP-3541 is enabled.
-	if err == nil && len(ret) >= 1 && ret[0] == 0xEF {
+	if err == nil && len(ret) >= 1 && ret[0] == 0xEF && evm.chainRules.IsEIP3541 {
 		err = ErrInvalidCode
 	}
 
@@ -437,12 +437,13 @@ func opBlockhash(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) (
 		num.Clear()
 		return nil, nil
 	}
-	var upper, lower uint64
-	upper = interpreter.evm.Context.BlockNumber.Uint64()
-	if upper < 257 {
-		lower = 0
-	} else {
-		lower = upper - 256
+	var (
+		upper  = interpreter.evm.Context.BlockNumber.Uint64()
+		window = interpreter.evm.chainRules.BlockHashWindow
+		lower  uint64
+	)
+	if upper > window {
+		lower = upper - window
 	}
 	if num64 >= lower && num64 < upper {
 		num.SetBytes(interpreter.evm.Context.GetHash(num64).Bytes())
@@ -31,6 +31,12 @@ func (op *operation) Stack() (int, int) {
 	return op.minStack, op.maxStack
 }
 
+// ConstantGas returns the static gas cost of the opcode, excluding any dynamic
+// costs such as memory expansion or state access.
+func (op *operation) ConstantGas() uint64 {
+	return op.constantGas
+}
+
 // HasCost returns true if the opcode has a cost. Opcodes which do _not_ have
 // a cost assigned are one of two things:
 // - undefined, a.k.a invalid opcodes,
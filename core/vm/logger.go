@@ -41,3 +41,10 @@ type EVMLogger interface {
 	CaptureState(pc uint64, op OpCode, gas, cost uint64, scope *ScopeContext, rData []byte, depth int, err error)
 	CaptureFault(pc uint64, op OpCode, gas, cost uint64, scope *ScopeContext, depth int, err error)
 }
+
+// RefundLogger is an optional extension of EVMLogger. Tracers implementing it
+// are notified at the end of each transaction of the gas refund accumulated in
+// the refund counter, along with the amount actually applied after capping.
+type RefundLogger interface {
+	CaptureRefund(uncapped, applied uint64)
+}
@@ -17,6 +17,8 @@
 package runtime
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"math/big"
 	"os"
@@ -99,6 +101,38 @@ func TestExecute(t *testing.T) {
 	}
 }
 
+// Tests that code starting with 0xEF is rejected at deployment unless the chain
+// config opts out of EIP-3541.
+func TestCreateEFCode(t *testing.T) {
+	// Init code returning the single byte 0xEF as the runtime code
+	initcode := []byte{
+		byte(vm.PUSH1), 0xEF,
+		byte(vm.PUSH1), 0,
+		byte(vm.MSTORE8),
+		byte(vm.PUSH1), 1,
+		byte(vm.PUSH1), 0,
+		byte(vm.RETURN),
+	}
+	if _, _, _, err := Create(initcode, nil); !errors.Is(err, vm.ErrInvalidCode) {
+		t.Fatalf("default config: have error %v, want %v", err, vm.ErrInvalidCode)
+	}
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	cfg := &Config{
+		ChainConfig: &params.ChainConfig{ChainID: big.NewInt(1), AllowEFCodePrefix: true},
+		State:       statedb,
+	}
+	code, address, _, err := Create(initcode, cfg)
+	if err != nil {
+		t.Fatalf("permissive config: failed to deploy 0xEF code: %v", err)
+	}
+	if !bytes.Equal(code, []byte{0xEF}) {
+		t.Fatalf("permissive config: returned code mismatch: have %x, want ef", code)
+	}
+	if have := cfg.State.GetCode(address); !bytes.Equal(have, []byte{0xEF}) {
+		t.Fatalf("permissive config: deployed code mismatch: have %x, want ef", have)
+	}
+}
+
 func TestCall(t *testing.T) {
 	state, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
 	address := common.HexToAddress("0x0a")
@@ -308,6 +342,37 @@ func TestBlockhash(t *testing.T) {
 	}
 }
 
+// Tests that BLOCKHASH only serves the hashes within the window configured in
+// the chain config.
+func TestBlockhashWindow(t *testing.T) {
+	n := uint64(1000)
+	parentHash := common.BytesToHash(big.NewInt(int64(n - 1)).Bytes())
+	header := fakeHeader(n, parentHash)
+
+	// Returns the hashes of the blocks at the edge of and just beyond the window
+	code := []byte{
+		byte(vm.PUSH1), 16, byte(vm.NUMBER), byte(vm.SUB), byte(vm.BLOCKHASH),
+		byte(vm.PUSH1), 0, byte(vm.MSTORE),
+		byte(vm.PUSH1), 17, byte(vm.NUMBER), byte(vm.SUB), byte(vm.BLOCKHASH),
+		byte(vm.PUSH1), 32, byte(vm.MSTORE),
+		byte(vm.PUSH1), 64, byte(vm.PUSH1), 0, byte(vm.RETURN),
+	}
+	ret, _, err := Execute(code, nil, &Config{
+		ChainConfig: &params.ChainConfig{ChainID: big.NewInt(1), BlockHashWindow: 16},
+		GetHashFn:   core.GetHashFn(header, &dummyChain{}),
+		BlockNumber: new(big.Int).Set(header.Number),
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if have := new(big.Int).SetBytes(ret[0:32]); have.Uint64() != n-16 {
+		t.Fatalf("hash within window mismatch: have %d, want %d", have, n-16)
+	}
+	if have := new(big.Int).SetBytes(ret[32:64]); have.BitLen() != 0 {
+		t.Fatalf("expected zero hash beyond window, got %x", ret[32:64])
+	}
+}
+
 // benchmarkNonModifyingCode benchmarks code, but if the code modifies the
 // state, this should not be used, since it does not reset the state between runs.
 func benchmarkNonModifyingCode(gas uint64, code []byte, name string, tracerCode string, b *testing.B) {
@@ -7,9 +7,12 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	qrllibCommon "github.com/theQRL/go-qrllib/common"
 	"github.com/theQRL/go-qrllib/dilithium"
+	"github.com/theQRL/go-qrllib/misc"
+	"github.com/theQRL/go-qrllib/qrl"
 	"github.com/theQRL/go-zond/common"
 )
 
@@ -43,6 +46,16 @@ func LoadDilithium(file string) (*dilithium.Dilithium, error) {
 	return HexToDilithium(string(buf))
 }
 
+// LoadDilithiumMnemonic loads Dilithium from the given file having the mnemonic
+// of a seed (not extended seed).
+func LoadDilithiumMnemonic(file string) (*dilithium.Dilithium, error) {
+	mnemonic, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return MnemonicToDilithium(string(mnemonic))
+}
+
 func GenerateDilithiumKey() (*dilithium.Dilithium, error) {
 	return dilithium.New()
 }
@@ -107,6 +120,30 @@ func HexToDilithium(hexSeedStr string) (*dilithium.Dilithium, error) {
 	return dilithium.NewDilithiumFromSeed(hexSeed)
 }
 
+// MnemonicToDilithium parses the mnemonic of a seed (not extended seed).
+func MnemonicToDilithium(mnemonic string) (*dilithium.Dilithium, error) {
+	words := strings.Fields(mnemonic)
+	if want := qrllibCommon.SeedSize * 2 / 3; len(words) != want {
+		return nil, fmt.Errorf("invalid mnemonic length %d, want %d words", len(words), want)
+	}
+	for _, word := range words {
+		if _, ok := mnemonicWords[word]; !ok {
+			return nil, fmt.Errorf("invalid word %q in mnemonic", word)
+		}
+	}
+	seed := misc.MnemonicToSeedBin(strings.Join(words, " "))
+	return dilithium.NewDilithiumFromSeed(seed)
+}
+
+// mnemonicWords is the lookup set of the words allowed in a mnemonic.
+var mnemonicWords = func() map[string]struct{} {
+	words := make(map[string]struct{}, len(qrl.WordList))
+	for _, word := range qrl.WordList {
+		words[word] = struct{}{}
+	}
+	return words
+}()
+
 func DilithiumPKToAddress(publicKey []byte) common.Address {
 	var pk [DilithiumPublicKeyLength]uint8
 	copy(pk[:], publicKey)
@@ -23,6 +23,7 @@ import (
 	"io"
 	"math/big"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -55,11 +56,67 @@ func TestBuildSchema(t *testing.T) {
 	}
 	defer stack.Close()
 	// Make sure the schema can be parsed and matched up to the object model.
-	if _, err := newHandler(stack, nil, nil, []string{}, []string{}); err != nil {
+	if _, err := newHandler(stack, nil, nil, []string{}, []string{}, false); err != nil {
 		t.Errorf("Could not construct GraphQL handler: %v", err)
 	}
 }
 
+// Tests that schema introspection queries are only answered if enabled.
+func TestGraphQLIntrospection(t *testing.T) {
+	for _, tt := range []struct {
+		noIntrospection bool
+		query           string
+		code            int
+		want            string
+	}{
+		{false, `{"query": "{__schema{queryType{name}}}"}`, http.StatusOK, `{"data":{"__schema":{"queryType":{"name":"Query"}}}}`},
+		{true, `{"query": "{__schema{queryType{name}}}"}`, http.StatusBadRequest, `{"errors":[{"message":"introspection is disabled"}]}`},
+		{true, `{"query": "{__type(name:\"Query\"){name}}"}`, http.StatusBadRequest, `{"errors":[{"message":"introspection is disabled"}]}`},
+		{true, `{"query": "{s: __schema{queryType{name}}}"}`, http.StatusBadRequest, `{"errors":[{"message":"introspection is disabled"}]}`},
+		{true, `{"query": "{__typename}"}`, http.StatusOK, `{"data":{"__typename":"Query"}}`},
+		{true, `{"query": "# __schema\n{__typename}"}`, http.StatusOK, `{"data":{"__typename":"Query"}}`},
+	} {
+		stack := createNode(t)
+		defer stack.Close()
+		handler, err := newHandler(stack, nil, nil, []string{}, []string{}, tt.noIntrospection)
+		if err != nil {
+			t.Fatalf("could not construct GraphQL handler: %v", err)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(tt.query)))
+		if rec.Code != tt.code {
+			t.Errorf("noIntrospection %v, query %s: status code mismatch: have %d, want %d", tt.noIntrospection, tt.query, rec.Code, tt.code)
+		}
+		if have := rec.Body.String(); have != tt.want {
+			t.Errorf("noIntrospection %v, query %s: response mismatch: have %s, want %s", tt.noIntrospection, tt.query, have, tt.want)
+		}
+	}
+}
+
+// Tests that introspection fields are found by their position in the query
+// document, regardless of aliases and fragments, but not in strings or comments.
+func TestUsesIntrospection(t *testing.T) {
+	for _, tt := range []struct {
+		query string
+		want  bool
+	}{
+		{`{__schema{queryType{name}}}`, true},
+		{`{__type(name: "Query"){name}}`, true},
+		{`{__typename}`, false},
+		{`query Q($n: Long = -1) @foo { s: __schema { types { name } } }`, true},
+		{`{ ...F } fragment F on Query { __type(name: "Query") { name } }`, true},
+		{`{ ... on Query { block { hash } } }`, false},
+		{`{ block(number: 1) { hash } } # __schema`, false},
+		{`{ logs(filter: {addresses: ["__schema"], topics: [[]]}) { data } }`, false},
+		{"{ block(hash: \"\"\"a \"quoted\"\n__type\"\"\") { hash } }", false},
+		{`{ __schema { types { name } }`, false}, // Malformed, left to the executor
+	} {
+		if have := usesIntrospection(tt.query); have != tt.want {
+			t.Errorf("query %q: introspection mismatch: have %v, want %v", tt.query, have, tt.want)
+		}
+	}
+}
+
 // Tests that a graphQL request is successfully handled when graphql is enabled on the specified endpoint
 func TestGraphQLBlockSerialization(t *testing.T) {
 	stack := createNode(t)
@@ -455,7 +512,7 @@ func newGQLService(t *testing.T, stack *node.Node, gspec *core.Genesis, genBlock
 	}
 	// Set up handler
 	filterSystem := filters.NewFilterSystem(zondBackend.APIBackend, filters.Config{})
-	handler, err := newHandler(stack, zondBackend.APIBackend, filterSystem, []string{}, []string{})
+	handler, err := newHandler(stack, zondBackend.APIBackend, filterSystem, []string{}, []string{}, false)
 	if err != nil {
 		t.Fatalf("could not create graphql service: %v", err)
 	}
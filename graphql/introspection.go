@@ -0,0 +1,289 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package graphql
+
+import (
+	"fmt"
+	"strings"
+	"text/scanner"
+)
+
+// usesIntrospection parses the given query document and reports whether any of
+// its operations or fragments select the schema introspection meta fields. The
+// __typename field is not part of them, as it's needed to query union types.
+//
+// Malformed documents are reported as not using introspection, leaving it to the
+// executor to report the syntax error.
+func usesIntrospection(query string) bool {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(querySyntaxError); !ok {
+				panic(r)
+			}
+		}
+	}()
+	p := newQueryParser(query)
+	p.parseDocument()
+	return p.introspection
+}
+
+// querySyntaxError is raised by the query parser on malformed input.
+type querySyntaxError string
+
+// queryParser is a minimal GraphQL query document parser, walking the selection
+// sets of the document to find the fields it requests. The graphql-go parser is
+// internal to its module, so it cannot be used to inspect the document.
+type queryParser struct {
+	sc   scanner.Scanner
+	next rune
+
+	introspection bool // Whether an introspection meta field was selected
+}
+
+func newQueryParser(query string) *queryParser {
+	p := new(queryParser)
+	p.sc.Init(strings.NewReader(query))
+	p.sc.Mode = scanner.ScanIdents | scanner.ScanInts | scanner.ScanFloats | scanner.ScanStrings
+	p.sc.Error = func(s *scanner.Scanner, msg string) {
+		panic(querySyntaxError(msg))
+	}
+	p.consume()
+	return p
+}
+
+// consume advances to the next token, skipping commas, comments and the body of
+// block strings.
+func (p *queryParser) consume() {
+	for {
+		p.next = p.sc.Scan()
+		switch {
+		case p.next == ',':
+			continue
+		case p.next == '#':
+			for ch := p.sc.Peek(); ch != '\n' && ch != scanner.EOF; ch = p.sc.Peek() {
+				p.sc.Next()
+			}
+			continue
+		case p.next == scanner.String && p.sc.TokenText() == `""` && p.sc.Peek() == '"':
+			p.sc.Next()
+			p.skipBlockString()
+		}
+		return
+	}
+}
+
+// skipBlockString skips the body of a block string up to and including its
+// closing triple quote.
+func (p *queryParser) skipBlockString() {
+	quotes := 0
+	for quotes < 3 {
+		switch ch := p.sc.Next(); ch {
+		case scanner.EOF:
+			panic(querySyntaxError("unterminated block string"))
+		case '"':
+			quotes++
+		case '\\':
+			quotes = 0
+			if p.sc.Peek() == '"' {
+				p.sc.Next()
+			}
+		default:
+			quotes = 0
+		}
+	}
+}
+
+func (p *queryParser) peekKeyword(keyword string) bool {
+	return p.next == scanner.Ident && p.sc.TokenText() == keyword
+}
+
+func (p *queryParser) consumeToken(expected rune) {
+	if p.next != expected {
+		panic(querySyntaxError(fmt.Sprintf("unexpected %q, expecting %s", p.sc.TokenText(), scanner.TokenString(expected))))
+	}
+	p.consume()
+}
+
+func (p *queryParser) consumeIdent() string {
+	name := p.sc.TokenText()
+	p.consumeToken(scanner.Ident)
+	return name
+}
+
+func (p *queryParser) consumeSpread() {
+	for i := 0; i < 3; i++ {
+		p.consumeToken('.')
+	}
+}
+
+func (p *queryParser) parseDocument() {
+	for p.next != scanner.EOF {
+		switch {
+		case p.next == '{':
+			p.parseSelectionSet()
+		case p.peekKeyword("query"), p.peekKeyword("mutation"), p.peekKeyword("subscription"):
+			p.consume()
+			if p.next == scanner.Ident {
+				p.consume()
+			}
+			if p.next == '(' {
+				p.parseVariableDefinitions()
+			}
+			p.parseDirectives()
+			p.parseSelectionSet()
+		case p.peekKeyword("fragment"):
+			p.consume()
+			p.consumeIdent()
+			if p.consumeIdent() != "on" {
+				panic(querySyntaxError("expected \"on\" in fragment definition"))
+			}
+			p.consumeIdent()
+			p.parseDirectives()
+			p.parseSelectionSet()
+		default:
+			panic(querySyntaxError(fmt.Sprintf("unexpected %q, expecting definition", p.sc.TokenText())))
+		}
+	}
+}
+
+func (p *queryParser) parseVariableDefinitions() {
+	p.consumeToken('(')
+	for p.next != ')' {
+		p.consumeToken('$')
+		p.consumeIdent()
+		p.consumeToken(':')
+		p.parseType()
+		if p.next == '=' {
+			p.consume()
+			p.parseValue()
+		}
+		p.parseDirectives()
+	}
+	p.consumeToken(')')
+}
+
+func (p *queryParser) parseType() {
+	if p.next == '[' {
+		p.consume()
+		p.parseType()
+		p.consumeToken(']')
+	} else {
+		p.consumeIdent()
+	}
+	if p.next == '!' {
+		p.consume()
+	}
+}
+
+func (p *queryParser) parseSelectionSet() {
+	p.consumeToken('{')
+	for p.next != '}' {
+		if p.next == '.' {
+			p.parseSpread()
+		} else {
+			p.parseField()
+		}
+	}
+	p.consumeToken('}')
+}
+
+func (p *queryParser) parseSpread() {
+	p.consumeSpread()
+
+	// Named fragment spreads are parsed along with their fragment definitions
+	if p.next == scanner.Ident && !p.peekKeyword("on") {
+		p.consume()
+		p.parseDirectives()
+		return
+	}
+	if p.peekKeyword("on") {
+		p.consume()
+		p.consumeIdent()
+	}
+	p.parseDirectives()
+	p.parseSelectionSet()
+}
+
+func (p *queryParser) parseField() {
+	name := p.consumeIdent()
+	if p.next == ':' {
+		p.consume()
+		name = p.consumeIdent()
+	}
+	if name == "__schema" || name == "__type" {
+		p.introspection = true
+	}
+	if p.next == '(' {
+		p.parseArguments()
+	}
+	p.parseDirectives()
+	if p.next == '{' {
+		p.parseSelectionSet()
+	}
+}
+
+func (p *queryParser) parseArguments() {
+	p.consumeToken('(')
+	for p.next != ')' {
+		p.consumeIdent()
+		p.consumeToken(':')
+		p.parseValue()
+	}
+	p.consumeToken(')')
+}
+
+func (p *queryParser) parseDirectives() {
+	for p.next == '@' {
+		p.consume()
+		p.consumeIdent()
+		if p.next == '(' {
+			p.parseArguments()
+		}
+	}
+}
+
+func (p *queryParser) parseValue() {
+	switch p.next {
+	case '$':
+		p.consume()
+		p.consumeIdent()
+	case '-':
+		p.consume()
+		if p.next != scanner.Int && p.next != scanner.Float {
+			panic(querySyntaxError("expected number after minus sign"))
+		}
+		p.consume()
+	case scanner.Int, scanner.Float, scanner.String, scanner.Ident:
+		p.consume()
+	case '[':
+		p.consume()
+		for p.next != ']' {
+			p.parseValue()
+		}
+		p.consume()
+	case '{':
+		p.consume()
+		for p.next != '}' {
+			p.consumeIdent()
+			p.consumeToken(':')
+			p.parseValue()
+		}
+		p.consume()
+	default:
+		panic(querySyntaxError(fmt.Sprintf("unexpected %q, expecting value", p.sc.TokenText())))
+	}
+}
@@ -33,7 +33,8 @@ import (
 )
 
 type handler struct {
-	Schema *graphql.Schema
+	Schema          *graphql.Schema
+	noIntrospection bool // Reject queries using introspection fields
 }
 
 func (h handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -87,7 +88,16 @@ func (h handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		})
 	}
 
-	response := h.Schema.Exec(ctx, params.Query, params.OperationName, params.Variables)
+	var response *graphql.Response
+	if h.noIntrospection && usesIntrospection(params.Query) {
+		// The schema silently drops disabled introspection fields, reject the
+		// query instead so clients can tell why they got no data.
+		response = &graphql.Response{
+			Errors: []*gqlErrors.QueryError{{Message: "introspection is disabled"}},
+		}
+	} else {
+		response = h.Schema.Exec(ctx, params.Query, params.OperationName, params.Variables)
+	}
 	if timer != nil {
 		timer.Stop()
 	}
@@ -106,21 +116,27 @@ func (h handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 // New constructs a new GraphQL service instance.
-func New(stack *node.Node, backend zondapi.Backend, filterSystem *filters.FilterSystem, cors, vhosts []string) error {
-	_, err := newHandler(stack, backend, filterSystem, cors, vhosts)
+func New(stack *node.Node, backend zondapi.Backend, filterSystem *filters.FilterSystem, cors, vhosts []string, noIntrospection bool) error {
+	_, err := newHandler(stack, backend, filterSystem, cors, vhosts, noIntrospection)
 	return err
 }
 
 // newHandler returns a new `http.Handler` that will answer GraphQL queries.
 // It additionally exports an interactive query browser on the / endpoint.
-func newHandler(stack *node.Node, backend zondapi.Backend, filterSystem *filters.FilterSystem, cors, vhosts []string) (*handler, error) {
+// If introspection is disabled, queries using schema introspection fields are
+// rejected with an error.
+func newHandler(stack *node.Node, backend zondapi.Backend, filterSystem *filters.FilterSystem, cors, vhosts []string, noIntrospection bool) (*handler, error) {
 	q := Resolver{backend, filterSystem}
 
-	s, err := graphql.ParseSchema(schema, &q)
+	var opts []graphql.SchemaOpt
+	if noIntrospection {
+		opts = append(opts, graphql.DisableIntrospection())
+	}
+	s, err := graphql.ParseSchema(schema, &q, opts...)
 	if err != nil {
 		return nil, err
 	}
-	h := handler{Schema: s}
+	h := handler{Schema: s, noIntrospection: noIntrospection}
 	handler := node.NewHTTPHandlerStack(h, cors, vhosts, nil)
 
 	stack.RegisterHandler("GraphQL UI", "/graphql/ui", GraphiQL{})
@@ -52,6 +52,20 @@ web3._extend({
 			call: 'admin_removeTrustedPeer',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'subscriptionCount',
+			call: 'admin_subscriptionCount',
+		}),
+		new web3._extend.Method({
+			name: 'addLocal',
+			call: 'admin_addLocal',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'removeLocal',
+			call: 'admin_removeLocal',
+			params: 1
+		}),
 		new web3._extend.Method({
 			name: 'exportChain',
 			call: 'admin_exportChain',
@@ -94,10 +108,18 @@ web3._extend({
 			name: 'nodeInfo',
 			getter: 'admin_nodeInfo'
 		}),
+		new web3._extend.Property({
+			name: 'nodeInfoExtended',
+			getter: 'admin_nodeInfoExtended'
+		}),
 		new web3._extend.Property({
 			name: 'peers',
 			getter: 'admin_peers'
 		}),
+		new web3._extend.Property({
+			name: 'peerBandwidth',
+			getter: 'admin_peerBandwidth'
+		}),
 		new web3._extend.Property({
 			name: 'datadir',
 			getter: 'admin_datadir'
@@ -110,6 +132,28 @@ const DebugJs = `
 web3._extend({
 	property: 'debug',
 	methods: [
+		new web3._extend.Method({
+			name: 'accountCount',
+			call: 'debug_accountCount',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputDefaultBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'dbStats',
+			call: 'debug_dbStats',
+			params: 0
+		}),
+		new web3._extend.Method({
+			name: 'genesisAlloc',
+			call: 'debug_genesisAlloc',
+			params: 0
+		}),
+		new web3._extend.Method({
+			name: 'exportFlatState',
+			call: 'debug_exportFlatState',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputDefaultBlockNumberFormatter, null]
+		}),
 		new web3._extend.Method({
 			name: 'accountRange',
 			call: 'debug_accountRange',
@@ -137,6 +181,12 @@ web3._extend({
 			call: 'debug_getRawReceipts',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'computeReceiptsRoot',
+			call: 'debug_computeReceiptsRoot',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
 		new web3._extend.Method({
 			name: 'getRawTransaction',
 			call: 'debug_getRawTransaction',
@@ -323,6 +373,12 @@ web3._extend({
 			params: 2,
 			inputFormatter: [null, null]
 		}),
+		new web3._extend.Method({
+			name: 'traceBlockByAddress',
+			call: 'debug_traceBlockByAddress',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, null, null]
+		}),
 		new web3._extend.Method({
 			name: 'traceTransaction',
 			call: 'debug_traceTransaction',
@@ -399,6 +455,16 @@ web3._extend({
 			call: 'debug_getTrieFlushInterval',
 			params: 0
 		}),
+		new web3._extend.Method({
+			name: 'accessListOf',
+			call: 'debug_accessListOf',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'simulateReorg',
+			call: 'debug_simulateReorg',
+			params: 1
+		}),
 	],
 	properties: []
 });
@@ -438,6 +504,12 @@ web3._extend({
 			inputFormatter: [web3._extend.formatters.inputCallFormatter, web3._extend.formatters.inputBlockNumberFormatter],
 			outputFormatter: web3._extend.utils.toDecimal
 		}),
+		new web3._extend.Method({
+			name: 'estimateGasBatch',
+			call: 'zond_estimateGasBatch',
+			params: 2,
+			inputFormatter: [null, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
 		new web3._extend.Method({
 			name: 'submitTransaction',
 			call: 'zond_submitTransaction',
@@ -456,6 +528,84 @@ web3._extend({
 			params: 1,
 			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'getCanonicalHash',
+			call: 'zond_getCanonicalHash',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getCodeSize',
+			call: 'zond_getCodeSize',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getStateRoot',
+			call: 'zond_getStateRoot',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getHeadersByRange',
+			call: 'zond_getHeadersByRange',
+			params: 2,
+			inputFormatter: [web3._extend.utils.fromDecimal, web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'gasSchedule',
+			call: 'zond_gasSchedule',
+			params: 0
+		}),
+		new web3._extend.Property({
+			name: 'headSummary',
+			getter: 'zond_headSummary'
+		}),
+		new web3._extend.Property({
+			name: 'forkStatus',
+			getter: 'zond_forkStatus'
+		}),
+		new web3._extend.Method({
+			name: 'getBloomBits',
+			call: 'zond_getBloomBits',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getBloomRange',
+			call: 'zond_getBloomRange',
+			params: 2,
+			inputFormatter: [web3._extend.utils.fromDecimal, web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'getBlockCoinbaseReward',
+			call: 'zond_blockCoinbaseReward',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'isCanonical',
+			call: 'zond_isCanonical',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'getRawBlocksByRange',
+			call: 'zond_getRawBlocksByRange',
+			params: 2,
+			inputFormatter: [web3._extend.utils.fromDecimal, web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'getTotalDifficulty',
+			call: 'zond_getTotalDifficulty',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'blockTipDistribution',
+			call: 'zond_blockTipDistribution',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
 		new web3._extend.Method({
 			name: 'getHeaderByHash',
 			call: 'zond_getHeaderByHash',
@@ -478,6 +628,33 @@ web3._extend({
 			call: 'zond_getRawTransactionByHash',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'pendingTransactionPosition',
+			call: 'zond_pendingTransactionPosition',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'getRawReceipt',
+			call: 'zond_getRawReceipt',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'validateTransaction',
+			call: 'zond_validateTransaction',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'earliestBlock',
+			call: 'zond_earliestBlock',
+			params: 0,
+			outputFormatter: web3._extend.utils.toDecimal
+		}),
+		new web3._extend.Method({
+			name: 'getTransactionBySenderAndNonce',
+			call: 'zond_getTransactionBySenderAndNonce',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.utils.toHex]
+		}),
 		new web3._extend.Method({
 			name: 'getRawTransactionFromBlock',
 			call: function(args) {
@@ -504,11 +681,23 @@ web3._extend({
 			params: 3,
 			inputFormatter: [null, web3._extend.formatters.inputBlockNumberFormatter, null]
 		}),
+		new web3._extend.Method({
+			name: 'suggestGasTipCapWithConfidence',
+			call: 'zond_suggestGasTipCapWithConfidence',
+			params: 1,
+			outputFormatter: web3._extend.utils.toBigNumber
+		}),
 		new web3._extend.Method({
 			name: 'getLogs',
 			call: 'zond_getLogs',
 			params: 1,
 		}),
+		new web3._extend.Method({
+			name: 'getContractLogs',
+			call: 'zond_getContractLogs',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter, null]
+		}),
 		new web3._extend.Method({
 			name: 'call',
 			call: 'zond_call',
@@ -534,11 +723,33 @@ web3._extend({
 				return formatted;
 			}
 		}),
+		new web3._extend.Property({
+			name: 'syncStatus',
+			getter: 'zond_syncStatus'
+		}),
+		new web3._extend.Property({
+			name: 'networkInfo',
+			getter: 'zond_networkInfo'
+		}),
+		new web3._extend.Property({
+			name: 'snapshotEnabled',
+			getter: 'zond_snapshotEnabled'
+		}),
 		new web3._extend.Property({
 			name: 'maxPriorityFeePerGas',
 			getter: 'zond_maxPriorityFeePerGas',
 			outputFormatter: web3._extend.utils.toBigNumber
 		}),
+		new web3._extend.Property({
+			name: 'pendingBaseFee',
+			getter: 'zond_pendingBaseFee',
+			outputFormatter: web3._extend.utils.toBigNumber
+		}),
+		new web3._extend.Property({
+			name: 'mempoolMinTip',
+			getter: 'zond_mempoolMinTip',
+			outputFormatter: web3._extend.utils.toBigNumber
+		}),
 	]
 });
 `
@@ -553,6 +764,10 @@ web3._extend({
 			params: 1,
 			inputFormatter: [web3._extend.formatters.inputAddressFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'getFeeRecipient',
+			call: 'miner_getFeeRecipient',
+		}),
 		new web3._extend.Method({
 			name: 'setExtra',
 			call: 'miner_setExtra',
@@ -575,6 +790,10 @@ web3._extend({
 			call: 'miner_setRecommitInterval',
 			params: 1,
 		}),
+		new web3._extend.Method({
+			name: 'status',
+			call: 'miner_status',
+		}),
 	],
 	properties: []
 });
@@ -609,7 +828,31 @@ web3._extend({
 const TxpoolJs = `
 web3._extend({
 	property: 'txpool',
-	methods: [],
+	methods:
+	[
+		new web3._extend.Method({
+			name: 'recentlyDropped',
+			call: 'txpool_recentlyDropped',
+			params: 1,
+			inputFormatter: [null]
+		}),
+		new web3._extend.Method({
+			name: 'replacementHistory',
+			call: 'txpool_replacementHistory',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'pendingForAccount',
+			call: 'txpool_pendingForAccount',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'transactionFirstSeen',
+			call: 'txpool_transactionFirstSeen',
+			params: 1
+		}),
+	],
 	properties:
 	[
 		new web3._extend.Property({
@@ -620,6 +863,10 @@ web3._extend({
 			name: 'inspect',
 			getter: 'txpool_inspect'
 		}),
+		new web3._extend.Property({
+			name: 'locals',
+			getter: 'txpool_locals'
+		}),
 		new web3._extend.Property({
 			name: 'status',
 			getter: 'txpool_status',
@@ -17,11 +17,13 @@
 package zondapi
 
 import (
+	"container/heap"
 	"context"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 	"strings"
 	"time"
 
@@ -29,12 +31,15 @@ import (
 	"github.com/theQRL/go-zond/accounts"
 	"github.com/theQRL/go-zond/accounts/abi"
 	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/common/bitutil"
 	"github.com/theQRL/go-zond/common/hexutil"
 	"github.com/theQRL/go-zond/common/math"
 	"github.com/theQRL/go-zond/consensus"
 	"github.com/theQRL/go-zond/consensus/misc/eip1559"
 	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/rawdb"
 	"github.com/theQRL/go-zond/core/state"
+	"github.com/theQRL/go-zond/core/txpool"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/core/vm"
 	"github.com/theQRL/go-zond/crypto"
@@ -45,6 +50,7 @@ import (
 	"github.com/theQRL/go-zond/rpc"
 	"github.com/theQRL/go-zond/trie"
 	"github.com/theQRL/go-zond/zond/tracers/logger"
+	"golang.org/x/exp/slices"
 )
 
 // EthereumAPI provides an API to access Ethereum related information.
@@ -78,6 +84,150 @@ func (s *EthereumAPI) MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, e
 	return (*hexutil.Big)(tipcap), err
 }
 
+// tipConfidencePercentiles maps the confidence levels accepted by
+// SuggestGasTipCapWithConfidence to percentiles of recently paid tips.
+var tipConfidencePercentiles = map[string]int{
+	"low":    30,
+	"medium": 60,
+	"high":   90,
+}
+
+// SuggestGasTipCapWithConfidence returns a suggestion for a gas tip cap for
+// dynamic fee transactions with the given confidence level of being included.
+// The level is one of low, medium or high, higher levels suggesting tips paid
+// by a larger share of the recently included transactions.
+func (s *EthereumAPI) SuggestGasTipCapWithConfidence(ctx context.Context, level string) (*hexutil.Big, error) {
+	percentile, ok := tipConfidencePercentiles[level]
+	if !ok {
+		return nil, fmt.Errorf("invalid confidence level %q, want low, medium or high", level)
+	}
+	tipcap, err := s.b.SuggestGasTipCapAtPercentile(ctx, percentile)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(tipcap), nil
+}
+
+// MempoolMinTip returns the lowest effective tip among the pending pool
+// transactions the miner would currently pack into the pending block. It
+// returns nil if no pool transaction would be included.
+func (s *EthereumAPI) MempoolMinTip(ctx context.Context) (*hexutil.Big, error) {
+	header, err := s.b.HeaderByNumber(ctx, rpc.PendingBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, errors.New("pending block is not available")
+	}
+	// Retrieve the transactions passing the miner's tip floor and split them
+	// into locals and remotes, the same way the miner does
+	var (
+		remotes = s.b.TxPoolPending(true)
+		locals  = make(map[common.Address][]*txpool.LazyTransaction)
+	)
+	for _, addr := range s.b.TxPoolLocals() {
+		if txs := remotes[addr]; len(txs) > 0 {
+			delete(remotes, addr)
+			locals[addr] = txs
+		}
+	}
+	tip := marginalTip([]map[common.Address][]*txpool.LazyTransaction{locals, remotes}, header.BaseFee, header.GasLimit)
+	if tip == nil {
+		return nil, nil
+	}
+	return (*hexutil.Big)(tip), nil
+}
+
+// marginalTip packs the given groups of pending transactions into gasLimit one
+// group after the other, each following the miner's price and nonce ordering,
+// and returns the lowest effective tip among the packed transactions. It returns
+// nil if no transaction fits.
+//
+// The accounts are kept in a heap, so the work is bounded by the number of
+// accounts plus the number of transactions fitting into gasLimit.
+func marginalTip(groups []map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int, gasLimit uint64) *big.Int {
+	var (
+		gas    = gasLimit
+		minTip *big.Int
+	)
+	for _, pending := range groups {
+		heads := make(tipHeap, 0, len(pending))
+		for _, txs := range pending {
+			if head := newTipHead(txs, baseFee); head != nil {
+				heads = append(heads, head)
+			}
+		}
+		heap.Init(&heads)
+
+		for len(heads) > 0 && gas >= params.TxGas {
+			head := heads[0]
+			tx := head.txs[0].Resolve()
+			if tx == nil || tx.Gas() > gas {
+				// Not includable, skip all further transactions from the account
+				heap.Pop(&heads)
+				continue
+			}
+			gas -= tx.Gas()
+			if minTip == nil || head.tip.Cmp(minTip) < 0 {
+				minTip = head.tip
+			}
+			if next := newTipHead(head.txs[1:], baseFee); next != nil {
+				heads[0] = next
+				heap.Fix(&heads, 0)
+			} else {
+				heap.Pop(&heads)
+			}
+		}
+	}
+	return minTip
+}
+
+// tipHead is the remainder of an account's pending transactions, along with the
+// effective tip of the first one.
+type tipHead struct {
+	txs []*txpool.LazyTransaction
+	tip *big.Int
+}
+
+// newTipHead creates the head of an account's pending transactions, or returns
+// nil if there are none left or the fee cap of the first one is below the base
+// fee, in which case the account cannot be included any further.
+func newTipHead(txs []*txpool.LazyTransaction, baseFee *big.Int) *tipHead {
+	if len(txs) == 0 {
+		return nil
+	}
+	tip := txs[0].GasTipCap
+	if baseFee != nil {
+		if txs[0].GasFeeCap.Cmp(baseFee) < 0 {
+			return nil
+		}
+		if capped := new(big.Int).Sub(txs[0].GasFeeCap, baseFee); capped.Cmp(tip) < 0 {
+			tip = capped
+		}
+	}
+	return &tipHead{txs: txs, tip: tip}
+}
+
+// tipHeap is a max-heap of account heads ordered by effective tip.
+type tipHeap []*tipHead
+
+func (h tipHeap) Len() int           { return len(h) }
+func (h tipHeap) Less(i, j int) bool { return h[i].tip.Cmp(h[j].tip) > 0 }
+func (h tipHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *tipHeap) Push(x interface{}) {
+	*h = append(*h, x.(*tipHead))
+}
+
+func (h *tipHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	*h = old[0 : n-1]
+	return x
+}
+
 type feeHistoryResult struct {
 	OldestBlock  *hexutil.Big     `json:"oldestBlock"`
 	Reward       [][]*hexutil.Big `json:"reward,omitempty"`
@@ -207,6 +357,19 @@ func (s *TxPoolAPI) ContentFrom(addr common.Address) map[string]map[string]*RPCT
 	return content
 }
 
+// PendingForAccount returns the pending transactions of the given account in the
+// pool, ordered by nonce.
+func (s *TxPoolAPI) PendingForAccount(addr common.Address) []*RPCTransaction {
+	pending, _ := s.b.TxPoolContentFrom(addr)
+	curHeader := s.b.CurrentHeader()
+
+	result := make([]*RPCTransaction, len(pending))
+	for i, tx := range pending {
+		result[i] = NewRPCPendingTransaction(tx, curHeader, s.b.ChainConfig())
+	}
+	return result
+}
+
 // Status returns the number of pending and queued transaction in the pool.
 func (s *TxPoolAPI) Status() map[string]hexutil.Uint {
 	pending, queue := s.b.Stats()
@@ -216,6 +379,35 @@ func (s *TxPoolAPI) Status() map[string]hexutil.Uint {
 	}
 }
 
+// RecentlyDropped returns up to limit of the transactions most recently evicted
+// from the pool along with the reason of their removal, newest first. All the
+// remembered transactions are returned if no limit is given.
+func (s *TxPoolAPI) RecentlyDropped(limit *hexutil.Uint64) []*txpool.DroppedTx {
+	var n int
+	if limit != nil {
+		n = int(*limit)
+	}
+	return s.b.TxPoolRecentlyDropped(n)
+}
+
+// ReplacementHistory returns the transactions that successively occupied the
+// given account and nonce slot of the pool, oldest first, along with the time
+// each of them took over the slot.
+func (s *TxPoolAPI) ReplacementHistory(address common.Address, nonce hexutil.Uint64) []*txpool.ReplacedTx {
+	return s.b.TxPoolReplacementHistory(address, uint64(nonce))
+}
+
+// TransactionFirstSeen returns the time the local node first saw the given pool
+// transaction, or nil if the transaction is not in the pool.
+func (s *TxPoolAPI) TransactionFirstSeen(hash common.Hash) *time.Time {
+	tx := s.b.GetPoolTransaction(hash)
+	if tx == nil {
+		return nil
+	}
+	seen := tx.Time()
+	return &seen
+}
+
 // Inspect retrieves the content of the transaction pool and flattens it into an
 // easily inspectable list.
 func (s *TxPoolAPI) Inspect() map[string]map[string]map[string]string {
@@ -293,6 +485,39 @@ func (s *BlockChainAPI) BlockNumber() hexutil.Uint64 {
 	return hexutil.Uint64(header.Number.Uint64())
 }
 
+// EarliestBlock returns the number of the lowest block whose full data (header
+// and body) is still available locally, taking into account blocks truncated
+// from the tail of the freezer and bodies pruned from the key-value store.
+func (s *BlockChainAPI) EarliestBlock() (hexutil.Uint64, error) {
+	var (
+		db   = s.b.ChainDb()
+		head = s.b.CurrentBlock().Number.Uint64()
+	)
+	// Databases without a freezer never truncate their tail.
+	tail, err := db.Tail()
+	if errors.Is(err, rawdb.ErrNotSupported) {
+		tail = 0
+	} else if err != nil {
+		return 0, err
+	}
+	if tail > head {
+		return 0, errors.New("no block data available")
+	}
+	// Block data is retained as a contiguous range ending at the head, so search
+	// for the first block past the freezer tail which still has its body.
+	available := func(number uint64) bool {
+		hash := rawdb.ReadCanonicalHash(db, number)
+		return hash != (common.Hash{}) && rawdb.HasBody(db, hash, number)
+	}
+	n := sort.Search(int(head-tail+1), func(i int) bool {
+		return available(tail + uint64(i))
+	})
+	if n > int(head-tail) {
+		return 0, errors.New("no block data available")
+	}
+	return hexutil.Uint64(tail + uint64(n)), nil
+}
+
 // GetBalance returns the amount of wei for the given address in the state of the
 // given block number. The rpc.LatestBlockNumber and rpc.PendingBlockNumber meta
 // block numbers are also allowed.
@@ -455,6 +680,223 @@ func (s *BlockChainAPI) GetHeaderByNumber(ctx context.Context, number rpc.BlockN
 	return nil, err
 }
 
+// errRangeTruncated is returned by the callbacks of forEachHeaderInRange to end
+// the iteration early without failing the request.
+var errRangeTruncated = errors.New("range truncated")
+
+// forEachHeaderInRange invokes fn on up to count consecutive canonical headers
+// starting at the given block number, rejecting counts above max. The range is
+// truncated at the current head, or at the first missing header.
+func (s *BlockChainAPI) forEachHeaderInRange(ctx context.Context, start hexutil.Uint64, count hexutil.Uint64, max uint64, fn func(header *types.Header) error) error {
+	if count == 0 {
+		return errors.New("invalid count: 0")
+	}
+	if uint64(count) > max {
+		return fmt.Errorf("requested count too large: %d, max %d", count, max)
+	}
+	// Limit the range up until the current head
+	head := s.b.CurrentHeader().Number.Uint64()
+	if uint64(start) > head {
+		return nil
+	}
+	last := uint64(start) + uint64(count) - 1
+	if last > head {
+		last = head
+	}
+	for number := uint64(start); number <= last; number++ {
+		header, err := s.b.HeaderByNumber(ctx, rpc.BlockNumber(number))
+		if err != nil {
+			return err
+		}
+		if header == nil {
+			break
+		}
+		if err := fn(header); err != nil {
+			if errors.Is(err, errRangeTruncated) {
+				break
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// maxHeadersByRange is the maximum number of headers GetHeadersByRange serves
+// in a single request.
+const maxHeadersByRange = 1024
+
+// GetHeadersByRange returns up to count consecutive canonical headers starting
+// at the given block number. The range is truncated at the current head.
+func (s *BlockChainAPI) GetHeadersByRange(ctx context.Context, start hexutil.Uint64, count hexutil.Uint64) ([]map[string]interface{}, error) {
+	headers := []map[string]interface{}{}
+	err := s.forEachHeaderInRange(ctx, start, count, maxHeadersByRange, func(header *types.Header) error {
+		headers = append(headers, s.rpcMarshalHeader(header))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return headers, nil
+}
+
+// maxRawBlocksByRange is the maximum number of blocks GetRawBlocksByRange serves
+// in a single request.
+const maxRawBlocksByRange = 256
+
+// GetRawBlocksByRange returns up to count consecutive RLP encoded canonical
+// blocks starting at the given block number. The range is truncated at the
+// current head.
+func (s *BlockChainAPI) GetRawBlocksByRange(ctx context.Context, start hexutil.Uint64, count hexutil.Uint64) ([]hexutil.Bytes, error) {
+	blocks := []hexutil.Bytes{}
+	err := s.forEachHeaderInRange(ctx, start, count, maxRawBlocksByRange, func(header *types.Header) error {
+		block, err := s.b.BlockByHash(ctx, header.Hash())
+		if err != nil {
+			return err
+		}
+		if block == nil {
+			return errRangeTruncated
+		}
+		blob, err := rlp.EncodeToBytes(block)
+		if err != nil {
+			return err
+		}
+		blocks = append(blocks, blob)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// GetCanonicalHash returns the hash of the canonical block at the given height.
+// It only consults the canonical hash index, so it is cheaper than retrieving
+// the full header. Named block tags are resolved to their current header.
+func (s *BlockChainAPI) GetCanonicalHash(ctx context.Context, number rpc.BlockNumber) (*common.Hash, error) {
+	if number < 0 {
+		header, err := s.b.HeaderByNumber(ctx, number)
+		if header == nil || err != nil {
+			return nil, err
+		}
+		hash := header.Hash()
+		return &hash, nil
+	}
+	hash := rawdb.ReadCanonicalHash(s.b.ChainDb(), uint64(number))
+	if hash == (common.Hash{}) {
+		return nil, nil
+	}
+	return &hash, nil
+}
+
+// IsCanonical reports whether the block with the given hash is part of the
+// canonical chain. If the block is not known to the node, nil is returned.
+func (s *BlockChainAPI) IsCanonical(ctx context.Context, hash common.Hash) (*bool, error) {
+	header, err := s.b.HeaderByHash(ctx, hash)
+	if header == nil || err != nil {
+		return nil, err
+	}
+	canonical := rawdb.ReadCanonicalHash(s.b.ChainDb(), header.Number.Uint64()) == hash
+	return &canonical, nil
+}
+
+// GasSchedule returns the constant gas cost of every opcode defined by the chain
+// rules active at the current head, keyed by opcode name. Dynamic costs such as
+// memory expansion or state access are not included.
+func (s *BlockChainAPI) GasSchedule() (map[string]hexutil.Uint64, error) {
+	header := s.b.CurrentHeader()
+	jt, err := vm.LookupInstructionSet(s.b.ChainConfig().Rules(header.Number, header.Time))
+	if err != nil {
+		return nil, err
+	}
+	schedule := make(map[string]hexutil.Uint64)
+	for i, op := range jt {
+		// Undefined opcodes carry no cost, STOP is the only defined one without
+		if !op.HasCost() && vm.OpCode(i) != vm.STOP {
+			continue
+		}
+		schedule[vm.OpCode(i).String()] = hexutil.Uint64(op.ConstantGas())
+	}
+	return schedule, nil
+}
+
+// PendingBaseFee returns the base fee of the pending block the miner is
+// assembling on top of the current head.
+func (s *BlockChainAPI) PendingBaseFee(ctx context.Context) (*hexutil.Big, error) {
+	header, err := s.b.HeaderByNumber(ctx, rpc.PendingBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil || header.BaseFee == nil {
+		return nil, errors.New("pending base fee is not available")
+	}
+	return (*hexutil.Big)(header.BaseFee), nil
+}
+
+// GetStateRoot returns the state root of the requested block, or nil if the
+// block is not known.
+func (s *BlockChainAPI) GetStateRoot(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*common.Hash, error) {
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		if header, _ := s.b.HeaderByHash(ctx, hash); header == nil {
+			return nil, nil
+		}
+	}
+	header, err := s.b.HeaderByNumberOrHash(ctx, blockNrOrHash)
+	if header == nil || err != nil {
+		return nil, err
+	}
+	return &header.Root, nil
+}
+
+// GetTotalDifficulty returns the total difficulty of the requested block, or
+// nil if the block is not known. It is a compatibility shim for tools written
+// against pre-merge Ethereum: Zond has been proof-of-stake since genesis and
+// never accumulated any difficulty, so the terminal value is always zero.
+func (s *BlockChainAPI) GetTotalDifficulty(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*hexutil.Big, error) {
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		if header, _ := s.b.HeaderByHash(ctx, hash); header == nil {
+			return nil, nil
+		}
+	}
+	header, err := s.b.HeaderByNumberOrHash(ctx, blockNrOrHash)
+	if header == nil || err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(new(big.Int)), nil
+}
+
+// TipDistribution summarizes the effective priority fees paid by the
+// transactions of a block. The fields are nil for blocks without transactions.
+type TipDistribution struct {
+	Min    *hexutil.Big `json:"min"`
+	Median *hexutil.Big `json:"median"`
+	Max    *hexutil.Big `json:"max"`
+}
+
+// BlockTipDistribution returns the minimum, median and maximum effective tip of
+// the transactions in the requested block, computed against the block's base
+// fee. For an even number of transactions, the upper median is returned.
+func (s *BlockChainAPI) BlockTipDistribution(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*TipDistribution, error) {
+	block, err := s.b.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if block == nil || err != nil {
+		return nil, err
+	}
+	txs := block.Transactions()
+	if len(txs) == 0 {
+		return new(TipDistribution), nil
+	}
+	tips := make([]*big.Int, len(txs))
+	for i, tx := range txs {
+		tips[i] = tx.EffectiveGasTipValue(block.BaseFee())
+	}
+	slices.SortFunc(tips, func(a, b *big.Int) int { return a.Cmp(b) })
+
+	return &TipDistribution{
+		Min:    (*hexutil.Big)(tips[0]),
+		Median: (*hexutil.Big)(tips[len(tips)/2]),
+		Max:    (*hexutil.Big)(tips[len(tips)-1]),
+	}, nil
+}
+
 // GetHeaderByHash returns the requested header by hash.
 func (s *BlockChainAPI) GetHeaderByHash(ctx context.Context, hash common.Hash) map[string]interface{} {
 	header, _ := s.b.HeaderByHash(ctx, hash)
@@ -506,6 +948,17 @@ func (s *BlockChainAPI) GetCode(ctx context.Context, address common.Address, blo
 	return code, state.Error()
 }
 
+// GetCodeSize returns the size in bytes of the code stored at the given address
+// in the state for the given block number, without transferring the code itself.
+func (s *BlockChainAPI) GetCodeSize(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Uint64, error) {
+	state, _, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if state == nil || err != nil {
+		return 0, err
+	}
+	size := state.GetCodeSize(address)
+	return hexutil.Uint64(size), state.Error()
+}
+
 // GetStorageAt returns the storage from the state at the given address, key and
 // block number. The rpc.LatestBlockNumber and rpc.PendingBlockNumber meta block
 // numbers are also allowed.
@@ -550,6 +1003,155 @@ func (s *BlockChainAPI) GetBlockReceipts(ctx context.Context, blockNrOrHash rpc.
 	return result, nil
 }
 
+// BlockCoinbaseReward returns the total priority fees paid to the fee recipient
+// of the given block, that is the sum of the effective tip of each transaction
+// multiplied by the gas it used.
+func (s *BlockChainAPI) BlockCoinbaseReward(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*hexutil.Big, error) {
+	block, err := s.b.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if block == nil || err != nil {
+		return nil, err
+	}
+	receipts, err := s.b.GetReceipts(ctx, block.Hash())
+	if err != nil {
+		return nil, err
+	}
+	txs := block.Transactions()
+	if len(txs) != len(receipts) {
+		return nil, fmt.Errorf("receipts length mismatch: %d vs %d", len(txs), len(receipts))
+	}
+	reward := new(big.Int)
+	for i, tx := range txs {
+		tip := tx.EffectiveGasTipValue(block.BaseFee())
+		reward.Add(reward, tip.Mul(tip, new(big.Int).SetUint64(receipts[i].GasUsed)))
+	}
+	return (*hexutil.Big)(reward), nil
+}
+
+// HeadSummary contains the most commonly needed properties of the current head
+// block.
+type HeadSummary struct {
+	Number    hexutil.Uint64 `json:"number"`
+	Hash      common.Hash    `json:"hash"`
+	Timestamp hexutil.Uint64 `json:"timestamp"`
+	BaseFee   *hexutil.Big   `json:"baseFeePerGas,omitempty"`
+	GasUsed   hexutil.Uint64 `json:"gasUsed"`
+	GasLimit  hexutil.Uint64 `json:"gasLimit"`
+	TxCount   hexutil.Uint   `json:"transactionCount"`
+	Size      hexutil.Uint64 `json:"size"`
+}
+
+// HeadSummary returns a summary of the current head block.
+func (s *BlockChainAPI) HeadSummary(ctx context.Context) (*HeadSummary, error) {
+	block, err := s.b.BlockByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, errors.New("head block not found")
+	}
+	summary := &HeadSummary{
+		Number:    hexutil.Uint64(block.NumberU64()),
+		Hash:      block.Hash(),
+		Timestamp: hexutil.Uint64(block.Time()),
+		GasUsed:   hexutil.Uint64(block.GasUsed()),
+		GasLimit:  hexutil.Uint64(block.GasLimit()),
+		TxCount:   hexutil.Uint(len(block.Transactions())),
+		Size:      hexutil.Uint64(block.Size()),
+	}
+	if baseFee := block.BaseFee(); baseFee != nil {
+		summary.BaseFee = (*hexutil.Big)(baseFee)
+	}
+	return summary, nil
+}
+
+// ForkStatus reports whether a protocol upgrade is active at the current head.
+type ForkStatus struct {
+	Name   string `json:"name"`
+	Active bool   `json:"active"`
+}
+
+// ForkStatus returns the known protocol upgrades of the chain in activation
+// order, along with whether each of them is active at the current head.
+func (s *BlockChainAPI) ForkStatus(ctx context.Context) ([]ForkStatus, error) {
+	head := s.b.CurrentHeader()
+	if head == nil {
+		return nil, errors.New("head header not found")
+	}
+	rules := s.b.ChainConfig().Rules(head.Number, head.Time)
+	return []ForkStatus{
+		{Name: "shanghai", Active: rules.IsShanghai},
+		{Name: "eip3541", Active: rules.IsEIP3541},
+	}, nil
+}
+
+// BloomBitsResult contains the logs bloom of a block, along with the bloom
+// reassembled from the bloom-bits index if its section is already indexed.
+type BloomBitsResult struct {
+	Bloom        types.Bloom    `json:"bloom"`
+	Section      hexutil.Uint64 `json:"section"`
+	IndexedBloom *types.Bloom   `json:"indexedBloom"`
+}
+
+// GetBloomBits returns the logs bloom of the given block. If the bloom-bits
+// section containing the block has been indexed, the bloom is also rebuilt
+// from the indexed bit vectors.
+func (s *BlockChainAPI) GetBloomBits(ctx context.Context, number rpc.BlockNumber) (*BloomBitsResult, error) {
+	header, err := s.b.HeaderByNumber(ctx, number)
+	if header == nil || err != nil {
+		return nil, err
+	}
+	var (
+		size, sections = s.b.BloomStatus()
+		num            = header.Number.Uint64()
+		result         = &BloomBitsResult{Bloom: header.Bloom, Section: hexutil.Uint64(num / size)}
+	)
+	if num/size >= sections {
+		return result, nil
+	}
+	// The section is indexed, rebuild the bloom from the bit vectors
+	var (
+		db      = s.b.ChainDb()
+		section = num / size
+		head    = rawdb.ReadCanonicalHash(db, (section+1)*size-1)
+		index   = num % size
+		bloom   types.Bloom
+	)
+	for bit := uint(0); bit < types.BloomBitLength; bit++ {
+		compressed, err := rawdb.ReadBloomBits(db, bit, section, head)
+		if err != nil {
+			return nil, err
+		}
+		bits, err := bitutil.DecompressBytes(compressed, int(size/8))
+		if err != nil {
+			return nil, err
+		}
+		if bits[index/8]&(1<<(7-index%8)) != 0 {
+			bloom[types.BloomByteLength-1-bit/8] |= 1 << (bit % 8)
+		}
+	}
+	result.IndexedBloom = &bloom
+	return result, nil
+}
+
+// maxBloomRange is the maximum number of blooms GetBloomRange serves in a
+// single request.
+const maxBloomRange = 4096
+
+// GetBloomRange returns the logs blooms of up to count consecutive canonical
+// blocks starting at the given block number. The range is truncated at the
+// current head.
+func (s *BlockChainAPI) GetBloomRange(ctx context.Context, start hexutil.Uint64, count hexutil.Uint64) ([]types.Bloom, error) {
+	blooms := []types.Bloom{}
+	err := s.forEachHeaderInRange(ctx, start, count, maxBloomRange, func(header *types.Header) error {
+		blooms = append(blooms, header.Bloom)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return blooms, nil
+}
+
 // OverrideAccount indicates the overriding fields of account during the execution
 // of a message call.
 // Note, state and stateDiff can't be specified at the same time. If state is
@@ -803,6 +1405,19 @@ func executeEstimate(ctx context.Context, b Backend, args TransactionArgs, state
 // there are unexpected failures. The gas limit is capped by both `args.Gas` (if non-nil &
 // non-zero) and `gasCap` (if non-zero).
 func DoEstimateGas(ctx context.Context, b Backend, args TransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *StateOverride, gasCap uint64) (hexutil.Uint64, error) {
+	state, header, err := b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if state == nil || err != nil {
+		return 0, err
+	}
+	if err := overrides.Apply(state); err != nil {
+		return 0, err
+	}
+	return estimateGas(ctx, b, args, state, header, gasCap)
+}
+
+// estimateGas returns the lowest possible gas limit that allows the transaction to run
+// successfully on top of the given state. The state itself is left untouched.
+func estimateGas(ctx context.Context, b Backend, args TransactionArgs, state *state.StateDB, header *types.Header, gasCap uint64) (hexutil.Uint64, error) {
 	// Binary search the gas limit, as it may need to be higher than the amount used
 	var (
 		lo uint64 // lowest-known gas limit where tx execution fails
@@ -816,15 +1431,8 @@ func DoEstimateGas(ctx context.Context, b Backend, args TransactionArgs, blockNr
 	if args.Gas != nil && uint64(*args.Gas) >= params.TxGas {
 		hi = uint64(*args.Gas)
 	} else {
-		// Retrieve the block to act as the gas ceiling
-		block, err := b.BlockByNumberOrHash(ctx, blockNrOrHash)
-		if err != nil {
-			return 0, err
-		}
-		if block == nil {
-			return 0, errors.New("block not found")
-		}
-		hi = block.GasLimit()
+		// Use the block gas limit as the ceiling
+		hi = header.GasLimit
 	}
 	// Normalize the max fee per gas the call is willing to spend.
 	var feeCap *big.Int
@@ -838,14 +1446,6 @@ func DoEstimateGas(ctx context.Context, b Backend, args TransactionArgs, blockNr
 		feeCap = common.Big0
 	}
 
-	state, header, err := b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
-	if state == nil || err != nil {
-		return 0, err
-	}
-	if err := overrides.Apply(state); err != nil {
-		return 0, err
-	}
-
 	// Recap the highest gas limit with account's available balance.
 	if feeCap.BitLen() != 0 {
 		balance := state.GetBalance(*args.From) // from can't be nil
@@ -934,6 +1534,52 @@ func (s *BlockChainAPI) EstimateGas(ctx context.Context, args TransactionArgs, b
 	return DoEstimateGas(ctx, s.b, args, bNrOrHash, overrides, s.b.RPCGasCap())
 }
 
+// maxEstimateGasBatch is the maximum number of messages EstimateGasBatch serves
+// in a single request.
+const maxEstimateGasBatch = 64
+
+// EstimateGasBatch estimates the gas of a sequence of messages, where each message is
+// executed on top of the state changes made by the ones preceding it. The messages are
+// applied on a copy of the state at `blockNrOrHash`, or the latest block if unspecified.
+func (s *BlockChainAPI) EstimateGasBatch(ctx context.Context, msgs []TransactionArgs, blockNrOrHash *rpc.BlockNumberOrHash) ([]hexutil.Uint64, error) {
+	if len(msgs) > maxEstimateGasBatch {
+		return nil, fmt.Errorf("requested batch too large: %d, max %d", len(msgs), maxEstimateGasBatch)
+	}
+	bNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if blockNrOrHash != nil {
+		bNrOrHash = *blockNrOrHash
+	}
+	state, header, err := s.b.StateAndHeaderByNumberOrHash(ctx, bNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	var (
+		gasCap  = s.b.RPCGasCap()
+		results = make([]hexutil.Uint64, 0, len(msgs))
+	)
+	for i, args := range msgs {
+		if args.From == nil {
+			args.From = new(common.Address)
+		}
+		gas, err := estimateGas(ctx, s.b, args, state, header, gasCap)
+		if err != nil {
+			if _, ok := err.(*revertError); ok {
+				return nil, err
+			}
+			return nil, fmt.Errorf("message %d: %w", i, err)
+		}
+		// Apply the message with the estimated limit so that the following
+		// messages observe its state changes.
+		args.Gas = &gas
+		if _, err := doCall(ctx, s.b, args, state, header, nil, nil, s.b.RPCEVMTimeout(), gasCap); err != nil {
+			return nil, fmt.Errorf("message %d: %w", i, err)
+		}
+		state.Finalise(true)
+		results = append(results, gas)
+	}
+	return results, nil
+}
+
 // RPCMarshalHeader converts the given header to the RPC output .
 func RPCMarshalHeader(head *types.Header) map[string]interface{} {
 	result := map[string]interface{}{
@@ -1318,6 +1964,38 @@ func (s *TransactionAPI) GetTransactionByHash(ctx context.Context, hash common.H
 	return nil, nil
 }
 
+// PendingTransactionPosition returns the index of the given transaction in the
+// block currently being assembled by the miner, or nil if it is not included.
+func (s *TransactionAPI) PendingTransactionPosition(ctx context.Context, hash common.Hash) (*hexutil.Uint64, error) {
+	pending, _ := s.b.PendingBlockAndReceipts()
+	if pending == nil {
+		return nil, nil
+	}
+	for i, tx := range pending.Transactions() {
+		if tx.Hash() == hash {
+			index := hexutil.Uint64(i)
+			return &index, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetTransactionBySenderAndNonce returns the hash of the mined transaction sent
+// by the given account with the given nonce. It requires the node to maintain
+// the sender and nonce index, and returns nil if the transaction is not found.
+func (s *TransactionAPI) GetTransactionBySenderAndNonce(ctx context.Context, address common.Address, nonce hexutil.Uint64) (*common.Hash, error) {
+	hash := rawdb.ReadTxHashBySenderNonce(s.b.ChainDb(), address, uint64(nonce))
+	if hash == nil {
+		return nil, nil
+	}
+	// Index entries are not removed on reorgs, ensure the transaction is canonical
+	tx, _, _, _, err := s.b.GetTransaction(ctx, *hash)
+	if tx == nil || err != nil {
+		return nil, err
+	}
+	return hash, nil
+}
+
 // GetRawTransactionByHash returns the bytes of the transaction for the given hash.
 func (s *TransactionAPI) GetRawTransactionByHash(ctx context.Context, hash common.Hash) (hexutil.Bytes, error) {
 	// Retrieve a finalized transaction, or a pooled otherwise
@@ -1357,6 +2035,23 @@ func (s *TransactionAPI) GetTransactionReceipt(ctx context.Context, hash common.
 	return marshalReceipt(receipt, blockHash, blockNumber, signer, tx, int(index)), nil
 }
 
+// GetRawReceipt returns the binary-encoded consensus receipt of the transaction
+// with the given hash, as committed to by the block's receipt root.
+func (s *TransactionAPI) GetRawReceipt(ctx context.Context, hash common.Hash) (hexutil.Bytes, error) {
+	tx, blockHash, _, index, err := s.b.GetTransaction(ctx, hash)
+	if tx == nil || err != nil {
+		return nil, nil
+	}
+	receipts, err := s.b.GetReceipts(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(receipts)) <= index {
+		return nil, nil
+	}
+	return receipts[index].MarshalBinary()
+}
+
 // marshalReceipt marshals a transaction receipt into a JSON object.
 func marshalReceipt(receipt *types.Receipt, blockHash common.Hash, blockNumber uint64, signer types.Signer, tx *types.Transaction, txIndex int) map[string]interface{} {
 	from, _ := types.Sender(signer, tx)
@@ -1415,6 +2110,10 @@ func SubmitTransaction(ctx context.Context, b Backend, tx *types.Transaction) (c
 		return common.Hash{}, err
 	}
 	if err := b.SendTx(ctx, tx); err != nil {
+		var mismatch *types.ChainIdMismatchError
+		if errors.As(err, &mismatch) {
+			return common.Hash{}, &chainIDMismatchError{error: err, expected: mismatch.Want, provided: mismatch.Have}
+		}
 		return common.Hash{}, err
 	}
 	// Print a log with full tx details for manual investigations and interventions
@@ -1433,6 +2132,22 @@ func SubmitTransaction(ctx context.Context, b Backend, tx *types.Transaction) (c
 	return tx.Hash(), nil
 }
 
+// chainIDMismatchError is an API error reporting a transaction signed for another
+// chain, carrying the chain ID expected by the node and the one provided.
+type chainIDMismatchError struct {
+	error
+	expected *big.Int
+	provided *big.Int
+}
+
+// ErrorData returns the expected and provided chain IDs.
+func (e *chainIDMismatchError) ErrorData() interface{} {
+	return map[string]*hexutil.Big{
+		"expected": (*hexutil.Big)(e.expected),
+		"provided": (*hexutil.Big)(e.provided),
+	}
+}
+
 // SendTransaction creates a transaction for the given argument, sign it and submit it to the
 // transaction pool.
 func (s *TransactionAPI) SendTransaction(ctx context.Context, args TransactionArgs) (common.Hash, error) {
@@ -1489,9 +2204,56 @@ func (s *TransactionAPI) SendRawTransaction(ctx context.Context, input hexutil.B
 	if err := tx.UnmarshalBinary(input); err != nil {
 		return common.Hash{}, err
 	}
+	if err := checkTxSize(tx.Size(), s.b.RPCMaxTxSize()); err != nil {
+		return common.Hash{}, err
+	}
+	if err := checkTxGasLimit(tx.Gas(), s.b.CurrentHeader()); err != nil {
+		return common.Hash{}, err
+	}
+	if err := checkTxFutureNonce(ctx, s.b, tx); err != nil {
+		return common.Hash{}, err
+	}
 	return SubmitTransaction(ctx, s.b, tx)
 }
 
+// TxValidationResult is the outcome of checking a transaction against the
+// transaction pool admission rules.
+type TxValidationResult struct {
+	Hash     common.Hash `json:"hash"`
+	Accepted bool        `json:"accepted"`
+	Reason   string      `json:"reason,omitempty"`
+}
+
+// ValidateTransaction checks whether the given signed transaction would be
+// accepted into the transaction pool, without submitting it. Malformed inputs
+// are reported as errors, while rejections are returned with their reason.
+func (s *TransactionAPI) ValidateTransaction(ctx context.Context, input hexutil.Bytes) (*TxValidationResult, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return nil, err
+	}
+	result := &TxValidationResult{Hash: tx.Hash()}
+	err := checkTxSize(tx.Size(), s.b.RPCMaxTxSize())
+	if err == nil {
+		err = checkTxGasLimit(tx.Gas(), s.b.CurrentHeader())
+	}
+	if err == nil {
+		err = checkTxFee(tx.GasPrice(), tx.Gas(), s.b.RPCTxFeeCap())
+	}
+	if err == nil {
+		err = checkTxFutureNonce(ctx, s.b, tx)
+	}
+	if err == nil {
+		err = s.b.ValidateTx(ctx, tx)
+	}
+	if err != nil {
+		result.Reason = err.Error()
+	} else {
+		result.Accepted = true
+	}
+	return result, nil
+}
+
 // Sign calculates an ECDSA signature for:
 // keccak256("\x19Ethereum Signed Message:\n" + len(message) + message).
 //
@@ -1707,6 +2469,33 @@ func (api *DebugAPI) GetRawReceipts(ctx context.Context, blockNrOrHash rpc.Block
 	return result, nil
 }
 
+// ReceiptsRootResult holds the receipts root re-derived from a block's receipts
+// along with the one committed to in its header.
+type ReceiptsRootResult struct {
+	Computed common.Hash `json:"computed"`
+	Header   common.Hash `json:"header"`
+	Match    bool        `json:"match"`
+}
+
+// ComputeReceiptsRoot re-derives the receipts trie root of a block from its
+// stored receipts and compares it against the root in the block header.
+func (api *DebugAPI) ComputeReceiptsRoot(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*ReceiptsRootResult, error) {
+	header, err := api.b.HeaderByNumberOrHash(ctx, blockNrOrHash)
+	if header == nil || err != nil {
+		return nil, err
+	}
+	receipts, err := api.b.GetReceipts(ctx, header.Hash())
+	if err != nil {
+		return nil, err
+	}
+	computed := types.DeriveSha(receipts, trie.NewStackTrie(nil))
+	return &ReceiptsRootResult{
+		Computed: computed,
+		Header:   header.ReceiptHash,
+		Match:    computed == header.ReceiptHash,
+	}, nil
+}
+
 // GetRawTransaction returns the bytes of the transaction for the given hash.
 func (s *DebugAPI) GetRawTransaction(ctx context.Context, hash common.Hash) (hexutil.Bytes, error) {
 	// Retrieve a finalized transaction, or a pooled otherwise
@@ -1800,3 +2589,52 @@ func checkTxFee(gasPrice *big.Int, gas uint64, cap float64) error {
 	}
 	return nil
 }
+
+// checkTxSize is an internal function used to check whether the encoded size
+// of a raw transaction submitted over RPC is under the configured limit.
+func checkTxSize(size uint64, limit uint64) error {
+	// Short circuit if there is no limit for transaction size at all.
+	if limit == 0 {
+		return nil
+	}
+	if size > limit {
+		return fmt.Errorf("tx size (%d bytes) exceeds the configured limit (%d bytes)", size, limit)
+	}
+	return nil
+}
+
+// checkTxGasLimit is an internal function used to check whether the gas limit
+// of a transaction submitted over RPC fits into the current head block. Such
+// transactions could never be included, so there is no point pooling them.
+func checkTxGasLimit(gas uint64, head *types.Header) error {
+	if head == nil {
+		return nil
+	}
+	if gas > head.GasLimit {
+		return fmt.Errorf("%w: tx gas %d, block gas limit %d", txpool.ErrGasLimit, gas, head.GasLimit)
+	}
+	return nil
+}
+
+// checkTxFutureNonce is an internal function used to check whether the nonce
+// of a transaction submitted over RPC is within the configured gap of the next
+// nonce of its sender.
+func checkTxFutureNonce(ctx context.Context, b Backend, tx *types.Transaction) error {
+	gap := b.TxPoolMaxFutureNonce()
+	// Short circuit if there is no limit for the nonce gap at all.
+	if gap == 0 {
+		return nil
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return err
+	}
+	next, err := b.GetPoolNonce(ctx, from)
+	if err != nil {
+		return err
+	}
+	if tx.Nonce() > next && tx.Nonce()-next > gap {
+		return fmt.Errorf("tx nonce (%d) exceeds the next nonce of the sender (%d) by more than the configured gap (%d)", tx.Nonce(), next, gap)
+	}
+	return nil
+}
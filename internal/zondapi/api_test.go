@@ -21,10 +21,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -33,6 +35,7 @@ import (
 	"github.com/theQRL/go-zond"
 	"github.com/theQRL/go-zond/accounts"
 	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/common/bitutil"
 	"github.com/theQRL/go-zond/common/hexutil"
 	"github.com/theQRL/go-zond/consensus"
 	"github.com/theQRL/go-zond/consensus/beacon"
@@ -40,6 +43,8 @@ import (
 	"github.com/theQRL/go-zond/core/bloombits"
 	"github.com/theQRL/go-zond/core/rawdb"
 	"github.com/theQRL/go-zond/core/state"
+	"github.com/theQRL/go-zond/core/txpool"
+	"github.com/theQRL/go-zond/core/txpool/legacypool"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/core/vm"
 	"github.com/theQRL/go-zond/crypto"
@@ -348,9 +353,13 @@ func allTransactionTypes(addr common.Address, config *params.ChainConfig) []txDa
 }
 
 type testBackend struct {
-	db      zonddb.Database
-	chain   *core.BlockChain
-	pending *types.Block
+	db        zonddb.Database
+	chain     *core.BlockChain
+	pending   *types.Block
+	maxTxSize uint64
+	maxNonce  uint64
+	pool      map[common.Address][]*types.Transaction
+	locals    []common.Address
 }
 
 func newTestBackend(t *testing.T, n int, gspec *core.Genesis, engine consensus.Engine, generator func(i int, b *core.BlockGen)) *testBackend {
@@ -361,6 +370,7 @@ func newTestBackend(t *testing.T, n int, gspec *core.Genesis, engine consensus.E
 			TrieTimeLimit:     5 * time.Minute,
 			SnapshotLimit:     0,
 			TrieDirtyDisabled: true, // Archive mode
+			SenderNonceIndex:  true,
 		}
 	)
 	// Generate blocks for testing
@@ -386,6 +396,9 @@ func (b testBackend) SyncProgress() zond.SyncProgress { return zond.SyncProgress
 func (b testBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
 	return big.NewInt(0), nil
 }
+func (b testBackend) SuggestGasTipCapAtPercentile(ctx context.Context, percentile int) (*big.Int, error) {
+	return big.NewInt(int64(percentile)), nil
+}
 func (b testBackend) FeeHistory(ctx context.Context, blockCount uint64, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*big.Int, [][]*big.Int, []*big.Int, []float64, error) {
 	return nil, nil, nil, nil, nil
 }
@@ -395,6 +408,8 @@ func (b testBackend) ExtRPCEnabled() bool               { return false }
 func (b testBackend) RPCGasCap() uint64                 { return 10000000 }
 func (b testBackend) RPCEVMTimeout() time.Duration      { return time.Second }
 func (b testBackend) RPCTxFeeCap() float64              { return 0 }
+func (b testBackend) RPCMaxTxSize() uint64              { return b.maxTxSize }
+func (b testBackend) TxPoolMaxFutureNonce() uint64      { return b.maxNonce }
 func (b testBackend) SetHead(number uint64)             {}
 func (b testBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
 	if number == rpc.LatestBlockNumber {
@@ -464,7 +479,7 @@ func (b testBackend) StateAndHeaderByNumberOrHash(ctx context.Context, blockNrOr
 	}
 	panic("only implemented for number")
 }
-func (b testBackend) PendingBlockAndReceipts() (*types.Block, types.Receipts) { panic("implement me") }
+func (b testBackend) PendingBlockAndReceipts() (*types.Block, types.Receipts) { return b.pending, nil }
 func (b testBackend) GetReceipts(ctx context.Context, hash common.Hash) (types.Receipts, error) {
 	header, err := b.HeaderByHash(ctx, hash)
 	if header == nil || err != nil {
@@ -498,20 +513,58 @@ func (b testBackend) SubscribeChainSideEvent(ch chan<- core.ChainSideEvent) even
 func (b testBackend) SendTx(ctx context.Context, signedTx *types.Transaction) error {
 	panic("implement me")
 }
+func (b testBackend) ValidateTx(ctx context.Context, signedTx *types.Transaction) error {
+	panic("implement me")
+}
 func (b testBackend) GetTransaction(ctx context.Context, txHash common.Hash) (*types.Transaction, common.Hash, uint64, uint64, error) {
 	tx, blockHash, blockNumber, index := rawdb.ReadTransaction(b.db, txHash)
 	return tx, blockHash, blockNumber, index, nil
 }
-func (b testBackend) GetPoolTransactions() (types.Transactions, error)         { panic("implement me") }
-func (b testBackend) GetPoolTransaction(txHash common.Hash) *types.Transaction { panic("implement me") }
+func (b testBackend) GetPoolTransactions() (types.Transactions, error) { panic("implement me") }
+func (b testBackend) GetPoolTransaction(txHash common.Hash) *types.Transaction {
+	for _, txs := range b.pool {
+		for _, tx := range txs {
+			if tx.Hash() == txHash {
+				return tx
+			}
+		}
+	}
+	return nil
+}
 func (b testBackend) GetPoolNonce(ctx context.Context, addr common.Address) (uint64, error) {
-	panic("implement me")
+	state, err := b.chain.State()
+	if err != nil {
+		return 0, err
+	}
+	return state.GetNonce(addr), nil
 }
 func (b testBackend) Stats() (pending int, queued int) { panic("implement me") }
 func (b testBackend) TxPoolContent() (map[common.Address][]*types.Transaction, map[common.Address][]*types.Transaction) {
-	panic("implement me")
+	return b.pool, nil
 }
 func (b testBackend) TxPoolContentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction) {
+	return b.pool[addr], nil
+}
+func (b testBackend) TxPoolPending(enforceTips bool) map[common.Address][]*txpool.LazyTransaction {
+	pending := make(map[common.Address][]*txpool.LazyTransaction, len(b.pool))
+	for addr, txs := range b.pool {
+		for _, tx := range txs {
+			pending[addr] = append(pending[addr], &txpool.LazyTransaction{
+				Hash:      tx.Hash(),
+				Tx:        tx,
+				Time:      tx.Time(),
+				GasFeeCap: tx.GasFeeCap(),
+				GasTipCap: tx.GasTipCap(),
+			})
+		}
+	}
+	return pending
+}
+func (b testBackend) TxPoolLocals() []common.Address { return b.locals }
+func (b testBackend) TxPoolRecentlyDropped(limit int) []*txpool.DroppedTx {
+	panic("implement me")
+}
+func (b testBackend) TxPoolReplacementHistory(addr common.Address, nonce uint64) []*txpool.ReplacedTx {
 	panic("implement me")
 }
 func (b testBackend) SubscribeNewTxsEvent(events chan<- core.NewTxsEvent) event.Subscription {
@@ -639,6 +692,57 @@ func TestEstimateGas(t *testing.T) {
 	}
 }
 
+func TestEstimateGasBatch(t *testing.T) {
+	t.Parallel()
+	var (
+		accounts = newAccounts(2)
+		genesis  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: core.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+			},
+		}
+		api    = NewBlockChainAPI(newTestBackend(t, 1, genesis, beacon.NewFaker(), func(i int, b *core.BlockGen) {}))
+		latest = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	)
+	// The second message spends funds that only the first one provides.
+	msgs := []TransactionArgs{
+		{
+			From:  &accounts[0].addr,
+			To:    &accounts[1].addr,
+			Value: (*hexutil.Big)(big.NewInt(1000)),
+		},
+		{
+			From:  &accounts[1].addr,
+			To:    &accounts[0].addr,
+			Value: (*hexutil.Big)(big.NewInt(1000)),
+		},
+	}
+	if _, err := api.EstimateGas(context.Background(), msgs[1], &latest, nil); !errors.Is(err, core.ErrInsufficientFunds) {
+		t.Fatalf("independent estimate error mismatch: have %v, want %v", err, core.ErrInsufficientFunds)
+	}
+	gas, err := api.EstimateGasBatch(context.Background(), msgs, &latest)
+	if err != nil {
+		t.Fatalf("failed to estimate batch: %v", err)
+	}
+	want := []hexutil.Uint64{hexutil.Uint64(params.TxGas), hexutil.Uint64(params.TxGas)}
+	if !reflect.DeepEqual(gas, want) {
+		t.Fatalf("estimate mismatch: have %v, want %v", gas, want)
+	}
+	// Reversing the order makes the first message fail.
+	if _, err := api.EstimateGasBatch(context.Background(), []TransactionArgs{msgs[1], msgs[0]}, &latest); !errors.Is(err, core.ErrInsufficientFunds) {
+		t.Fatalf("reversed batch error mismatch: have %v, want %v", err, core.ErrInsufficientFunds)
+	}
+	// Batches above the limit are rejected without executing any message.
+	oversized := make([]TransactionArgs, maxEstimateGasBatch+1)
+	for i := range oversized {
+		oversized[i] = msgs[0]
+	}
+	if _, err := api.EstimateGasBatch(context.Background(), oversized, &latest); err == nil || !strings.Contains(err.Error(), "requested batch too large") {
+		t.Fatalf("oversized batch error mismatch: have %v", err)
+	}
+}
+
 func TestCall(t *testing.T) {
 	t.Parallel()
 	// Initialize test accounts
@@ -1530,3 +1634,975 @@ func testRPCResponseWithFile(t *testing.T, testid int, result interface{}, rpc s
 	}
 	require.JSONEqf(t, string(want), string(data), "test %d: json not match, want: %s, have: %s", testid, string(want), string(data))
 }
+
+func TestRPCGetTotalDifficulty(t *testing.T) {
+	t.Parallel()
+
+	var (
+		genesis = &core.Genesis{Config: params.TestChainConfig}
+		backend = newTestBackend(t, 4, genesis, beacon.NewFaker(), func(i int, b *core.BlockGen) {})
+		api     = NewBlockChainAPI(backend)
+		ctx     = context.Background()
+	)
+	head := backend.chain.CurrentBlock()
+	for i, blockNrOrHash := range []rpc.BlockNumberOrHash{
+		rpc.BlockNumberOrHashWithNumber(0),
+		rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber),
+		rpc.BlockNumberOrHashWithHash(head.Hash(), false),
+	} {
+		td, err := api.GetTotalDifficulty(ctx, blockNrOrHash)
+		if err != nil {
+			t.Fatalf("test %d: unexpected error: %v", i, err)
+		}
+		if td == nil || td.ToInt().Sign() != 0 {
+			t.Fatalf("test %d: total difficulty mismatch: have %v, want 0", i, td)
+		}
+	}
+	for i, blockNrOrHash := range []rpc.BlockNumberOrHash{
+		rpc.BlockNumberOrHashWithNumber(100),
+		rpc.BlockNumberOrHashWithHash(common.Hash{0x01}, false),
+	} {
+		td, err := api.GetTotalDifficulty(ctx, blockNrOrHash)
+		if err != nil {
+			t.Fatalf("unknown block %d: unexpected error: %v", i, err)
+		}
+		if td != nil {
+			t.Fatalf("unknown block %d: have %v, want nil", i, td)
+		}
+	}
+}
+
+func TestRPCGasSchedule(t *testing.T) {
+	t.Parallel()
+
+	var (
+		genesis = &core.Genesis{Config: params.TestChainConfig}
+		backend = newTestBackend(t, 1, genesis, beacon.NewFaker(), func(i int, b *core.BlockGen) {})
+		api     = NewBlockChainAPI(backend)
+	)
+	schedule, err := api.GasSchedule()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for name, want := range map[string]uint64{
+		"STOP":   0,
+		"ADD":    3,
+		"MUL":    5,
+		"PUSH0":  2,
+		"PUSH1":  3,
+		"JUMPI":  10,
+		"SSTORE": 0,
+	} {
+		have, ok := schedule[name]
+		if !ok {
+			t.Errorf("opcode %s missing from schedule", name)
+			continue
+		}
+		if uint64(have) != want {
+			t.Errorf("opcode %s: gas mismatch: have %d, want %d", name, have, want)
+		}
+	}
+	if _, ok := schedule[vm.OpCode(0x0c).String()]; ok {
+		t.Errorf("undefined opcode present in schedule")
+	}
+}
+
+func TestRPCBlockTipDistribution(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _  = pqcrypto.HexToDilithium("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+		addr    = key.GetAddress()
+		to      = common.Address{0x01}
+		genesis = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  core.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+		}
+		signer = types.LatestSigner(params.TestChainConfig)
+	)
+	backend := newTestBackend(t, 1, genesis, beacon.NewFaker(), func(i int, b *core.BlockGen) {
+		// The fourth transaction's tip is capped by its fee cap at 2 wei above the base fee.
+		for nonce, tip := range []struct{ tipCap, feeCapExtra int64 }{
+			{1, 1000}, {5, 1000}, {3, 1000}, {100, 2}, {10, 1000},
+		} {
+			tx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+				Nonce:     uint64(nonce),
+				To:        &to,
+				Gas:       params.TxGas,
+				GasTipCap: big.NewInt(tip.tipCap),
+				GasFeeCap: new(big.Int).Add(b.BaseFee(), big.NewInt(tip.feeCapExtra)),
+			}), signer, key)
+			if err != nil {
+				t.Fatalf("failed to sign tx: %v", err)
+			}
+			b.AddTx(tx)
+		}
+	})
+	api := NewBlockChainAPI(backend)
+	ctx := context.Background()
+
+	dist, err := api.BlockTipDistribution(ctx, rpc.BlockNumberOrHashWithNumber(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for name, have := range map[string]*hexutil.Big{"min": dist.Min, "median": dist.Median, "max": dist.Max} {
+		want := map[string]int64{"min": 1, "median": 3, "max": 10}[name]
+		if have == nil || have.ToInt().Int64() != want {
+			t.Errorf("%s tip mismatch: have %v, want %d", name, have, want)
+		}
+	}
+	// Blocks without transactions have no distribution.
+	dist, err = api.BlockTipDistribution(ctx, rpc.BlockNumberOrHashWithNumber(0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dist == nil || dist.Min != nil || dist.Median != nil || dist.Max != nil {
+		t.Fatalf("empty block distribution mismatch: have %+v", dist)
+	}
+	// Unknown blocks yield nil.
+	if dist, err = api.BlockTipDistribution(ctx, rpc.BlockNumberOrHashWithNumber(100)); dist != nil || err != nil {
+		t.Fatalf("unknown block: have %v, %v, want nil", dist, err)
+	}
+}
+
+func TestRPCComputeReceiptsRoot(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _  = pqcrypto.HexToDilithium("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+		addr    = key.GetAddress()
+		to      = common.Address{0x01}
+		genesis = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  core.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+		}
+		signer = types.LatestSigner(params.TestChainConfig)
+	)
+	backend := newTestBackend(t, 1, genesis, beacon.NewFaker(), func(i int, b *core.BlockGen) {
+		for nonce := uint64(0); nonce < 3; nonce++ {
+			tx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+				Nonce:     nonce,
+				To:        &to,
+				Gas:       params.TxGas,
+				GasFeeCap: b.BaseFee(),
+			}), signer, key)
+			if err != nil {
+				t.Fatalf("failed to sign tx: %v", err)
+			}
+			b.AddTx(tx)
+		}
+	})
+	api := NewDebugAPI(backend)
+	ctx := context.Background()
+
+	block := backend.chain.GetBlockByNumber(1)
+	if have := len(block.Transactions()); have != 3 {
+		t.Fatalf("transaction count mismatch: have %d, want 3", have)
+	}
+	for i, blockNrOrHash := range []rpc.BlockNumberOrHash{
+		rpc.BlockNumberOrHashWithNumber(0),
+		rpc.BlockNumberOrHashWithNumber(1),
+		rpc.BlockNumberOrHashWithHash(block.Hash(), false),
+	} {
+		result, err := api.ComputeReceiptsRoot(ctx, blockNrOrHash)
+		if err != nil {
+			t.Fatalf("test %d: unexpected error: %v", i, err)
+		}
+		if result == nil || !result.Match || result.Computed != result.Header {
+			t.Fatalf("test %d: receipts root mismatch: %+v", i, result)
+		}
+	}
+	if result, _ := api.ComputeReceiptsRoot(ctx, rpc.BlockNumberOrHashWithNumber(1)); result.Header != block.ReceiptHash() {
+		t.Fatalf("header root mismatch: have %x, want %x", result.Header, block.ReceiptHash())
+	}
+	// Unknown blocks yield nil.
+	if result, err := api.ComputeReceiptsRoot(ctx, rpc.BlockNumberOrHashWithNumber(100)); result != nil || err != nil {
+		t.Fatalf("unknown block: have %v, %v, want nil", result, err)
+	}
+}
+
+func TestRPCBlockCoinbaseReward(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _   = pqcrypto.HexToDilithium("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+		addr     = key.GetAddress()
+		to       = common.Address{0x01}
+		coinbase = common.Address{0xc0, 0x1b}
+		genesis  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  core.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+		}
+		signer = types.LatestSigner(params.TestChainConfig)
+	)
+	backend := newTestBackend(t, 1, genesis, beacon.NewFaker(), func(i int, b *core.BlockGen) {
+		b.SetCoinbase(coinbase)
+		for nonce := uint64(0); nonce < 3; nonce++ {
+			tx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+				Nonce:     nonce,
+				To:        &to,
+				Gas:       params.TxGas,
+				GasTipCap: big.NewInt(int64(nonce+1) * params.GWei),
+				GasFeeCap: new(big.Int).Add(b.BaseFee(), big.NewInt(2*params.GWei)),
+			}), signer, key)
+			if err != nil {
+				t.Fatalf("failed to sign tx: %v", err)
+			}
+			b.AddTx(tx)
+		}
+	})
+	api := NewBlockChainAPI(backend)
+	ctx := context.Background()
+
+	reward, err := api.BlockCoinbaseReward(ctx, rpc.BlockNumberOrHashWithNumber(1))
+	if err != nil {
+		t.Fatalf("failed to compute coinbase reward: %v", err)
+	}
+	// The last transaction's tip is capped by its fee cap to 2 gwei
+	want := big.NewInt(int64(params.TxGas) * (1 + 2 + 2) * params.GWei)
+	if reward.ToInt().Cmp(want) != 0 {
+		t.Fatalf("coinbase reward mismatch: have %v, want %v", reward.ToInt(), want)
+	}
+	before, err := api.GetBalance(ctx, coinbase, rpc.BlockNumberOrHashWithNumber(0))
+	if err != nil {
+		t.Fatalf("failed to get coinbase balance: %v", err)
+	}
+	after, err := api.GetBalance(ctx, coinbase, rpc.BlockNumberOrHashWithNumber(1))
+	if err != nil {
+		t.Fatalf("failed to get coinbase balance: %v", err)
+	}
+	if delta := new(big.Int).Sub(after.ToInt(), before.ToInt()); delta.Cmp(reward.ToInt()) != 0 {
+		t.Fatalf("coinbase balance delta mismatch: have %v, want %v", delta, reward.ToInt())
+	}
+	// Unknown blocks yield nil.
+	if reward, err := api.BlockCoinbaseReward(ctx, rpc.BlockNumberOrHashWithNumber(100)); reward != nil || err != nil {
+		t.Fatalf("unknown block: have %v, %v, want nil", reward, err)
+	}
+}
+
+func TestSendRawTransactionFutureNonce(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _  = pqcrypto.HexToDilithium("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+		to      = common.Address{0x01}
+		genesis = &core.Genesis{Config: params.TestChainConfig}
+		backend = newTestBackend(t, 0, genesis, beacon.NewFaker(), func(i int, b *core.BlockGen) {})
+		signer  = types.LatestSigner(params.TestChainConfig)
+	)
+	tx, err := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+		Nonce:     100,
+		To:        &to,
+		Gas:       params.TxGas,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(params.GWei),
+	})
+	if err != nil {
+		t.Fatalf("failed to sign tx: %v", err)
+	}
+	enc, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to encode tx: %v", err)
+	}
+	backend.maxNonce = 16
+	_, err = NewTransactionAPI(backend, nil).SendRawTransaction(context.Background(), enc)
+	if err == nil || !strings.Contains(err.Error(), "by more than the configured gap") {
+		t.Fatalf("far-future nonce not rejected: %v", err)
+	}
+	// Validation without submission applies the same limit
+	api := NewTransactionAPI(futureNonceBackend{backend}, nil)
+	res, err := api.ValidateTransaction(context.Background(), enc)
+	if err != nil {
+		t.Fatalf("failed to validate tx: %v", err)
+	}
+	if res.Accepted || !strings.Contains(res.Reason, "by more than the configured gap") {
+		t.Fatalf("far-future nonce not rejected: %+v", res)
+	}
+	// A gap reaching past the nonce space must not overflow into a rejection
+	backend.maxNonce = math.MaxUint64
+	api = NewTransactionAPI(futureNonceBackend{backend}, nil)
+	if res, err = api.ValidateTransaction(context.Background(), enc); err != nil {
+		t.Fatalf("failed to validate tx: %v", err)
+	}
+	if !res.Accepted {
+		t.Fatalf("nonce within an unbounded gap rejected: %s", res.Reason)
+	}
+}
+
+// futureNonceBackend is a backend accepting every transaction into the pool,
+// leaving only the RPC level checks to reject them.
+type futureNonceBackend struct {
+	testBackend
+}
+
+func (b futureNonceBackend) ValidateTx(ctx context.Context, signedTx *types.Transaction) error {
+	return nil
+}
+
+func TestRPCHeadSummary(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _  = pqcrypto.HexToDilithium("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+		addr    = key.GetAddress()
+		to      = common.Address{0x01}
+		genesis = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  core.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+		}
+		signer = types.LatestSigner(params.TestChainConfig)
+	)
+	backend := newTestBackend(t, 2, genesis, beacon.NewFaker(), func(i int, b *core.BlockGen) {
+		for j := 0; j < 2; j++ {
+			tx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+				Nonce:     b.TxNonce(addr),
+				To:        &to,
+				Gas:       params.TxGas,
+				GasFeeCap: b.BaseFee(),
+			}), signer, key)
+			if err != nil {
+				t.Fatalf("failed to sign tx: %v", err)
+			}
+			b.AddTx(tx)
+		}
+	})
+	ctx := context.Background()
+
+	summary, err := NewBlockChainAPI(backend).HeadSummary(ctx)
+	if err != nil {
+		t.Fatalf("failed to retrieve head summary: %v", err)
+	}
+	header, _ := backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	block, _ := backend.BlockByNumber(ctx, rpc.LatestBlockNumber)
+	want := &HeadSummary{
+		Number:    hexutil.Uint64(header.Number.Uint64()),
+		Hash:      header.Hash(),
+		Timestamp: hexutil.Uint64(header.Time),
+		BaseFee:   (*hexutil.Big)(header.BaseFee),
+		GasUsed:   hexutil.Uint64(header.GasUsed),
+		GasLimit:  hexutil.Uint64(header.GasLimit),
+		TxCount:   2,
+		Size:      hexutil.Uint64(block.Size()),
+	}
+	if !reflect.DeepEqual(summary, want) {
+		t.Fatalf("head summary mismatch:\nhave %+v\nwant %+v", summary, want)
+	}
+}
+
+func TestRPCForkStatus(t *testing.T) {
+	t.Parallel()
+
+	allowEF := *params.TestChainConfig
+	allowEF.AllowEFCodePrefix = true
+
+	for _, test := range []struct {
+		config *params.ChainConfig
+		want   []ForkStatus
+	}{
+		{
+			config: params.TestChainConfig,
+			want:   []ForkStatus{{Name: "shanghai", Active: true}, {Name: "eip3541", Active: true}},
+		},
+		{
+			config: &allowEF,
+			want:   []ForkStatus{{Name: "shanghai", Active: true}, {Name: "eip3541", Active: false}},
+		},
+	} {
+		genesis := &core.Genesis{Config: test.config}
+		backend := newTestBackend(t, 1, genesis, beacon.NewFaker(), func(i int, b *core.BlockGen) {})
+
+		status, err := NewBlockChainAPI(backend).ForkStatus(context.Background())
+		if err != nil {
+			t.Fatalf("failed to retrieve fork status: %v", err)
+		}
+		if !reflect.DeepEqual(status, test.want) {
+			t.Errorf("fork status mismatch (allow EF prefix %v): have %+v, want %+v", test.config.AllowEFCodePrefix, status, test.want)
+		}
+	}
+}
+
+// bloomBackend is a backend reporting a custom bloom-bits indexing status.
+type bloomBackend struct {
+	testBackend
+	size, sections uint64
+}
+
+func (b bloomBackend) BloomStatus() (uint64, uint64) { return b.size, b.sections }
+
+func TestRPCGetBloomBits(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _  = pqcrypto.HexToDilithium("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+		addr    = key.GetAddress()
+		logger  = common.Address{0x10, 0x99}
+		genesis = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: core.GenesisAlloc{
+				addr: {Balance: big.NewInt(params.Ether)},
+				// Emits a single empty log
+				logger: {Balance: new(big.Int), Code: []byte{byte(vm.PUSH1), 0x0, byte(vm.PUSH1), 0x0, byte(vm.LOG0)}},
+			},
+		}
+		signer = types.LatestSigner(params.TestChainConfig)
+	)
+	const size = 8
+	backend := newTestBackend(t, 2*size, genesis, beacon.NewFaker(), func(i int, b *core.BlockGen) {
+		// Only log in every other block
+		if i%2 == 1 {
+			return
+		}
+		tx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+			Nonce:     b.TxNonce(addr),
+			To:        &logger,
+			Gas:       100000,
+			GasFeeCap: b.BaseFee(),
+		}), signer, key)
+		if err != nil {
+			t.Fatalf("failed to sign tx: %v", err)
+		}
+		b.AddTx(tx)
+	})
+	// Index the first section of blocks
+	gen, err := bloombits.NewGenerator(size)
+	if err != nil {
+		t.Fatalf("failed to create bloom generator: %v", err)
+	}
+	for i := uint64(0); i < size; i++ {
+		if err := gen.AddBloom(uint(i), backend.chain.GetHeaderByNumber(i).Bloom); err != nil {
+			t.Fatalf("failed to add bloom %d: %v", i, err)
+		}
+	}
+	head := backend.chain.GetHeaderByNumber(size - 1).Hash()
+	for bit := uint(0); bit < types.BloomBitLength; bit++ {
+		bits, err := gen.Bitset(bit)
+		if err != nil {
+			t.Fatalf("failed to retrieve bitset %d: %v", bit, err)
+		}
+		rawdb.WriteBloomBits(backend.db, bit, 0, head, bitutil.CompressBytes(bits))
+	}
+	api := NewBlockChainAPI(bloomBackend{testBackend: backend, size: size, sections: 1})
+	ctx := context.Background()
+
+	for number := uint64(0); number <= 2*size; number++ {
+		header := backend.chain.GetHeaderByNumber(number)
+		result, err := api.GetBloomBits(ctx, rpc.BlockNumber(number))
+		if err != nil {
+			t.Fatalf("block %d: failed to retrieve bloom bits: %v", number, err)
+		}
+		if result.Bloom != header.Bloom {
+			t.Fatalf("block %d: bloom mismatch: have %x, want %x", number, result.Bloom, header.Bloom)
+		}
+		if have, want := uint64(result.Section), number/size; have != want {
+			t.Fatalf("block %d: section mismatch: have %d, want %d", number, have, want)
+		}
+		switch {
+		case number < size && (result.IndexedBloom == nil || *result.IndexedBloom != header.Bloom):
+			t.Fatalf("block %d: indexed bloom mismatch: have %x, want %x", number, result.IndexedBloom, header.Bloom)
+		case number >= size && result.IndexedBloom != nil:
+			t.Fatalf("block %d: unexpected indexed bloom for unindexed section", number)
+		}
+	}
+	if (backend.chain.GetHeaderByNumber(1).Bloom == types.Bloom{}) {
+		t.Fatal("test blocks contain no logs")
+	}
+	// Unknown blocks yield nil.
+	if result, err := api.GetBloomBits(ctx, rpc.BlockNumber(100)); result != nil || err != nil {
+		t.Fatalf("unknown block: have %v, %v, want nil", result, err)
+	}
+}
+
+func TestSendRawTransactionMaxSize(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _  = pqcrypto.HexToDilithium("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+		to      = common.Address{0x01}
+		genesis = &core.Genesis{Config: params.TestChainConfig}
+		backend = newTestBackend(t, 0, genesis, beacon.NewFaker(), func(i int, b *core.BlockGen) {})
+		signer  = types.LatestSigner(params.TestChainConfig)
+	)
+	sign := func(data []byte) hexutil.Bytes {
+		tx, err := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+			To:        &to,
+			Gas:       1_000_000,
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: big.NewInt(params.GWei),
+			Data:      data,
+		})
+		if err != nil {
+			t.Fatalf("failed to sign tx: %v", err)
+		}
+		enc, err := tx.MarshalBinary()
+		if err != nil {
+			t.Fatalf("failed to encode tx: %v", err)
+		}
+		return enc
+	}
+	// Allow transactions up to the size of one without payload.
+	backend.maxTxSize = uint64(len(sign(nil)))
+	api := NewTransactionAPI(backend, nil)
+
+	_, err := api.SendRawTransaction(context.Background(), sign(make([]byte, 1)))
+	if err == nil || !strings.Contains(err.Error(), "exceeds the configured limit") {
+		t.Fatalf("oversized transaction not rejected: %v", err)
+	}
+}
+
+func TestSendRawTransactionExceedsBlockGasLimit(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _  = pqcrypto.HexToDilithium("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+		to      = common.Address{0x01}
+		genesis = &core.Genesis{Config: params.TestChainConfig}
+		backend = newTestBackend(t, 0, genesis, beacon.NewFaker(), func(i int, b *core.BlockGen) {})
+		signer  = types.LatestSigner(params.TestChainConfig)
+	)
+	tx, err := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+		To:        &to,
+		Gas:       backend.CurrentHeader().GasLimit + 1,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(params.GWei),
+	})
+	if err != nil {
+		t.Fatalf("failed to sign tx: %v", err)
+	}
+	enc, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to encode tx: %v", err)
+	}
+	_, err = NewTransactionAPI(backend, nil).SendRawTransaction(context.Background(), enc)
+	if !errors.Is(err, txpool.ErrGasLimit) {
+		t.Fatalf("transaction above the block gas limit not rejected: have %v, want %v", err, txpool.ErrGasLimit)
+	}
+}
+
+// chainIDBackend is a backend running the basic pool validation rules on the
+// submitted transactions.
+type chainIDBackend struct {
+	*backendMock
+}
+
+func (b chainIDBackend) SendTx(ctx context.Context, signedTx *types.Transaction) error {
+	return txpool.ValidateTransaction(signedTx, b.current, types.LatestSigner(b.config), &txpool.ValidationOptions{
+		Config:  b.config,
+		Accept:  1 << types.DynamicFeeTxType,
+		MaxSize: 128 * 1024,
+		MinTip:  new(big.Int),
+	})
+}
+
+func TestSendRawTransactionChainIDMismatch(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _  = pqcrypto.HexToDilithium("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+		to      = common.Address{0x01}
+		backend = chainIDBackend{newBackendMock()}
+		other   = big.NewInt(7)
+	)
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(other), &types.DynamicFeeTx{
+		ChainID:   other,
+		To:        &to,
+		Gas:       params.TxGas,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(10),
+	})
+	if err != nil {
+		t.Fatalf("failed to sign tx: %v", err)
+	}
+	enc, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to encode tx: %v", err)
+	}
+	_, err = NewTransactionAPI(backend, nil).SendRawTransaction(context.Background(), enc)
+	if !errors.Is(err, txpool.ErrInvalidSender) || !errors.Is(err, types.ErrInvalidChainId) {
+		t.Fatalf("wrong-chain transaction error mismatch: %v", err)
+	}
+	derr, ok := err.(rpc.DataError)
+	if !ok {
+		t.Fatalf("error carries no data: %v", err)
+	}
+	want := map[string]*hexutil.Big{
+		"expected": (*hexutil.Big)(backend.config.ChainID),
+		"provided": (*hexutil.Big)(other),
+	}
+	if have := derr.ErrorData(); !reflect.DeepEqual(have, want) {
+		t.Fatalf("error data mismatch: have %v, want %v", have, want)
+	}
+}
+
+func TestRPCGetTransactionBySenderAndNonce(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _  = pqcrypto.HexToDilithium("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+		addr    = key.GetAddress()
+		to      = common.Address{0x01}
+		genesis = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  core.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+		}
+		signer = types.LatestSigner(params.TestChainConfig)
+		txs    []*types.Transaction
+	)
+	backend := newTestBackend(t, 2, genesis, beacon.NewFaker(), func(i int, b *core.BlockGen) {
+		tx, err := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+			Nonce:     uint64(i),
+			To:        &to,
+			Gas:       params.TxGas,
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: b.BaseFee(),
+		})
+		if err != nil {
+			t.Fatalf("failed to sign tx: %v", err)
+		}
+		b.AddTx(tx)
+		txs = append(txs, tx)
+	})
+	api := NewTransactionAPI(backend, nil)
+	ctx := context.Background()
+
+	for nonce, tx := range txs {
+		hash, err := api.GetTransactionBySenderAndNonce(ctx, addr, hexutil.Uint64(nonce))
+		if err != nil {
+			t.Fatalf("nonce %d: unexpected error: %v", nonce, err)
+		}
+		if hash == nil || *hash != tx.Hash() {
+			t.Fatalf("nonce %d: hash mismatch: have %v, want %v", nonce, hash, tx.Hash())
+		}
+	}
+	// Unknown nonces and senders yield nil.
+	for i, query := range []struct {
+		addr  common.Address
+		nonce uint64
+	}{{addr, uint64(len(txs))}, {to, 0}} {
+		hash, err := api.GetTransactionBySenderAndNonce(ctx, query.addr, hexutil.Uint64(query.nonce))
+		if err != nil {
+			t.Fatalf("query %d: unexpected error: %v", i, err)
+		}
+		if hash != nil {
+			t.Fatalf("query %d: have %v, want nil", i, hash)
+		}
+	}
+}
+
+func TestRPCMempoolMinTip(t *testing.T) {
+	t.Parallel()
+
+	genesis := &core.Genesis{Config: params.TestChainConfig}
+	backend := newTestBackend(t, 0, genesis, beacon.NewFaker(), nil)
+	api := NewEthereumAPI(backend)
+
+	var (
+		baseFee = big.NewInt(params.GWei)
+		header  = &types.Header{
+			Number:   big.NewInt(1),
+			GasLimit: 4 * params.TxGas,
+			BaseFee:  baseFee,
+		}
+	)
+	backend.setPendingBlock(types.NewBlockWithHeader(header))
+
+	newTx := func(nonce uint64, gas uint64, tip int64, feeCap *big.Int) *types.Transaction {
+		return types.NewTx(&types.DynamicFeeTx{
+			Nonce:     nonce,
+			Gas:       gas,
+			GasTipCap: big.NewInt(tip * params.GWei),
+			GasFeeCap: feeCap,
+		})
+	}
+	feeCap := big.NewInt(100 * params.GWei)
+
+	// An empty pool has no marginal tip
+	tip, err := api.MempoolMinTip(context.Background())
+	if err != nil {
+		t.Fatalf("failed to retrieve tip: %v", err)
+	}
+	if tip != nil {
+		t.Fatalf("empty pool: have tip %v, want nil", tip)
+	}
+	// Fill the pool with more transactions than fit into the pending block
+	backend.pool = map[common.Address][]*types.Transaction{
+		{0x01}: {newTx(0, params.TxGas, 10, feeCap), newTx(1, params.TxGas, 2, feeCap)},
+		{0x02}: {newTx(0, params.TxGas, 5, feeCap)},
+		{0x03}: {newTx(0, params.TxGas, 4, feeCap)},
+		{0x04}: {newTx(0, params.TxGas, 3, feeCap)},
+		{0x05}: {newTx(0, params.TxGas, 1, feeCap)},
+		{0x06}: {newTx(0, params.TxGas, 50, baseFee)},       // fee cap leaves no tip
+		{0x07}: {newTx(0, params.TxGas, 99, big.NewInt(1))}, // fee cap below base fee
+		{0x08}: {newTx(0, 5*params.TxGas, 8, feeCap)},       // exceeds the block gas limit
+	}
+	tip, err = api.MempoolMinTip(context.Background())
+	if err != nil {
+		t.Fatalf("failed to retrieve tip: %v", err)
+	}
+	// Included are the transactions tipping 10, 5, 4 and 3 gwei
+	if want := big.NewInt(3 * params.GWei); tip == nil || tip.ToInt().Cmp(want) != 0 {
+		t.Fatalf("marginal tip mismatch: have %v, want %v", tip, want)
+	}
+	// Local transactions are packed first, regardless of their tip
+	backend.locals = []common.Address{{0x05}}
+	tip, err = api.MempoolMinTip(context.Background())
+	if err != nil {
+		t.Fatalf("failed to retrieve tip: %v", err)
+	}
+	if want := big.NewInt(1 * params.GWei); tip == nil || tip.ToInt().Cmp(want) != 0 {
+		t.Fatalf("marginal tip with locals mismatch: have %v, want %v", tip, want)
+	}
+}
+
+func TestRPCPendingTransactionPosition(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _  = pqcrypto.HexToDilithium("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		genesis = &core.Genesis{Config: params.TestChainConfig}
+		signer  = types.LatestSigner(genesis.Config)
+		backend = newTestBackend(t, 0, genesis, beacon.NewFaker(), nil)
+		api     = NewTransactionAPI(backend, new(AddrLocker))
+	)
+	var txs []*types.Transaction
+	for nonce := uint64(0); nonce < 5; nonce++ {
+		tx, err := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+			Nonce:     nonce,
+			To:        &common.Address{0x01},
+			Gas:       params.TxGas,
+			GasFeeCap: big.NewInt(params.GWei),
+		})
+		if err != nil {
+			t.Fatalf("failed to sign tx: %v", err)
+		}
+		txs = append(txs, tx)
+	}
+	// Without a pending block no position is known
+	if pos, err := api.PendingTransactionPosition(context.Background(), txs[0].Hash()); err != nil || pos != nil {
+		t.Fatalf("no pending block: have %v (err %v), want nil", pos, err)
+	}
+	// Include all but the last transaction in the pending block
+	header := &types.Header{Number: big.NewInt(1), GasLimit: params.GenesisGasLimit}
+	backend.setPendingBlock(types.NewBlock(header, txs[:4], nil, blocktest.NewHasher()))
+
+	for i, tx := range txs[:4] {
+		pos, err := api.PendingTransactionPosition(context.Background(), tx.Hash())
+		if err != nil {
+			t.Fatalf("tx %d: failed to retrieve position: %v", i, err)
+		}
+		if pos == nil || uint64(*pos) != uint64(i) {
+			t.Fatalf("tx %d: position mismatch: have %v, want %d", i, pos, i)
+		}
+	}
+	if pos, err := api.PendingTransactionPosition(context.Background(), txs[4].Hash()); err != nil || pos != nil {
+		t.Fatalf("excluded tx: have %v (err %v), want nil", pos, err)
+	}
+}
+
+// txPoolBackend is a backend submitting transactions into a live transaction
+// pool backed by the test chain.
+type txPoolBackend struct {
+	*testBackend
+	txPool *txpool.TxPool
+}
+
+func newTxPoolBackend(t *testing.T, backend *testBackend) *txPoolBackend {
+	config := legacypool.DefaultConfig
+	config.Journal = ""
+
+	pool, err := txpool.New(new(big.Int).SetUint64(config.PriceLimit), backend.chain, []txpool.SubPool{legacypool.New(config, backend.chain)})
+	if err != nil {
+		t.Fatalf("failed to create tx pool: %v", err)
+	}
+	return &txPoolBackend{testBackend: backend, txPool: pool}
+}
+
+func (b *txPoolBackend) SendTx(ctx context.Context, signedTx *types.Transaction) error {
+	return b.txPool.Add([]*types.Transaction{signedTx}, true, true)[0]
+}
+
+func (b *txPoolBackend) GetPoolTransaction(txHash common.Hash) *types.Transaction {
+	return b.txPool.Get(txHash)
+}
+
+func TestRPCTransactionFirstSeen(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _  = pqcrypto.HexToDilithium("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		genesis = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  core.GenesisAlloc{key.GetAddress(): {Balance: big.NewInt(params.Ether)}},
+		}
+		signer  = types.LatestSigner(genesis.Config)
+		backend = newTxPoolBackend(t, newTestBackend(t, 0, genesis, beacon.NewFaker(), nil))
+		api     = NewTxPoolAPI(backend)
+		txAPI   = NewTransactionAPI(backend, nil)
+	)
+	defer backend.txPool.Close()
+
+	var txs []*types.Transaction
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		tx, err := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+			Nonce:     nonce,
+			To:        &common.Address{0x01},
+			Gas:       params.TxGas,
+			GasTipCap: big.NewInt(params.GWei),
+			GasFeeCap: big.NewInt(2 * params.GWei),
+		})
+		if err != nil {
+			t.Fatalf("failed to sign tx: %v", err)
+		}
+		txs = append(txs, tx)
+	}
+	// Unknown transactions have no first-seen time
+	if seen := api.TransactionFirstSeen(txs[0].Hash()); seen != nil {
+		t.Fatalf("unknown tx: have %v, want nil", seen)
+	}
+	// Submit the transactions one by one, the pool must report their arrival
+	var prev time.Time
+	for i, tx := range txs {
+		enc, err := tx.MarshalBinary()
+		if err != nil {
+			t.Fatalf("tx %d: failed to encode: %v", i, err)
+		}
+		before := time.Now()
+		if _, err := txAPI.SendRawTransaction(context.Background(), enc); err != nil {
+			t.Fatalf("tx %d: failed to submit: %v", i, err)
+		}
+		after := time.Now()
+
+		seen := api.TransactionFirstSeen(tx.Hash())
+		if seen == nil {
+			t.Fatalf("tx %d: missing first-seen time", i)
+		}
+		if seen.Before(before) || seen.After(after) {
+			t.Fatalf("tx %d: first-seen time %v outside of submission window [%v, %v]", i, seen, before, after)
+		}
+		if seen.Before(prev) {
+			t.Fatalf("tx %d: first-seen time %v before previous %v", i, seen, prev)
+		}
+		// Repeated queries must report the same time
+		if again := api.TransactionFirstSeen(tx.Hash()); !again.Equal(*seen) {
+			t.Fatalf("tx %d: first-seen time changed: have %v, want %v", i, again, seen)
+		}
+		prev = *seen
+	}
+}
+
+func TestRPCPendingForAccount(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _  = pqcrypto.HexToDilithium("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		genesis = &core.Genesis{Config: params.TestChainConfig}
+		signer  = types.LatestSigner(genesis.Config)
+		backend = newTestBackend(t, 0, genesis, beacon.NewFaker(), nil)
+		api     = NewTxPoolAPI(backend)
+	)
+	var txs []*types.Transaction
+	for nonce := uint64(0); nonce < 3; nonce++ {
+		tx, err := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+			Nonce:     nonce,
+			To:        &common.Address{0x01},
+			Gas:       params.TxGas,
+			GasFeeCap: big.NewInt(params.GWei),
+		})
+		if err != nil {
+			t.Fatalf("failed to sign tx: %v", err)
+		}
+		txs = append(txs, tx)
+	}
+	backend.pool = map[common.Address][]*types.Transaction{key.GetAddress(): txs}
+
+	pending := api.PendingForAccount(key.GetAddress())
+	if len(pending) != len(txs) {
+		t.Fatalf("pending transaction count mismatch: have %d, want %d", len(pending), len(txs))
+	}
+	for i, tx := range pending {
+		if tx.Hash != txs[i].Hash() || uint64(tx.Nonce) != uint64(i) {
+			t.Errorf("tx %d: mismatch: have %x (nonce %d), want %x (nonce %d)", i, tx.Hash, tx.Nonce, txs[i].Hash(), i)
+		}
+	}
+	if pending := api.PendingForAccount(common.Address{0x02}); len(pending) != 0 {
+		t.Fatalf("unknown account: have %d transactions, want 0", len(pending))
+	}
+}
+
+func TestRPCGetBloomRange(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _  = pqcrypto.HexToDilithium("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+		addr    = key.GetAddress()
+		logger  = common.Address{0x10, 0x99}
+		genesis = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: core.GenesisAlloc{
+				addr: {Balance: big.NewInt(params.Ether)},
+				// Emits a single empty log
+				logger: {Balance: new(big.Int), Code: []byte{byte(vm.PUSH1), 0x0, byte(vm.PUSH1), 0x0, byte(vm.LOG0)}},
+			},
+		}
+		signer = types.LatestSigner(params.TestChainConfig)
+	)
+	backend := newTestBackend(t, 4, genesis, beacon.NewFaker(), func(i int, b *core.BlockGen) {
+		// Only log in every other block
+		if i%2 == 1 {
+			return
+		}
+		tx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+			Nonce:     b.TxNonce(addr),
+			To:        &logger,
+			Gas:       100000,
+			GasFeeCap: b.BaseFee(),
+		}), signer, key)
+		if err != nil {
+			t.Fatalf("failed to sign tx: %v", err)
+		}
+		b.AddTx(tx)
+	})
+	api := NewBlockChainAPI(backend)
+
+	// The range is truncated at the head
+	blooms, err := api.GetBloomRange(context.Background(), 1, 10)
+	if err != nil {
+		t.Fatalf("failed to retrieve bloom range: %v", err)
+	}
+	if len(blooms) != 4 {
+		t.Fatalf("bloom count mismatch: have %d, want 4", len(blooms))
+	}
+	for i, bloom := range blooms {
+		header := backend.chain.GetHeaderByNumber(uint64(i + 1))
+		if bloom != header.Bloom {
+			t.Errorf("block %d: bloom mismatch: have %x, want %x", i+1, bloom, header.Bloom)
+		}
+	}
+	if blooms[0] == (types.Bloom{}) {
+		t.Error("expected non-empty bloom for block with logs")
+	}
+	if _, err := api.GetBloomRange(context.Background(), 0, maxBloomRange+1); err == nil {
+		t.Error("expected error for count above the limit")
+	}
+	if _, err := api.GetBloomRange(context.Background(), 0, 0); err == nil {
+		t.Error("expected error for zero count")
+	}
+}
+
+func TestRPCEarliestBlockWithoutFreezer(t *testing.T) {
+	t.Parallel()
+
+	// The test backend runs on a memory database without a freezer, whose
+	// Tail reports rawdb.ErrNotSupported.
+	genesis := &core.Genesis{Config: params.TestChainConfig}
+	backend := newTestBackend(t, 4, genesis, beacon.NewFaker(), func(i int, b *core.BlockGen) {})
+	if _, err := backend.ChainDb().Tail(); !errors.Is(err, rawdb.ErrNotSupported) {
+		t.Fatalf("unexpected tail error: have %v, want %v", err, rawdb.ErrNotSupported)
+	}
+	earliest, err := NewBlockChainAPI(backend).EarliestBlock()
+	if err != nil {
+		t.Fatalf("failed to retrieve earliest block: %v", err)
+	}
+	if earliest != 0 {
+		t.Fatalf("earliest block mismatch: have %d, want 0", earliest)
+	}
+}
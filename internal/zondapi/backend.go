@@ -29,6 +29,7 @@ import (
 	"github.com/theQRL/go-zond/core"
 	"github.com/theQRL/go-zond/core/bloombits"
 	"github.com/theQRL/go-zond/core/state"
+	"github.com/theQRL/go-zond/core/txpool"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/core/vm"
 	"github.com/theQRL/go-zond/event"
@@ -44,6 +45,7 @@ type Backend interface {
 	SyncProgress() zond.SyncProgress
 
 	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	SuggestGasTipCapAtPercentile(ctx context.Context, percentile int) (*big.Int, error)
 	FeeHistory(ctx context.Context, blockCount uint64, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*big.Int, [][]*big.Int, []*big.Int, []float64, error)
 	ChainDb() zonddb.Database
 	AccountManager() *accounts.Manager
@@ -51,6 +53,8 @@ type Backend interface {
 	RPCGasCap() uint64            // global gas cap for zond_call over rpc: DoS protection
 	RPCEVMTimeout() time.Duration // global timeout for zond_call over rpc: DoS protection
 	RPCTxFeeCap() float64         // global tx fee cap for all transaction related APIs
+	RPCMaxTxSize() uint64         // global size limit for raw transactions submitted over rpc
+	TxPoolMaxFutureNonce() uint64 // maximum nonce gap for raw transactions submitted over rpc
 
 	// Blockchain API
 	SetHead(number uint64)
@@ -73,6 +77,7 @@ type Backend interface {
 
 	// Transaction pool API
 	SendTx(ctx context.Context, signedTx *types.Transaction) error
+	ValidateTx(ctx context.Context, signedTx *types.Transaction) error
 	GetTransaction(ctx context.Context, txHash common.Hash) (*types.Transaction, common.Hash, uint64, uint64, error)
 	GetPoolTransactions() (types.Transactions, error)
 	GetPoolTransaction(txHash common.Hash) *types.Transaction
@@ -80,6 +85,10 @@ type Backend interface {
 	Stats() (pending int, queued int)
 	TxPoolContent() (map[common.Address][]*types.Transaction, map[common.Address][]*types.Transaction)
 	TxPoolContentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction)
+	TxPoolPending(enforceTips bool) map[common.Address][]*txpool.LazyTransaction
+	TxPoolLocals() []common.Address
+	TxPoolRecentlyDropped(limit int) []*txpool.DroppedTx
+	TxPoolReplacementHistory(addr common.Address, nonce uint64) []*txpool.ReplacedTx
 	SubscribeNewTxsEvent(chan<- core.NewTxsEvent) event.Subscription
 
 	ChainConfig() *params.ChainConfig
@@ -32,6 +32,7 @@ import (
 	"github.com/theQRL/go-zond/core"
 	"github.com/theQRL/go-zond/core/bloombits"
 	"github.com/theQRL/go-zond/core/state"
+	"github.com/theQRL/go-zond/core/txpool"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/core/vm"
 	"github.com/theQRL/go-zond/event"
@@ -239,6 +240,9 @@ func (b *backendMock) deactivateLondon() {
 func (b *backendMock) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
 	return big.NewInt(42), nil
 }
+func (b *backendMock) SuggestGasTipCapAtPercentile(ctx context.Context, percentile int) (*big.Int, error) {
+	return big.NewInt(42), nil
+}
 func (b *backendMock) CurrentHeader() *types.Header     { return b.current }
 func (b *backendMock) ChainConfig() *params.ChainConfig { return b.config }
 
@@ -253,6 +257,8 @@ func (b *backendMock) ExtRPCEnabled() bool               { return false }
 func (b *backendMock) RPCGasCap() uint64                 { return 0 }
 func (b *backendMock) RPCEVMTimeout() time.Duration      { return time.Second }
 func (b *backendMock) RPCTxFeeCap() float64              { return 0 }
+func (b *backendMock) RPCMaxTxSize() uint64              { return 0 }
+func (b *backendMock) TxPoolMaxFutureNonce() uint64      { return 0 }
 func (b *backendMock) SetHead(number uint64)             {}
 func (b *backendMock) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
 	return nil, nil
@@ -300,6 +306,9 @@ func (b *backendMock) SubscribeChainSideEvent(ch chan<- core.ChainSideEvent) eve
 	return nil
 }
 func (b *backendMock) SendTx(ctx context.Context, signedTx *types.Transaction) error { return nil }
+func (b *backendMock) ValidateTx(ctx context.Context, signedTx *types.Transaction) error {
+	return nil
+}
 func (b *backendMock) GetTransaction(ctx context.Context, txHash common.Hash) (*types.Transaction, common.Hash, uint64, uint64, error) {
 	return nil, [32]byte{}, 0, 0, nil
 }
@@ -312,6 +321,16 @@ func (b *backendMock) Stats() (pending int, queued int) { return 0, 0 }
 func (b *backendMock) TxPoolContent() (map[common.Address][]*types.Transaction, map[common.Address][]*types.Transaction) {
 	return nil, nil
 }
+func (b *backendMock) TxPoolPending(enforceTips bool) map[common.Address][]*txpool.LazyTransaction {
+	return nil
+}
+func (b *backendMock) TxPoolLocals() []common.Address { return nil }
+func (b *backendMock) TxPoolRecentlyDropped(limit int) []*txpool.DroppedTx {
+	return nil
+}
+func (b *backendMock) TxPoolReplacementHistory(addr common.Address, nonce uint64) []*txpool.ReplacedTx {
+	return nil
+}
 func (b *backendMock) TxPoolContentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction) {
 	return nil, nil
 }
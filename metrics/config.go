@@ -16,18 +16,21 @@
 
 package metrics
 
+import "time"
+
 // Config contains the configuration for the metric collection.
 type Config struct {
-	Enabled          bool   `toml:",omitempty"`
-	EnabledExpensive bool   `toml:",omitempty"`
-	HTTP             string `toml:",omitempty"`
-	Port             int    `toml:",omitempty"`
-	EnableInfluxDB   bool   `toml:",omitempty"`
-	InfluxDBEndpoint string `toml:",omitempty"`
-	InfluxDBDatabase string `toml:",omitempty"`
-	InfluxDBUsername string `toml:",omitempty"`
-	InfluxDBPassword string `toml:",omitempty"`
-	InfluxDBTags     string `toml:",omitempty"`
+	Enabled          bool          `toml:",omitempty"`
+	EnabledExpensive bool          `toml:",omitempty"`
+	HTTP             string        `toml:",omitempty"`
+	Port             int           `toml:",omitempty"`
+	Interval         time.Duration `toml:",omitempty"`
+	EnableInfluxDB   bool          `toml:",omitempty"`
+	InfluxDBEndpoint string        `toml:",omitempty"`
+	InfluxDBDatabase string        `toml:",omitempty"`
+	InfluxDBUsername string        `toml:",omitempty"`
+	InfluxDBPassword string        `toml:",omitempty"`
+	InfluxDBTags     string        `toml:",omitempty"`
 
 	EnableInfluxDBV2     bool   `toml:",omitempty"`
 	InfluxDBToken        string `toml:",omitempty"`
@@ -41,6 +44,7 @@ var DefaultConfig = Config{
 	EnabledExpensive: false,
 	HTTP:             "127.0.0.1",
 	Port:             6060,
+	Interval:         10 * time.Second,
 	EnableInfluxDB:   false,
 	InfluxDBEndpoint: "http://localhost:8086",
 	InfluxDBDatabase: "gzond",
@@ -2,10 +2,14 @@ package influxdb
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/theQRL/go-zond/metrics"
 )
 
+// newTicker creates the ticker driving the periodic reports, replaceable in tests.
+var newTicker = time.NewTicker
+
 func readMeter(namespace, name string, i interface{}) (string, map[string]interface{}) {
 	switch metric := i.(type) {
 	case metrics.Counter:
@@ -25,6 +25,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
 	"github.com/theQRL/go-zond/metrics"
@@ -100,6 +101,42 @@ func TestExampleV2(t *testing.T) {
 	}
 }
 
+// Tests that the reporter pushes the registry at the configured interval.
+func TestReportInterval(t *testing.T) {
+	var (
+		interval = make(chan time.Duration, 1)
+		tick     = make(chan time.Time)
+		written  = make(chan struct{}, 1)
+	)
+	newTicker = func(d time.Duration) *time.Ticker {
+		interval <- d
+		return &time.Ticker{C: tick}
+	}
+	defer func() { newTicker = time.NewTicker }()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/write") {
+			select {
+			case written <- struct{}{}:
+			default:
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	go InfluxDBWithTags(internal.ExampleMetrics(), 42*time.Second, ts.URL, "db", "user", "pass", "goth.", nil)
+	if have := <-interval; have != 42*time.Second {
+		t.Fatalf("report interval mismatch: have %v, want %v", have, 42*time.Second)
+	}
+	tick <- time.Now()
+	select {
+	case <-written:
+	case <-time.After(5 * time.Second):
+		t.Fatal("metrics not pushed on tick")
+	}
+}
+
 func findFirstDiffPos(a, b string) string {
 	yy := strings.Split(b, "\n")
 	for i, x := range strings.Split(a, "\n") {
@@ -98,7 +98,7 @@ func (r *reporter) makeClient() (err error) {
 }
 
 func (r *reporter) run() {
-	intervalTicker := time.NewTicker(r.interval)
+	intervalTicker := newTicker(r.interval)
 	pingTicker := time.NewTicker(time.Second * 5)
 
 	defer intervalTicker.Stop()
@@ -55,7 +55,7 @@ func InfluxDBV2WithTags(r metrics.Registry, d time.Duration, endpoint string, to
 }
 
 func (r *v2Reporter) run() {
-	intervalTicker := time.NewTicker(r.interval)
+	intervalTicker := newTicker(r.interval)
 	pingTicker := time.NewTicker(time.Second * 5)
 
 	defer intervalTicker.Stop()
@@ -36,6 +36,15 @@ import (
 	"github.com/theQRL/go-zond/zond/downloader"
 )
 
+// Status is a snapshot of the block building state of the miner.
+type Status struct {
+	Building     bool            `json:"building"`     // Whether the miner is running or a payload is being built
+	PendingBlock *hexutil.Uint64 `json:"pendingBlock"` // Number of the pending block, nil if not yet assembled
+	TxCount      hexutil.Uint64  `json:"txCount"`      // Number of transactions in the pending block
+	GasUsed      hexutil.Uint64  `json:"gasUsed"`      // Gas used by the pending block
+	FeeRecipient common.Address  `json:"feeRecipient"` // Fee recipient of the blocks being built
+}
+
 // Backend wraps all methods required for mining. Only full node is capable
 // to offer all the functions here.
 type Backend interface {
@@ -53,6 +62,9 @@ type Config struct {
 	Recommit  time.Duration  // The time interval for miner to re-create mining work.
 
 	NewPayloadTimeout time.Duration // The maximum time allowance for creating a new payload
+	PendingBlocks     int           // Number of recent pending blocks retained across head changes
+	MaxBuilds         int           // Maximum number of payloads built simultaneously, the oldest is evicted beyond it (0 = unlimited)
+	PendingRefresh    time.Duration // Interval to rebuild the pending block while not sealing (0 = disabled)
 }
 
 // DefaultConfig contains default settings for miner.
@@ -66,6 +78,8 @@ var DefaultConfig = Config{
 	// run 3 rounds.
 	Recommit:          2 * time.Second,
 	NewPayloadTimeout: 2 * time.Second,
+	PendingBlocks:     4,
+	MaxBuilds:         10,
 }
 
 // Miner creates blocks and searches for proof-of-work values.
@@ -182,6 +196,11 @@ func (miner *Miner) Mining() bool {
 	return miner.worker.isRunning()
 }
 
+// Status returns a snapshot of the block building state of the miner.
+func (miner *Miner) Status() *Status {
+	return miner.worker.status()
+}
+
 func (miner *Miner) SetExtra(extra []byte) error {
 	if uint64(len(extra)) > params.MaximumExtraDataSize {
 		return fmt.Errorf("extra exceeds max length. %d > %v", len(extra), params.MaximumExtraDataSize)
@@ -221,6 +240,11 @@ func (miner *Miner) SetEtherbase(addr common.Address) {
 	miner.worker.setEtherbase(addr)
 }
 
+// Etherbase returns the fee recipient currently used for building pending blocks.
+func (miner *Miner) Etherbase() common.Address {
+	return miner.worker.etherbase()
+}
+
 // SetGasCeil sets the gaslimit to strive for when mining blocks post 1559.
 // For pre-1559 blocks, it sets the ceiling.
 func (miner *Miner) SetGasCeil(ceil uint64) {
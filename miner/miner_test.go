@@ -237,6 +237,19 @@ func TestMinerSetEtherbase(t *testing.T) {
 	}
 }
 
+// TestMinerGetEtherbase checks that the configured etherbase can be read back
+// after being changed.
+func TestMinerGetEtherbase(t *testing.T) {
+	miner, _, cleanup := createMiner(t)
+	defer cleanup(false)
+
+	coinbase := common.HexToAddress("0xdeedbeef")
+	miner.SetEtherbase(coinbase)
+	if addr := miner.Etherbase(); addr != coinbase {
+		t.Fatalf("Unexpected etherbase want %x got %x", coinbase, addr)
+	}
+}
+
 // waitForMiningState waits until either
 // * the desired mining state was reached
 // * a timeout was reached which fails the test
@@ -169,6 +169,49 @@ func (payload *Payload) ResolveFull() *engine.ExecutionPayloadEnvelope {
 	return engine.BlockToExecutableData(payload.full, payload.fullFees)
 }
 
+// terminate stops the background payload construction without delivering it.
+// It's safe to be called multiple times.
+func (payload *Payload) terminate() {
+	payload.lock.Lock()
+	defer payload.lock.Unlock()
+
+	select {
+	case <-payload.stop:
+	default:
+		close(payload.stop)
+	}
+}
+
+// trackBuild registers a payload being built in the background, terminating the
+// oldest builds if the configured limit of simultaneous builds is exceeded.
+func (w *worker) trackBuild(payload *Payload) {
+	w.buildsMu.Lock()
+	defer w.buildsMu.Unlock()
+
+	w.builds = append(w.builds, payload)
+	if limit := w.config.MaxBuilds; limit > 0 {
+		for len(w.builds) > limit {
+			evicted := w.builds[0]
+			w.builds = w.builds[1:]
+			evicted.terminate()
+			log.Info("Evicted payload build", "id", evicted.id, "limit", limit)
+		}
+	}
+}
+
+// untrackBuild removes a payload from the set being built in the background.
+func (w *worker) untrackBuild(payload *Payload) {
+	w.buildsMu.Lock()
+	defer w.buildsMu.Unlock()
+
+	for i, p := range w.builds {
+		if p == payload {
+			w.builds = append(w.builds[:i], w.builds[i+1:]...)
+			return
+		}
+	}
+}
+
 // buildPayload builds the payload according to the provided parameters.
 func (w *worker) buildPayload(args *BuildPayloadArgs) (*Payload, error) {
 	// Build the initial version with no transaction included. It should be fast
@@ -193,7 +236,12 @@ func (w *worker) buildPayload(args *BuildPayloadArgs) (*Payload, error) {
 
 	// Spin up a routine for updating the payload in background. This strategy
 	// can maximum the revenue for including transactions with highest fee.
+	w.trackBuild(payload)
+	w.building.Add(1)
 	go func() {
+		defer w.building.Add(-1)
+		defer w.untrackBuild(payload)
+
 		// Setup the timer for re-building the payload. The initial clock is kept
 		// for triggering process immediately.
 		timer := time.NewTimer(0)
@@ -26,6 +26,7 @@ import (
 	"github.com/theQRL/go-zond/consensus/beacon"
 	"github.com/theQRL/go-zond/core/rawdb"
 	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/event"
 	"github.com/theQRL/go-zond/params"
 )
 
@@ -81,6 +82,63 @@ func TestBuildPayload(t *testing.T) {
 	}
 }
 
+func TestBuildPayloadStatus(t *testing.T) {
+	var (
+		db     = rawdb.NewMemoryDatabase()
+		config = params.TestChainConfig
+		engine = beacon.NewFaker()
+	)
+	backend := newTestWorkerBackend(t, config, engine, db, 0)
+	defer backend.chain.Stop()
+	backend.txPool.Add(pendingTxs, true, false)
+
+	w := newWorker(testConfig, config, engine, backend, new(event.TypeMux), nil, true)
+	defer w.close()
+	w.setEtherbase(testBankAddress)
+
+	if status := w.status(); status.Building {
+		t.Fatal("worker reported building without a payload")
+	}
+	payload, err := w.buildPayload(&BuildPayloadArgs{
+		Parent:       backend.chain.CurrentBlock().Hash(),
+		Timestamp:    uint64(time.Now().Unix()),
+		FeeRecipient: common.HexToAddress("0xdeadbeef"),
+	})
+	if err != nil {
+		t.Fatalf("Failed to build payload %v", err)
+	}
+	// Wait for the pending block to include the pool transactions
+	var status *Status
+	for deadline := time.Now().Add(3 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		status = w.status()
+		if status.PendingBlock != nil && int(status.TxCount) == len(pendingTxs) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("pending block not assembled: %+v", status)
+		}
+	}
+	if !status.Building {
+		t.Error("worker not reported building while a payload is in progress")
+	}
+	if want := backend.chain.CurrentBlock().Number.Uint64() + 1; uint64(*status.PendingBlock) != want {
+		t.Errorf("pending block number mismatch: have %d, want %d", *status.PendingBlock, want)
+	}
+	if want := w.pendingBlock().GasUsed(); uint64(status.GasUsed) != want || want == 0 {
+		t.Errorf("pending gas used mismatch: have %d, want %d", status.GasUsed, want)
+	}
+	if status.FeeRecipient != testBankAddress {
+		t.Errorf("fee recipient mismatch: have %x, want %x", status.FeeRecipient, testBankAddress)
+	}
+	// Delivering the payload stops the background building
+	payload.Resolve()
+	for deadline := time.Now().Add(3 * time.Second); w.status().Building; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("worker still reported building after payload delivery")
+		}
+	}
+}
+
 func TestPayloadId(t *testing.T) {
 	ids := make(map[string]int)
 	for i, tt := range []*BuildPayloadArgs{
@@ -156,3 +214,59 @@ func TestPayloadId(t *testing.T) {
 		ids[id] = i
 	}
 }
+
+func TestBuildPayloadEviction(t *testing.T) {
+	var (
+		db     = rawdb.NewMemoryDatabase()
+		config = params.TestChainConfig
+		engine = beacon.NewFaker()
+	)
+	backend := newTestWorkerBackend(t, config, engine, db, 0)
+	defer backend.chain.Stop()
+
+	minerConfig := *testConfig
+	minerConfig.MaxBuilds = 2
+	w := newWorker(&minerConfig, config, engine, backend, new(event.TypeMux), nil, false)
+	defer w.close()
+
+	var payloads []*Payload
+	for i := 0; i < 3; i++ {
+		payload, err := w.buildPayload(&BuildPayloadArgs{
+			Parent:       backend.chain.CurrentBlock().Hash(),
+			Timestamp:    uint64(time.Now().Unix()) + uint64(i),
+			FeeRecipient: common.HexToAddress("0xdeadbeef"),
+		})
+		if err != nil {
+			t.Fatalf("Failed to build payload %v", err)
+		}
+		payloads = append(payloads, payload)
+	}
+	stopped := func(payload *Payload) bool {
+		select {
+		case <-payload.stop:
+			return true
+		default:
+			return false
+		}
+	}
+	if !stopped(payloads[0]) {
+		t.Error("oldest payload build not evicted")
+	}
+	for i, payload := range payloads[1:] {
+		if stopped(payload) {
+			t.Errorf("payload %d build evicted", i+1)
+		}
+	}
+	for deadline := time.Now().Add(3 * time.Second); w.building.Load() != 2; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("building payload count mismatch: have %d, want 2", w.building.Load())
+		}
+	}
+	// The evicted payload still delivers the best block built so far
+	if env := payloads[0].Resolve(); env == nil || env.ExecutionPayload == nil {
+		t.Fatal("evicted payload not resolvable")
+	}
+	for _, payload := range payloads[1:] {
+		payload.Resolve()
+	}
+}
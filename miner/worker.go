@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/common/hexutil"
 	"github.com/theQRL/go-zond/consensus"
 	"github.com/theQRL/go-zond/consensus/misc/eip1559"
 	"github.com/theQRL/go-zond/core"
@@ -59,6 +60,10 @@ const (
 	// any newly arrived transactions.
 	maxRecommitInterval = 15 * time.Second
 
+	// minPendingRefreshInterval is the minimal time interval to rebuild the
+	// pending block while not sealing.
+	minPendingRefreshInterval = 100 * time.Millisecond
+
 	// intervalAdjustRatio is the impact a single interval adjustment has on sealing work
 	// resubmitting interval.
 	intervalAdjustRatio = 0.1
@@ -201,15 +206,17 @@ type worker struct {
 	pendingMu    sync.RWMutex
 	pendingTasks map[common.Hash]*task
 
-	snapshotMu       sync.RWMutex // The lock used to protect the snapshots below
-	snapshotBlock    *types.Block
-	snapshotReceipts types.Receipts
-	snapshotState    *state.StateDB
+	snapshotMu sync.RWMutex       // The lock used to protect the snapshots below
+	snapshots  []*pendingSnapshot // Recent pending snapshots, oldest first, one per parent
+
+	buildsMu sync.Mutex // The lock used to protect the payload builds below
+	builds   []*Payload // Payloads being built in the background, oldest first
 
 	// atomic status counters
-	running atomic.Bool  // The indicator whether the consensus engine is running or not.
-	newTxs  atomic.Int32 // New arrival transaction count since last sealing work submitting.
-	syncing atomic.Bool  // The indicator whether the node is still syncing.
+	running  atomic.Bool  // The indicator whether the consensus engine is running or not.
+	newTxs   atomic.Int32 // New arrival transaction count since last sealing work submitting.
+	syncing  atomic.Bool  // The indicator whether the node is still syncing.
+	building atomic.Int32 // Number of payloads currently being built in the background.
 
 	// newpayloadTimeout is the maximum timeout allowance for creating payload.
 	// The default value is 2 seconds but node operator can set it to arbitrary
@@ -279,9 +286,16 @@ func newWorker(config *Config, chainConfig *params.ChainConfig, engine consensus
 	}
 	worker.newpayloadTimeout = newpayloadTimeout
 
+	// Sanitize the pending block refresh interval if the user-specified one is
+	// too short, zero disables periodic refreshing altogether.
+	refresh := worker.config.PendingRefresh
+	if refresh > 0 && refresh < minPendingRefreshInterval {
+		log.Warn("Sanitizing pending block refresh interval", "provided", refresh, "updated", minPendingRefreshInterval)
+		refresh = minPendingRefreshInterval
+	}
 	worker.wg.Add(4)
 	go worker.mainLoop()
-	go worker.newWorkLoop(recommit)
+	go worker.newWorkLoop(recommit, refresh)
 	go worker.resultLoop()
 	go worker.taskLoop()
 
@@ -306,6 +320,21 @@ func (w *worker) etherbase() common.Address {
 	return w.coinbase
 }
 
+// status returns a snapshot of the block building state of the worker.
+func (w *worker) status() *Status {
+	status := &Status{
+		Building:     w.isRunning() || w.building.Load() > 0,
+		FeeRecipient: w.etherbase(),
+	}
+	if block := w.pendingBlock(); block != nil {
+		number := hexutil.Uint64(block.NumberU64())
+		status.PendingBlock = &number
+		status.TxCount = hexutil.Uint64(len(block.Transactions()))
+		status.GasUsed = hexutil.Uint64(block.GasUsed())
+	}
+	return status
+}
+
 func (w *worker) setGasCeil(ceil uint64) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
@@ -327,15 +356,42 @@ func (w *worker) setRecommitInterval(interval time.Duration) {
 	}
 }
 
+// pendingSnapshot is a sealing-ready copy of a pending block built on top of a
+// particular parent, together with its receipts and post state.
+type pendingSnapshot struct {
+	block    *types.Block
+	receipts types.Receipts
+	state    *state.StateDB
+}
+
+// snapshot returns the pending snapshot built on top of the current chain head
+// if one is retained, or the most recently built one otherwise. The returned
+// value is nil in case the pending block is not initialized.
+//
+// The caller must hold snapshotMu.
+func (w *worker) snapshot() *pendingSnapshot {
+	if len(w.snapshots) == 0 {
+		return nil
+	}
+	head := w.chain.CurrentBlock().Hash()
+	for i := len(w.snapshots) - 1; i >= 0; i-- {
+		if w.snapshots[i].block.ParentHash() == head {
+			return w.snapshots[i]
+		}
+	}
+	return w.snapshots[len(w.snapshots)-1]
+}
+
 // pending returns the pending state and corresponding block. The returned
 // values can be nil in case the pending block is not initialized.
 func (w *worker) pending() (*types.Block, *state.StateDB) {
 	w.snapshotMu.RLock()
 	defer w.snapshotMu.RUnlock()
-	if w.snapshotState == nil {
+	snap := w.snapshot()
+	if snap == nil {
 		return nil, nil
 	}
-	return w.snapshotBlock, w.snapshotState.Copy()
+	return snap.block, snap.state.Copy()
 }
 
 // pendingBlock returns pending block. The returned block can be nil in case the
@@ -343,7 +399,11 @@ func (w *worker) pending() (*types.Block, *state.StateDB) {
 func (w *worker) pendingBlock() *types.Block {
 	w.snapshotMu.RLock()
 	defer w.snapshotMu.RUnlock()
-	return w.snapshotBlock
+	snap := w.snapshot()
+	if snap == nil {
+		return nil
+	}
+	return snap.block
 }
 
 // pendingBlockAndReceipts returns pending block and corresponding receipts.
@@ -351,7 +411,11 @@ func (w *worker) pendingBlock() *types.Block {
 func (w *worker) pendingBlockAndReceipts() (*types.Block, types.Receipts) {
 	w.snapshotMu.RLock()
 	defer w.snapshotMu.RUnlock()
-	return w.snapshotBlock, w.snapshotReceipts
+	snap := w.snapshot()
+	if snap == nil {
+		return nil, nil
+	}
+	return snap.block, snap.receipts
 }
 
 // start sets the running status as 1 and triggers new work submitting.
@@ -401,7 +465,9 @@ func recalcRecommit(minRecommit, prev time.Duration, target float64, inc bool) t
 }
 
 // newWorkLoop is a standalone goroutine to submit new sealing work upon received events.
-func (w *worker) newWorkLoop(recommit time.Duration) {
+// While not sealing, the pending block is additionally rebuilt every refresh
+// interval, independent of the recommit interval.
+func (w *worker) newWorkLoop(recommit time.Duration, refresh time.Duration) {
 	defer w.wg.Done()
 	var (
 		interrupt   *atomic.Int32
@@ -413,6 +479,13 @@ func (w *worker) newWorkLoop(recommit time.Duration) {
 	defer timer.Stop()
 	<-timer.C // discard the initial tick
 
+	var refreshCh <-chan time.Time
+	if refresh > 0 {
+		ticker := time.NewTicker(refresh)
+		defer ticker.Stop()
+		refreshCh = ticker.C
+	}
+
 	// commit aborts in-flight transaction execution with given signal and resubmits a new one.
 	commit := func(s int32) {
 		if interrupt != nil {
@@ -462,6 +535,13 @@ func (w *worker) newWorkLoop(recommit time.Duration) {
 				commit(commitInterruptResubmit)
 			}
 
+		case <-refreshCh:
+			// Rebuild the pending block from the current pool content, sealing
+			// work is kept up to date by the recommit timer instead.
+			if !w.isRunning() {
+				commit(commitInterruptResubmit)
+			}
+
 		case interval := <-w.resubmitIntervalCh:
 			// Adjust resubmit interval explicitly by user.
 			if interval < minRecommitInterval {
@@ -707,19 +787,41 @@ func (w *worker) makeEnv(parent *types.Header, header *types.Header, coinbase co
 	return env, nil
 }
 
-// updateSnapshot updates pending snapshot block, receipts and state.
+// updateSnapshot updates pending snapshot block, receipts and state. Snapshots
+// built on other parents are retained up to the configured limit, so pending
+// queries stay answerable while the head flips between recent blocks.
 func (w *worker) updateSnapshot(env *environment) {
 	w.snapshotMu.Lock()
 	defer w.snapshotMu.Unlock()
 
-	w.snapshotBlock = types.NewBlock(
-		env.header,
-		env.txs,
-		env.receipts,
-		trie.NewStackTrie(nil),
-	)
-	w.snapshotReceipts = copyReceipts(env.receipts)
-	w.snapshotState = env.state.Copy()
+	snap := &pendingSnapshot{
+		block: types.NewBlock(
+			env.header,
+			env.txs,
+			env.receipts,
+			trie.NewStackTrie(nil),
+		),
+		receipts: copyReceipts(env.receipts),
+		state:    env.state.Copy(),
+	}
+	// Drop the previous snapshot of the same parent and evict the oldest ones
+	// above the limit, the latest snapshot is always retained.
+	snapshots := w.snapshots[:0]
+	for _, s := range w.snapshots {
+		if s.block.ParentHash() != env.header.ParentHash {
+			snapshots = append(snapshots, s)
+		}
+	}
+	snapshots = append(snapshots, snap)
+
+	limit := w.config.PendingBlocks
+	if limit < 1 {
+		limit = 1
+	}
+	if len(snapshots) > limit {
+		snapshots = snapshots[len(snapshots)-limit:]
+	}
+	w.snapshots = snapshots
 }
 
 func (w *worker) commitTransaction(env *environment, tx *types.Transaction) ([]*types.Log, error) {
@@ -862,7 +964,7 @@ func (w *worker) prepareWork(genParams *generateParams) (*environment, error) {
 	header := &types.Header{
 		ParentHash: parent.Hash(),
 		Number:     new(big.Int).Add(parent.Number, common.Big1),
-		GasLimit:   core.CalcGasLimit(parent.GasLimit, w.config.GasCeil),
+		GasLimit:   core.CalcGasLimit(w.chainConfig, parent.GasLimit, w.config.GasCeil),
 		Time:       timestamp,
 		Coinbase:   genParams.coinbase,
 	}
@@ -17,6 +17,8 @@
 package miner
 
 import (
+	"errors"
+	"fmt"
 	"math/big"
 	"sync/atomic"
 	"testing"
@@ -188,6 +190,123 @@ func TestGenerateAndImportBlock(t *testing.T) {
 	}
 }
 
+// Tests that the worker retains recent pending blocks, so that pending queries
+// remain consistent with the chain head while it quickly flips between blocks.
+func TestPendingBlockCache(t *testing.T) {
+	var (
+		db     = rawdb.NewMemoryDatabase()
+		config = *params.AllBeaconProtocolChanges
+		engine = beacon.NewFaker()
+	)
+	backend := newTestWorkerBackend(t, &config, engine, db, 0)
+	defer backend.chain.Stop()
+
+	minerConfig := *testConfig
+	minerConfig.PendingBlocks = 4
+	w := newWorker(&minerConfig, &config, engine, backend, new(event.TypeMux), nil, true)
+	defer w.close()
+
+	// checkPending verifies that the pending block is built on the current head.
+	checkPending := func() error {
+		head := backend.chain.CurrentBlock()
+		block := w.pendingBlock()
+		if block == nil {
+			return errors.New("pending block not available")
+		}
+		if block.ParentHash() != head.Hash() {
+			return fmt.Errorf("pending block parent mismatch: have %x, want %x", block.ParentHash(), head.Hash())
+		}
+		if block.NumberU64() != head.Number.Uint64()+1 {
+			return fmt.Errorf("pending block number mismatch: have %d, want %d", block.NumberU64(), head.Number.Uint64()+1)
+		}
+		return nil
+	}
+	waitPending := func() {
+		var err error
+		for deadline := time.Now().Add(3 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+			if err = checkPending(); err == nil {
+				return
+			}
+		}
+		t.Fatalf("pending block not rebuilt on head %d: %v", backend.chain.CurrentBlock().Number, err)
+	}
+	// Advance the head one block at a time, letting the worker build a pending
+	// block on top of each of them.
+	waitPending()
+	_, blocks, _ := core.GenerateChainWithGenesis(backend.genesis, engine, 3, nil)
+	for _, block := range blocks {
+		if _, err := backend.chain.InsertChain([]*types.Block{block}); err != nil {
+			t.Fatalf("failed to insert block %d: %v", block.NumberU64(), err)
+		}
+		waitPending()
+	}
+	// Rewind the head rapidly, the pending block must follow without waiting
+	// for the worker to rebuild it.
+	for number := uint64(2); ; number-- {
+		if err := backend.chain.SetHead(number); err != nil {
+			t.Fatalf("failed to rewind to block %d: %v", number, err)
+		}
+		if err := checkPending(); err != nil {
+			t.Fatalf("head %d: %v", number, err)
+		}
+		if number == 0 {
+			break
+		}
+	}
+}
+
+func TestPendingRefresh(t *testing.T) {
+	var (
+		db     = rawdb.NewMemoryDatabase()
+		config = *params.AllBeaconProtocolChanges
+		engine = beacon.NewFaker()
+	)
+	backend := newTestWorkerBackend(t, &config, engine, db, 0)
+	defer backend.chain.Stop()
+
+	// waitPending waits for the initial pending block to be built.
+	waitPending := func(w *worker) *types.Block {
+		for deadline := time.Now().Add(3 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+			if block := w.pendingBlock(); block != nil {
+				return block
+			}
+		}
+		t.Fatal("pending block not built")
+		return nil
+	}
+	// Use a recommit interval far longer than the test, so that any rebuild of
+	// the pending block is caused by the refresh.
+	minerConfig := *testConfig
+	minerConfig.Recommit = time.Hour
+	minerConfig.PendingRefresh = 100 * time.Millisecond
+
+	w := newWorker(&minerConfig, &config, engine, backend, new(event.TypeMux), nil, true)
+	defer w.close()
+
+	first := waitPending(w)
+	refreshed := false
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if w.pendingBlock() != first {
+			refreshed = true
+			break
+		}
+	}
+	if !refreshed {
+		t.Fatal("pending block not refreshed")
+	}
+	// Without a refresh interval, the pending block is left alone.
+	minerConfig.PendingRefresh = 0
+
+	w = newWorker(&minerConfig, &config, engine, backend, new(event.TypeMux), nil, true)
+	defer w.close()
+
+	first = waitPending(w)
+	time.Sleep(300 * time.Millisecond)
+	if w.pendingBlock() != first {
+		t.Fatal("pending block refreshed without a refresh interval")
+	}
+}
+
 // TODO(rgeraldes24)
 /*
 func TestEmptyWorkEthash(t *testing.T) {
@@ -178,6 +178,7 @@ func (api *adminAPI) StartHTTP(host *string, port *int, cors *string, apis *stri
 		rpcEndpointConfig: rpcEndpointConfig{
 			batchItemLimit:         api.node.config.BatchRequestLimit,
 			batchResponseSizeLimit: api.node.config.BatchResponseMaxSize,
+			methodTimeouts:         api.node.config.RPCMethodTimeouts,
 		},
 	}
 	if cors != nil {
@@ -242,6 +243,7 @@ func (api *adminAPI) StartWS(host *string, port *int, allowedOrigins *string, ap
 		rpcEndpointConfig: rpcEndpointConfig{
 			batchItemLimit:         api.node.config.BatchRequestLimit,
 			batchResponseSizeLimit: api.node.config.BatchResponseMaxSize,
+			methodTimeouts:         api.node.config.RPCMethodTimeouts,
 		},
 	}
 	if apis != nil {
@@ -290,6 +292,16 @@ func (api *adminAPI) Peers() ([]*p2p.PeerInfo, error) {
 	return server.PeersInfo(), nil
 }
 
+// PeerBandwidth retrieves the number of subprotocol payload bytes sent to and
+// received from each connected peer.
+func (api *adminAPI) PeerBandwidth() (map[string]p2p.PeerBandwidth, error) {
+	server := api.node.Server()
+	if server == nil {
+		return nil, ErrNodeStopped
+	}
+	return server.PeersBandwidth(), nil
+}
+
 // NodeInfo retrieves all the information we know about the host node at the
 // protocol granularity.
 func (api *adminAPI) NodeInfo() (*p2p.NodeInfo, error) {
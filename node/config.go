@@ -24,6 +24,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/theQRL/go-zond/crypto"
 	"github.com/theQRL/go-zond/log"
@@ -132,6 +133,10 @@ type Config struct {
 	// HTTPPathPrefix specifies a path prefix on which http-rpc is to be served.
 	HTTPPathPrefix string `toml:",omitempty"`
 
+	// HTTPNoCompression disables the gzip/deflate compression of http-rpc
+	// responses, which is otherwise negotiated via the Accept-Encoding header.
+	HTTPNoCompression bool `toml:",omitempty"`
+
 	// AuthAddr is the listening address on which authenticated APIs are provided.
 	AuthAddr string `toml:",omitempty"`
 
@@ -185,6 +190,10 @@ type Config struct {
 	// Requests using ip address directly are not affected
 	GraphQLVirtualHosts []string `toml:",omitempty"`
 
+	// GraphQLNoIntrospection disables answering schema introspection queries
+	// on the GraphQL endpoint.
+	GraphQLNoIntrospection bool `toml:",omitempty"`
+
 	// Logger is a custom logger to use with the p2p.Server.
 	Logger log.Logger `toml:",omitempty"`
 
@@ -194,6 +203,15 @@ type Config struct {
 	// BatchResponseMaxSize is the maximum number of bytes returned from a batched rpc call.
 	BatchResponseMaxSize int `toml:",omitempty"`
 
+	// RPCMethodTimeouts overrides the request timeout of individual RPC methods,
+	// keyed by the full method name. Over HTTP, the timeouts are capped by the
+	// write timeout of HTTPTimeouts.
+	RPCMethodTimeouts map[string]time.Duration `toml:",omitempty"`
+
+	// RPCMinPeers is the number of peers the node needs to be connected to before
+	// the unauthenticated HTTP and WebSocket endpoints are enabled.
+	RPCMinPeers int `toml:",omitempty"`
+
 	// JWTSecret is the path to the hex-encoded jwt secret.
 	JWTSecret string `toml:",omitempty"`
 
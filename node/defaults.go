@@ -21,6 +21,7 @@ import (
 	"os/user"
 	"path/filepath"
 	"runtime"
+	"time"
 
 	"github.com/theQRL/go-zond/p2p"
 	"github.com/theQRL/go-zond/p2p/nat"
@@ -67,9 +68,10 @@ var DefaultConfig = Config{
 	BatchResponseMaxSize: 25 * 1000 * 1000,
 	GraphQLVirtualHosts:  []string{"localhost"},
 	P2P: p2p.Config{
-		ListenAddr: ":30303",
-		MaxPeers:   50,
-		NAT:        nat.Any(),
+		ListenAddr:       ":30303",
+		MaxPeers:         50,
+		MaxStaticBackoff: 10 * time.Minute,
+		NAT:              nat.Any(),
 	},
 	DBEngine: "", // Use whatever exists, will default to Pebble if non-existent and supported
 }
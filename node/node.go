@@ -27,6 +27,7 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/gofrs/flock"
 	"github.com/theQRL/go-zond/accounts"
@@ -64,6 +65,10 @@ type Node struct {
 	ipc           *ipcServer  // Stores information about the ipc http server
 	inprocHandler *rpc.Server // In-process RPC request handler to process the API requests
 
+	peerCount    func() int     // Peer count source gating the public RPC endpoints
+	minPeersQuit chan struct{}  // Closed to abort waiting for the minimum peer count
+	minPeersWG   sync.WaitGroup // Tracks the goroutine waiting for the minimum peer count
+
 	databases map[*closeTrackingDB]struct{} // All open databases
 }
 
@@ -73,6 +78,11 @@ const (
 	closedState
 )
 
+const (
+	minPeersCheckInterval = time.Second      // Interval to check the peer count while public RPC is held back
+	minPeersLogInterval   = 10 * time.Second // Interval to report waiting for the minimum peer count
+)
+
 // New creates a new P2P node, ready for protocol registration.
 func New(conf *Config) (*Node, error) {
 	// Copy config and resolve the datadir so future changes to the current
@@ -101,8 +111,16 @@ func New(conf *Config) (*Node, error) {
 	if strings.HasSuffix(conf.Name, ".ipc") {
 		return nil, errors.New(`Config.Name cannot end in ".ipc"`)
 	}
+	// Method timeouts never extend the HTTP write timeout, warn about any that
+	// can't take effect over HTTP.
+	for method, timeout := range conf.RPCMethodTimeouts {
+		if wt := conf.HTTPTimeouts.WriteTimeout; conf.HTTPHost != "" && timeout >= wt {
+			conf.Logger.Warn("RPC method timeout exceeds the HTTP write timeout", "method", method, "timeout", timeout, "writetimeout", wt)
+		}
+	}
 	server := rpc.NewServer()
 	server.SetBatchLimits(conf.BatchRequestLimit, conf.BatchResponseMaxSize)
+	server.SetMethodTimeouts(conf.RPCMethodTimeouts)
 	node := &Node{
 		config:        conf,
 		inprocHandler: server,
@@ -112,6 +130,7 @@ func New(conf *Config) (*Node, error) {
 		server:        &p2p.Server{Config: conf.P2P},
 		databases:     make(map[*closeTrackingDB]struct{}),
 	}
+	node.peerCount = node.server.PeerCount
 
 	// Register built-in APIs.
 	node.rpcAPIs = append(node.rpcAPIs, node.apis()...)
@@ -391,12 +410,14 @@ func (n *Node) startRPC() error {
 	}
 	var (
 		servers           []*httpServer
+		public            []*httpServer // unauthenticated endpoints, subject to the peer requirement
 		openAPIs, allAPIs = n.getAPIs()
 	)
 
 	rpcConfig := rpcEndpointConfig{
 		batchItemLimit:         n.config.BatchRequestLimit,
 		batchResponseSizeLimit: n.config.BatchResponseMaxSize,
+		methodTimeouts:         n.config.RPCMethodTimeouts,
 	}
 
 	initHttp := func(server *httpServer, port int) error {
@@ -408,11 +429,12 @@ func (n *Node) startRPC() error {
 			Vhosts:             n.config.HTTPVirtualHosts,
 			Modules:            n.config.HTTPModules,
 			prefix:             n.config.HTTPPathPrefix,
+			noCompression:      n.config.HTTPNoCompression,
 			rpcEndpointConfig:  rpcConfig,
 		}); err != nil {
 			return err
 		}
-		servers = append(servers, server)
+		public = append(public, server)
 		return nil
 	}
 
@@ -429,7 +451,7 @@ func (n *Node) startRPC() error {
 		}); err != nil {
 			return err
 		}
-		servers = append(servers, server)
+		public = append(public, server)
 		return nil
 	}
 
@@ -496,15 +518,52 @@ func (n *Node) startRPC() error {
 			return err
 		}
 	}
-	// Start the servers
-	for _, server := range servers {
+	// Start the servers, holding back requests to the public ones until enough
+	// peers are connected for them to serve reasonably fresh data.
+	for _, server := range public {
+		server.held.Store(n.config.RPCMinPeers > 0)
+	}
+	for _, server := range append(servers, public...) {
 		if err := server.start(); err != nil {
 			return err
 		}
 	}
+	if n.config.RPCMinPeers > 0 && len(public) > 0 {
+		n.minPeersQuit = make(chan struct{})
+		n.minPeersWG.Add(1)
+		go n.enablePublicRPC(public, n.minPeersQuit)
+	}
 	return nil
 }
 
+// enablePublicRPC waits until the node is connected to the configured minimum
+// number of peers and then enables the given unauthenticated RPC servers.
+func (n *Node) enablePublicRPC(servers []*httpServer, quit chan struct{}) {
+	defer n.minPeersWG.Done()
+
+	ticker := time.NewTicker(minPeersCheckInterval)
+	defer ticker.Stop()
+
+	var logged time.Time
+	for {
+		if peers := n.peerCount(); peers >= n.config.RPCMinPeers {
+			for _, server := range servers {
+				server.held.Store(false)
+			}
+			n.log.Info("Enabled RPC", "peers", peers)
+			return
+		} else if time.Since(logged) > minPeersLogInterval {
+			n.log.Info("Waiting for peers before enabling RPC", "peers", peers, "required", n.config.RPCMinPeers)
+			logged = time.Now()
+		}
+		select {
+		case <-ticker.C:
+		case <-quit:
+			return
+		}
+	}
+}
+
 func (n *Node) wsServerForPort(port int, authenticated bool) *httpServer {
 	httpServer, wsServer := n.http, n.ws
 	if authenticated {
@@ -517,6 +576,12 @@ func (n *Node) wsServerForPort(port int, authenticated bool) *httpServer {
 }
 
 func (n *Node) stopRPC() {
+	// Abort any pending delayed start before tearing the servers down
+	if n.minPeersQuit != nil {
+		close(n.minPeersQuit)
+		n.minPeersWG.Wait()
+		n.minPeersQuit = nil
+	}
 	n.http.stop()
 	n.ws.stop()
 	n.httpAuth.stop()
@@ -733,8 +798,9 @@ func (n *Node) OpenDatabase(name string, cache, handles int, namespace string, r
 // creates one if no previous can be found) from within the node's data directory,
 // also attaching a chain freezer to it that moves ancient chain data from the
 // database to immutable append-only files. If the node is an ephemeral one, a
-// memory database is returned.
-func (n *Node) OpenDatabaseWithFreezer(name string, cache, handles int, ancient string, namespace string, readonly bool) (zonddb.Database, error) {
+// memory database is returned. The freezer keeps the given number of recent blocks
+// in the key-value database, zero selecting the default threshold.
+func (n *Node) OpenDatabaseWithFreezer(name string, cache, handles int, ancient string, threshold uint64, namespace string, readonly bool) (zonddb.Database, error) {
 	n.lock.Lock()
 	defer n.lock.Unlock()
 	if n.state == closedState {
@@ -753,6 +819,7 @@ func (n *Node) OpenDatabaseWithFreezer(name string, cache, handles int, ancient
 			Cache:             cache,
 			Handles:           handles,
 			ReadOnly:          readonly,
+			FreezerThreshold:  threshold,
 		})
 	}
 
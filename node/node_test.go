@@ -24,6 +24,7 @@ import (
 	"net/http"
 	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -583,6 +584,123 @@ func (test rpcPrefixTest) check(t *testing.T, node *Node) {
 	}
 }
 
+// Tests that the public RPC endpoints are only enabled once the node is connected
+// to the configured minimum number of peers.
+func TestNodeRPCMinPeers(t *testing.T) {
+	t.Parallel()
+
+	node, err := New(&Config{HTTPHost: "127.0.0.1", RPCMinPeers: 2})
+	if err != nil {
+		t.Fatal("can't create node:", err)
+	}
+	defer node.Close()
+
+	// Report every peer count check, so the test can step along with them
+	var (
+		peers  atomic.Int32
+		polled = make(chan int)
+		done   = make(chan struct{})
+	)
+	defer close(done)
+
+	node.peerCount = func() int {
+		count := int(peers.Load())
+		select {
+		case polled <- count:
+		case <-done:
+		}
+		return count
+	}
+	peers.Store(1)
+	if err := node.Start(); err != nil {
+		t.Fatal("can't start node:", err)
+	}
+	if count := <-polled; count != 1 {
+		t.Fatalf("peer count mismatch: have %d, want 1", count)
+	}
+	if checkRPC(node.HTTPEndpoint()) {
+		t.Fatal("HTTP endpoint enabled below the minimum peer count")
+	}
+	// Once the minimum is reached, the servers are enabled before the waiting
+	// goroutine exits
+	peers.Store(2)
+	for <-polled != 2 {
+	}
+	node.minPeersWG.Wait()
+	if !checkRPC(node.HTTPEndpoint()) {
+		t.Fatal("HTTP endpoint not enabled after reaching the minimum peer count")
+	}
+}
+
+// Tests that failing to bind a public RPC endpoint fails the node start, even
+// if the endpoint is held back until the minimum peer count is reached.
+func TestNodeRPCMinPeersBindFailure(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("can't listen:", err)
+	}
+	defer listener.Close()
+
+	port := listener.Addr().(*net.TCPAddr).Port
+	node, err := New(&Config{HTTPHost: "127.0.0.1", HTTPPort: port, RPCMinPeers: 2})
+	if err != nil {
+		t.Fatal("can't create node:", err)
+	}
+	defer node.Close()
+
+	if err := node.Start(); err == nil {
+		t.Fatal("node started with an occupied HTTP port")
+	}
+}
+
+// Tests that rpc_modules only reports the modules enabled on the transport the
+// request arrived on.
+func TestNodeRPCModules(t *testing.T) {
+	t.Parallel()
+
+	node, err := New(&Config{
+		HTTPHost:    "127.0.0.1",
+		HTTPModules: []string{"test"},
+		WSHost:      "127.0.0.1",
+		WSModules:   []string{"test", "other"},
+	})
+	if err != nil {
+		t.Fatal("can't create node:", err)
+	}
+	defer node.Close()
+
+	node.RegisterAPIs([]rpc.API{
+		{Namespace: "test", Service: &testService{}},
+		{Namespace: "other", Service: &testService{}},
+		{Namespace: "hidden", Service: &testService{}},
+	})
+	if err := node.Start(); err != nil {
+		t.Fatal("can't start node:", err)
+	}
+	for _, test := range []struct {
+		endpoint string
+		want     map[string]string
+	}{
+		{node.HTTPEndpoint(), map[string]string{"rpc": "1.0", "test": "1.0"}},
+		{node.WSEndpoint(), map[string]string{"rpc": "1.0", "test": "1.0", "other": "1.0"}},
+	} {
+		client, err := rpc.Dial(test.endpoint)
+		if err != nil {
+			t.Fatalf("%s: can't dial: %v", test.endpoint, err)
+		}
+		modules, err := client.SupportedModules()
+		client.Close()
+		if err != nil {
+			t.Fatalf("%s: can't retrieve modules: %v", test.endpoint, err)
+		}
+		if !reflect.DeepEqual(modules, test.want) {
+			t.Errorf("%s: modules mismatch: have %v, want %v", test.endpoint, modules, test.want)
+		}
+	}
+}
+
 func createNode(t *testing.T, httpPort, wsPort int) *Node {
 	conf := &Config{
 		HTTPHost:     "127.0.0.1",
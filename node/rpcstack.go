@@ -18,6 +18,7 @@ package node
 
 import (
 	"compress/gzip"
+	"compress/zlib"
 	"context"
 	"fmt"
 	"io"
@@ -41,6 +42,7 @@ type httpConfig struct {
 	CorsAllowedOrigins []string
 	Vhosts             []string
 	prefix             string // path prefix on which to mount http handler
+	noCompression      bool   // disables gzip/deflate compression of responses
 	rpcEndpointConfig
 }
 
@@ -56,6 +58,7 @@ type rpcEndpointConfig struct {
 	jwtSecret              []byte // optional JWT secret
 	batchItemLimit         int
 	batchResponseSizeLimit int
+	methodTimeouts         map[string]time.Duration
 }
 
 type rpcHandler struct {
@@ -86,6 +89,8 @@ type httpServer struct {
 	host     string
 	port     int
 
+	held atomic.Bool // Rejects all requests while set, e.g. to wait for peers
+
 	handlerNames map[string]string
 }
 
@@ -195,6 +200,10 @@ func (h *httpServer) start() error {
 }
 
 func (h *httpServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.held.Load() {
+		http.Error(w, "RPC not yet available", http.StatusServiceUnavailable)
+		return
+	}
 	// check if ws request and serve if ws enabled
 	ws := h.wsHandler.Load().(*rpcHandler)
 	if ws != nil && isWebsocket(r) {
@@ -304,12 +313,13 @@ func (h *httpServer) enableRPC(apis []rpc.API, config httpConfig) error {
 	// Create RPC server and handler.
 	srv := rpc.NewServer()
 	srv.SetBatchLimits(config.batchItemLimit, config.batchResponseSizeLimit)
+	srv.SetMethodTimeouts(config.methodTimeouts)
 	if err := RegisterApis(apis, config.Modules, srv); err != nil {
 		return err
 	}
 	h.httpConfig = config
 	h.httpHandler.Store(&rpcHandler{
-		Handler: NewHTTPHandlerStack(srv, config.CorsAllowedOrigins, config.Vhosts, config.jwtSecret),
+		Handler: newHTTPHandlerStack(srv, config.CorsAllowedOrigins, config.Vhosts, config.jwtSecret, !config.noCompression),
 		server:  srv,
 	})
 	return nil
@@ -336,6 +346,7 @@ func (h *httpServer) enableWS(apis []rpc.API, config wsConfig) error {
 	// Create RPC server and handler.
 	srv := rpc.NewServer()
 	srv.SetBatchLimits(config.batchItemLimit, config.batchResponseSizeLimit)
+	srv.SetMethodTimeouts(config.methodTimeouts)
 	if err := RegisterApis(apis, config.Modules, srv); err != nil {
 		return err
 	}
@@ -387,13 +398,22 @@ func isWebsocket(r *http.Request) bool {
 
 // NewHTTPHandlerStack returns wrapped http-related handlers
 func NewHTTPHandlerStack(srv http.Handler, cors []string, vhosts []string, jwtSecret []byte) http.Handler {
+	return newHTTPHandlerStack(srv, cors, vhosts, jwtSecret, true)
+}
+
+// newHTTPHandlerStack returns wrapped http-related handlers, optionally
+// compressing the responses.
+func newHTTPHandlerStack(srv http.Handler, cors []string, vhosts []string, jwtSecret []byte, compress bool) http.Handler {
 	// Wrap the CORS-handler within a host-handler
 	handler := newCorsHandler(srv, cors)
 	handler = newVHostHandler(vhosts, handler)
 	if len(jwtSecret) != 0 {
 		handler = newJWTHandler(jwtSecret, handler)
 	}
-	return newGzipHandler(handler)
+	if !compress {
+		return handler
+	}
+	return newCompressHandler(handler)
 }
 
 // NewWSHandlerStack returns a wrapped ws-related handler.
@@ -464,26 +484,42 @@ func (h *virtualHostHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	http.Error(w, "invalid host specified", http.StatusForbidden)
 }
 
-var gzPool = sync.Pool{
-	New: func() interface{} {
-		w := gzip.NewWriter(io.Discard)
-		return w
+// compressWriter is the common interface of the gzip and zlib stream writers.
+type compressWriter interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// Pools of compressed stream writers, keyed by content encoding.
+var compressPools = map[string]*sync.Pool{
+	"gzip": {
+		New: func() interface{} {
+			return gzip.NewWriter(io.Discard)
+		},
+	},
+	"deflate": {
+		New: func() interface{} {
+			return zlib.NewWriter(io.Discard)
+		},
 	},
 }
 
-type gzipResponseWriter struct {
-	resp http.ResponseWriter
+type compressResponseWriter struct {
+	resp     http.ResponseWriter
+	encoding string // content encoding negotiated with the client
 
-	gz            *gzip.Writer
+	enc           compressWriter
 	contentLength uint64 // total length of the uncompressed response
 	written       uint64 // amount of written bytes from the uncompressed response
 	hasLength     bool   // true if uncompressed response had Content-Length
 	inited        bool   // true after init was called for the first time
+	closed        bool   // true after the compressed stream was closed
 }
 
 // init runs just before response headers are written. Among other things, this function
 // also decides whether compression will be applied at all.
-func (w *gzipResponseWriter) init() {
+func (w *compressResponseWriter) init() {
 	if w.inited {
 		return
 	}
@@ -492,7 +528,7 @@ func (w *gzipResponseWriter) init() {
 	hdr := w.resp.Header()
 	length := hdr.Get("content-length")
 	if len(length) > 0 {
-		if n, err := strconv.ParseUint(length, 10, 64); err != nil {
+		if n, err := strconv.ParseUint(length, 10, 64); err == nil {
 			w.hasLength = true
 			w.contentLength = n
 		}
@@ -501,7 +537,7 @@ func (w *gzipResponseWriter) init() {
 	// Setting Transfer-Encoding to "identity" explicitly disables compression. net/http
 	// also recognizes this header value and uses it to disable "chunked" transfer
 	// encoding, trimming the header from the response. This means downstream handlers can
-	// set this without harm, even if they aren't wrapped by newGzipHandler.
+	// set this without harm, even if they aren't wrapped by newCompressHandler.
 	//
 	// In go-ethereum, we use this signal to disable compression for certain error
 	// responses which are flushed out close to the write deadline of the response. For
@@ -509,67 +545,105 @@ func (w *gzipResponseWriter) init() {
 	// they require additional output that may not get written in time.
 	passthrough := hdr.Get("transfer-encoding") == "identity"
 	if !passthrough {
-		w.gz = gzPool.Get().(*gzip.Writer)
-		w.gz.Reset(w.resp)
+		w.enc = compressPools[w.encoding].Get().(compressWriter)
+		w.enc.Reset(w.resp)
 		hdr.Del("content-length")
-		hdr.Set("content-encoding", "gzip")
+		hdr.Set("content-encoding", w.encoding)
 	}
 }
 
-func (w *gzipResponseWriter) Header() http.Header {
+func (w *compressResponseWriter) Header() http.Header {
 	return w.resp.Header()
 }
 
-func (w *gzipResponseWriter) WriteHeader(status int) {
+func (w *compressResponseWriter) WriteHeader(status int) {
 	w.init()
 	w.resp.WriteHeader(status)
 }
 
-func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+func (w *compressResponseWriter) Write(b []byte) (int, error) {
 	w.init()
 
-	if w.gz == nil {
+	if w.enc == nil {
 		// Compression is disabled.
 		return w.resp.Write(b)
 	}
 
-	n, err := w.gz.Write(b)
+	n, err := w.enc.Write(b)
 	w.written += uint64(n)
 	if w.hasLength && w.written >= w.contentLength {
 		// The HTTP handler has finished writing the entire uncompressed response. Close
-		// the gzip stream to ensure the footer will be seen by the client in case the
-		// response is flushed after this call to write.
-		err = w.gz.Close()
+		// the compressed stream to ensure the footer will be seen by the client in case
+		// the response is flushed after this call to write.
+		err = w.closeStream()
 	}
 	return n, err
 }
 
-func (w *gzipResponseWriter) Flush() {
-	if w.gz != nil {
-		w.gz.Flush()
+func (w *compressResponseWriter) Flush() {
+	if w.enc != nil && !w.closed {
+		w.enc.Flush()
 	}
 	if f, ok := w.resp.(http.Flusher); ok {
 		f.Flush()
 	}
 }
 
-func (w *gzipResponseWriter) close() {
-	if w.gz == nil {
+// closeStream closes the compressed stream, writing its footer. Closing it again
+// is a no-op, as some encoders (e.g. zlib) would append the footer twice.
+func (w *compressResponseWriter) closeStream() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.enc.Close()
+}
+
+func (w *compressResponseWriter) close() {
+	if w.enc == nil {
 		return
 	}
-	w.gz.Close()
-	gzPool.Put(w.gz)
-	w.gz = nil
+	w.closeStream()
+	compressPools[w.encoding].Put(w.enc)
+	w.enc = nil
+}
+
+// negotiateEncoding picks the response content encoding from the Accept-Encoding
+// header of a request, preferring gzip over deflate. An empty string is returned
+// if the client accepts neither of them.
+func negotiateEncoding(accept string) string {
+	var deflate bool
+	for _, part := range strings.Split(accept, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil && v == 0 {
+				continue // explicitly refused by the client
+			}
+		}
+		switch strings.ToLower(strings.TrimSpace(coding)) {
+		case "gzip":
+			return "gzip"
+		case "deflate":
+			deflate = true
+		}
+	}
+	if deflate {
+		return "deflate"
+	}
+	return ""
 }
 
-func newGzipHandler(next http.Handler) http.Handler {
+// newCompressHandler wraps the given handler, compressing its responses with
+// gzip or deflate depending on the encodings accepted by the client.
+func newCompressHandler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" {
 			next.ServeHTTP(w, r)
 			return
 		}
 
-		wrapper := &gzipResponseWriter{resp: w}
+		wrapper := &compressResponseWriter{resp: w, encoding: encoding}
 		defer wrapper.close()
 
 		next.ServeHTTP(wrapper, r)
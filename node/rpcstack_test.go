@@ -18,6 +18,8 @@ package node
 
 import (
 	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"fmt"
 	"io"
 	"net/http"
@@ -524,7 +526,7 @@ func TestGzipHandler(t *testing.T) {
 	for _, test := range tests {
 		test := test
 		t.Run(test.name, func(t *testing.T) {
-			srv := httptest.NewServer(newGzipHandler(test.handler))
+			srv := httptest.NewServer(newCompressHandler(test.handler))
 			defer srv.Close()
 
 			resp, err := http.Get(srv.URL)
@@ -557,6 +559,91 @@ func TestGzipHandler(t *testing.T) {
 	}
 }
 
+// Tests that a compressed response with a known length is closed exactly once,
+// as closing a deflate stream twice appends its checksum again.
+func TestCompressHandlerContentLength(t *testing.T) {
+	srv := httptest.NewServer(newCompressHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-length", "8")
+		w.Write([]byte("response"))
+	})))
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	req.Header.Set("accept-encoding", "deflate")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream := bytes.NewReader(raw)
+	zr, err := zlib.NewReader(stream)
+	if err != nil {
+		t.Fatalf("invalid deflate stream: %v", err)
+	}
+	content, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("failed to decompress response: %v", err)
+	}
+	if string(content) != "response" {
+		t.Fatalf("wrong response content %q", content)
+	}
+	if stream.Len() != 0 {
+		t.Fatalf("trailing data after deflate stream: %x", raw[len(raw)-stream.Len():])
+	}
+}
+
+func TestHTTPCompression(t *testing.T) {
+	for _, tt := range []struct {
+		accept        string
+		noCompression bool
+		want          string
+	}{
+		{accept: "gzip", want: "gzip"},
+		{accept: "deflate", want: "deflate"},
+		{accept: "deflate, gzip;q=0.5", want: "gzip"},
+		{accept: "gzip;q=0, deflate", want: "deflate"},
+		{accept: "identity", want: ""},
+		{accept: "gzip", noCompression: true, want: ""},
+	} {
+		srv := createAndStartServer(t, &httpConfig{Modules: []string{"test"}, noCompression: tt.noCompression}, false, &wsConfig{}, nil)
+		url := fmt.Sprintf("http://%v", srv.listenAddr())
+
+		resp := rpcRequest(t, url, testMethod, "accept-encoding", tt.accept)
+		if have := resp.Header.Get("content-encoding"); have != tt.want {
+			t.Fatalf("accept %q: content encoding mismatch: have %q, want %q", tt.accept, have, tt.want)
+		}
+		var body io.Reader = resp.Body
+		switch tt.want {
+		case "gzip":
+			gz, err := gzip.NewReader(resp.Body)
+			if err != nil {
+				t.Fatalf("accept %q: invalid gzip stream: %v", tt.accept, err)
+			}
+			body = gz
+		case "deflate":
+			zr, err := zlib.NewReader(resp.Body)
+			if err != nil {
+				t.Fatalf("accept %q: invalid deflate stream: %v", tt.accept, err)
+			}
+			body = zr
+		}
+		content, err := io.ReadAll(body)
+		if err != nil {
+			t.Fatalf("accept %q: failed to read response: %v", tt.accept, err)
+		}
+		resp.Body.Close()
+		if !strings.Contains(string(content), `"jsonrpc":"2.0"`) {
+			t.Fatalf("accept %q: unexpected response %q", tt.accept, content)
+		}
+		srv.stop()
+	}
+}
+
 func TestHTTPWriteTimeout(t *testing.T) {
 	const (
 		timeoutRes = `{"jsonrpc":"2.0","id":1,"error":{"code":-32002,"message":"request timed out"}}`
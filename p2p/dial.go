@@ -137,6 +137,9 @@ type dialConfig struct {
 	log            log.Logger
 	clock          mclock.Clock
 	rand           *mrand.Rand
+	dialResult     func(enode.ID, error) // reports the outcome of dynamic dials, may be nil
+
+	maxStaticBackoff time.Duration // upper bound of static redial backoff, disabled if zero
 }
 
 func (cfg dialConfig) withDefaults() dialConfig {
@@ -250,6 +253,9 @@ loop:
 		case task := <-d.doneCh:
 			id := task.dest.ID()
 			delete(d.dialing, id)
+			if task.flags&staticDialedConn != 0 && task.err != nil {
+				d.backoffStatic(task)
+			}
 			d.updateStaticPool(id)
 			d.doneSinceLastLog++
 
@@ -261,8 +267,11 @@ loop:
 			d.peers[id] = struct{}{}
 			// Remove from static pool because the node is now connected.
 			task := d.static[id]
-			if task != nil && task.staticPoolIndex >= 0 {
-				d.removeFromStaticPool(task.staticPoolIndex)
+			if task != nil {
+				task.connected = d.clock.Now()
+				if task.staticPoolIndex >= 0 {
+					d.removeFromStaticPool(task.staticPoolIndex)
+				}
 			}
 			// TODO: cancel dials to connected peers
 
@@ -270,8 +279,22 @@ loop:
 			if c.is(dynDialedConn) || c.is(staticDialedConn) {
 				d.dialPeers--
 			}
-			delete(d.peers, c.node.ID())
-			d.updateStaticPool(c.node.ID())
+			id := c.node.ID()
+			delete(d.peers, id)
+			// A static peer dropping before it outlived its current backoff is
+			// treated as a failed attempt, a stable connection resets it.
+			if task := d.static[id]; task != nil {
+				stable := task.backoff
+				if stable < dialHistoryExpiration {
+					stable = dialHistoryExpiration
+				}
+				if time.Duration(d.clock.Now()-task.connected) < stable {
+					d.backoffStatic(task)
+				} else {
+					task.failures, task.backoff = 0, 0
+				}
+			}
+			d.updateStaticPool(id)
 
 		case node := <-d.addStaticCh:
 			id := node.ID()
@@ -407,6 +430,25 @@ func (d *dialScheduler) startStaticDials(n int) (started int) {
 	return started
 }
 
+// backoffStatic records a failed attempt to keep the given static node connected
+// and delays its next dial exponentially, starting at dialHistoryExpiration and
+// capped at maxStaticBackoff. It is a no-op if static backoff is disabled.
+func (d *dialScheduler) backoffStatic(task *dialTask) {
+	if d.maxStaticBackoff <= 0 {
+		return
+	}
+	task.failures++
+	task.backoff = dialHistoryExpiration
+	for i := 1; i < task.failures && task.backoff < d.maxStaticBackoff; i++ {
+		task.backoff *= 2
+	}
+	if task.backoff > d.maxStaticBackoff {
+		task.backoff = d.maxStaticBackoff
+	}
+	d.log.Debug("Delaying static dial", "id", task.dest.ID(), "failures", task.failures, "delay", task.backoff)
+	d.history.add(string(task.dest.ID().Bytes()), d.clock.Now().Add(task.backoff))
+}
+
 // updateStaticPool attempts to move the given static dial back into staticPool.
 func (d *dialScheduler) updateStaticPool(id enode.ID) {
 	task, ok := d.static[id]
@@ -456,6 +498,12 @@ type dialTask struct {
 	dest         *enode.Node
 	lastResolved mclock.AbsTime
 	resolveDelay time.Duration
+	err          error // outcome of the last run
+
+	// Static redial backoff state, owned by the dialScheduler loop.
+	failures  int            // consecutive failed attempts
+	backoff   time.Duration  // current redial delay
+	connected mclock.AbsTime // time the node last connected
 }
 
 func newDialTask(dest *enode.Node, flags connFlag) *dialTask {
@@ -467,6 +515,7 @@ type dialError struct {
 }
 
 func (t *dialTask) run(d *dialScheduler) {
+	t.err = nil
 	if t.needResolve() && !t.resolve(d) {
 		return
 	}
@@ -476,10 +525,14 @@ func (t *dialTask) run(d *dialScheduler) {
 		// For static nodes, resolve one more time if dialing fails.
 		if _, ok := err.(*dialError); ok && t.flags&staticDialedConn != 0 {
 			if t.resolve(d) {
-				t.dial(d, t.dest)
+				err = t.dial(d, t.dest)
 			}
 		}
 	}
+	t.err = err
+	if t.flags&dynDialedConn != 0 && d.dialResult != nil {
+		d.dialResult(t.dest.ID(), err)
+	}
 }
 
 func (t *dialTask) needResolve() bool {
@@ -358,6 +358,55 @@ func TestDialSchedHistory(t *testing.T) {
 	})
 }
 
+// This test checks that redials of a failing static node follow the exponential
+// backoff schedule, and that a quickly dropped static peer is backed off too.
+func TestDialSchedStaticBackoff(t *testing.T) {
+	t.Parallel()
+
+	var (
+		config = dialConfig{
+			maxActiveDials:   1,
+			maxDialPeers:     1,
+			maxStaticBackoff: 2 * time.Minute,
+		}
+		node   = newNode(uintID(0x01), "127.0.0.1:30303")
+		dial   = dialTestRound{wantNewDials: []*enode.Node{node}}
+		fail   = dialTestRound{failed: []enode.ID{node.ID()}, wantResolves: map[enode.ID]*enode.Node{node.ID(): nil}}
+		rounds []dialTestRound
+	)
+	// wait appends n rounds in which no dial may happen. Each round advances
+	// the clock by 16s.
+	wait := func(n int) {
+		for i := 0; i < n; i++ {
+			rounds = append(rounds, dialTestRound{})
+		}
+	}
+	rounds = append(rounds, dialTestRound{
+		update:       func(d *dialScheduler) { d.addStatic(node) },
+		wantNewDials: []*enode.Node{node},
+	})
+	// 1st failure at 16s, retried after 35s.
+	rounds = append(rounds, fail)
+	wait(2)
+	rounds = append(rounds, dial)
+	// 2nd failure at 80s, retried after 70s.
+	rounds = append(rounds, fail)
+	wait(4)
+	rounds = append(rounds, dial)
+	// 3rd failure at 176s, retried after 2m (capped from 140s).
+	rounds = append(rounds, fail)
+	wait(7)
+	rounds = append(rounds, dial)
+	// The dial succeeds at 320s, but the peer drops 16s later. The drop is
+	// counted as another failure, delaying the redial by 2m.
+	rounds = append(rounds, dialTestRound{succeeded: []enode.ID{node.ID()}})
+	rounds = append(rounds, dialTestRound{peersRemoved: []enode.ID{node.ID()}})
+	wait(7)
+	rounds = append(rounds, dial)
+
+	runDialTest(t, config, rounds)
+}
+
 func TestDialSchedResolve(t *testing.T) {
 	t.Parallel()
 
@@ -19,6 +19,8 @@ package enode
 import (
 	"sync"
 	"time"
+
+	"github.com/theQRL/go-zond/common/mclock"
 )
 
 // Iterator represents a sequence of nodes. The Next method moves to the next node in the
@@ -123,6 +125,107 @@ func (f *filterIter) Next() bool {
 	return false
 }
 
+// CooldownIter wraps an iterator such that nodes which repeatedly failed to be
+// dialed are skipped. After 'limit' consecutive failures, a node is not returned
+// by Next until 'cooldown' has passed since its last failure. Dial outcomes are
+// reported through DialFailed and DialSucceeded. Failure records are dropped
+// once no failure was reported for 'cooldown', keeping the tracking bounded to
+// the nodes failing recently.
+type CooldownIter struct {
+	Iterator
+	limit    int
+	cooldown time.Duration
+	clock    mclock.Clock
+
+	mu         sync.Mutex
+	failures   map[ID]*dialFailures
+	lastExpiry mclock.AbsTime // Time of the last sweep for expired failures
+}
+
+type dialFailures struct {
+	count int
+	last  mclock.AbsTime
+}
+
+// NewCooldownIter creates a CooldownIter wrapping the given iterator.
+func NewCooldownIter(it Iterator, limit int, cooldown time.Duration) *CooldownIter {
+	return &CooldownIter{
+		Iterator: it,
+		limit:    limit,
+		cooldown: cooldown,
+		clock:    mclock.System{},
+		failures: make(map[ID]*dialFailures),
+	}
+}
+
+// Next moves to the next node which is not cooling down.
+func (it *CooldownIter) Next() bool {
+	for it.Iterator.Next() {
+		if !it.coolingDown(it.Node().ID()) {
+			return true
+		}
+	}
+	return false
+}
+
+// DialFailed records a failed connection attempt to the given node.
+func (it *CooldownIter) DialFailed(id ID) {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+
+	now := it.clock.Now()
+	it.expireFailures(now)
+
+	f := it.failures[id]
+	if f == nil {
+		f = new(dialFailures)
+		it.failures[id] = f
+	}
+	f.count++
+	f.last = now
+}
+
+// expireFailures drops the failure records of all nodes whose last failure is
+// at least a cooldown old. To keep DialFailed cheap, the records are swept at
+// most once per cooldown period. The caller must hold it.mu.
+func (it *CooldownIter) expireFailures(now mclock.AbsTime) {
+	if time.Duration(now-it.lastExpiry) < it.cooldown {
+		return
+	}
+	it.lastExpiry = now
+	for id, f := range it.failures {
+		if time.Duration(now-f.last) >= it.cooldown {
+			delete(it.failures, id)
+		}
+	}
+}
+
+// DialSucceeded records a successful connection to the given node, clearing
+// any previously recorded failures.
+func (it *CooldownIter) DialSucceeded(id ID) {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+
+	delete(it.failures, id)
+}
+
+// coolingDown reports whether the given node has reached the failure limit and
+// should not be returned yet. Nodes whose cooldown has expired are forgiven.
+func (it *CooldownIter) coolingDown(id ID) bool {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+
+	f := it.failures[id]
+	if f == nil || f.count < it.limit {
+		return false
+	}
+	if time.Duration(it.clock.Now()-f.last) >= it.cooldown {
+		delete(it.failures, id)
+		return false
+	}
+	return true
+}
+
 // FairMix aggregates multiple node iterators. The mixer itself is an iterator which ends
 // only when Close is called. Source iterators added via AddSource are removed from the
 // mix when they end.
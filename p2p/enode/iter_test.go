@@ -23,6 +23,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/theQRL/go-zond/common/mclock"
 	"github.com/theQRL/go-zond/p2p/enr"
 )
 
@@ -70,6 +71,79 @@ func TestFilterNodes(t *testing.T) {
 	}
 }
 
+// This test checks that CooldownIter skips nodes which failed to be dialed too
+// often, and returns them again once the cooldown has passed.
+func TestCooldownIter(t *testing.T) {
+	var (
+		clock = new(mclock.Simulated)
+		nodes = []*Node{testNode(0, 0), testNode(1, 0), testNode(2, 0)}
+		it    = NewCooldownIter(CycleNodes(nodes), 2, time.Minute)
+	)
+	it.clock = clock
+
+	// Read a few rounds, returning the set of nodes seen.
+	read := func() map[ID]bool {
+		seen := make(map[ID]bool)
+		for i := 0; i < 3*len(nodes); i++ {
+			if !it.Next() {
+				t.Fatal("Next returned false")
+			}
+			seen[it.Node().ID()] = true
+		}
+		return seen
+	}
+	bad := nodes[1].ID()
+
+	// A single failure is below the limit.
+	it.DialFailed(bad)
+	if !read()[bad] {
+		t.Fatal("node skipped below the failure limit")
+	}
+	// Reaching the limit puts the node into cooldown.
+	it.DialFailed(bad)
+	if seen := read(); seen[bad] || len(seen) != 2 {
+		t.Fatalf("wrong nodes returned during cooldown: %v", seen)
+	}
+	clock.Run(time.Minute - time.Second)
+	if read()[bad] {
+		t.Fatal("node returned before the cooldown expired")
+	}
+	// The node is returned again after the cooldown.
+	clock.Run(time.Second)
+	if !read()[bad] {
+		t.Fatal("node skipped after the cooldown expired")
+	}
+	// A successful dial resets the failure count.
+	it.DialFailed(bad)
+	it.DialSucceeded(bad)
+	it.DialFailed(bad)
+	if !read()[bad] {
+		t.Fatal("failures not reset by successful dial")
+	}
+}
+
+// This test checks that CooldownIter forgets failures once their cooldown has
+// expired, even if the failing nodes are never returned by the iterator again.
+func TestCooldownIterExpiry(t *testing.T) {
+	var (
+		clock = new(mclock.Simulated)
+		it    = NewCooldownIter(CycleNodes(nil), 2, time.Minute)
+	)
+	it.clock = clock
+
+	for i := 0; i < 100; i++ {
+		it.DialFailed(testNode(uint64(i), 0).ID())
+	}
+	if len(it.failures) != 100 {
+		t.Fatalf("wrong number of tracked failures: have %d, want 100", len(it.failures))
+	}
+	clock.Run(time.Minute)
+	it.DialFailed(testNode(100, 0).ID())
+	if len(it.failures) != 1 {
+		t.Fatalf("expired failures not evicted: have %d tracked, want 1", len(it.failures))
+	}
+}
+
 func checkNodes(t *testing.T, nodes []*Node, wantLen int) {
 	if len(nodes) != wantLen {
 		t.Errorf("slice has %d nodes, want %d", len(nodes), wantLen)
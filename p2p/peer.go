@@ -22,6 +22,7 @@ import (
 	"io"
 	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/theQRL/go-zond/common/mclock"
@@ -115,6 +116,10 @@ type Peer struct {
 	pingRecv chan struct{}
 	disc     chan DiscReason
 
+	// Subprotocol payload traffic, tracked for bandwidth accounting
+	ingress atomic.Uint64
+	egress  atomic.Uint64
+
 	// events receives message send / receive events if set
 	events   *event.Feed
 	testPipe *MsgPipeRW // for testing
@@ -362,6 +367,7 @@ func (p *Peer) handle(msg Msg) error {
 			metrics.GetOrRegisterMeter(m, nil).Mark(int64(msg.meterSize))
 			metrics.GetOrRegisterMeter(m+"/packets", nil).Mark(1)
 		}
+		p.ingress.Add(uint64(msg.Size))
 		select {
 		case proto.in <- msg:
 			return nil
@@ -416,6 +422,7 @@ func (p *Peer) startProtocols(writeStart <-chan struct{}, writeErr chan<- error)
 		proto.closed = p.closed
 		proto.wstart = writeStart
 		proto.werr = writeErr
+		proto.egress = &p.egress
 		var rw MsgReadWriter = proto
 		if p.events != nil {
 			rw = newMsgEventer(rw, p.events, p.ID(), proto.Name, p.Info().Network.RemoteAddress, p.Info().Network.LocalAddress)
@@ -454,6 +461,7 @@ type protoRW struct {
 	werr   chan<- error    // for write results
 	offset uint64
 	w      MsgWriter
+	egress *atomic.Uint64 // counts written payload bytes, if set
 }
 
 func (rw *protoRW) WriteMsg(msg Msg) (err error) {
@@ -468,6 +476,9 @@ func (rw *protoRW) WriteMsg(msg Msg) (err error) {
 	select {
 	case <-rw.wstart:
 		err = rw.w.WriteMsg(msg)
+		if err == nil && rw.egress != nil {
+			rw.egress.Add(uint64(msg.Size))
+		}
 		// Report write status back to Peer.run. It will initiate
 		// shutdown if the error is non-nil and unblock the next write
 		// otherwise. The calling protocol code should exit for errors
@@ -508,6 +519,21 @@ type PeerInfo struct {
 	Protocols map[string]interface{} `json:"protocols"` // Sub-protocol specific metadata fields
 }
 
+// PeerBandwidth represents the subprotocol payload traffic exchanged with a peer.
+type PeerBandwidth struct {
+	Ingress uint64 `json:"ingress"` // Bytes received from the peer
+	Egress  uint64 `json:"egress"`  // Bytes sent to the peer
+}
+
+// Bandwidth returns the number of subprotocol payload bytes exchanged with the
+// peer since the connection was established.
+func (p *Peer) Bandwidth() PeerBandwidth {
+	return PeerBandwidth{
+		Ingress: p.ingress.Load(),
+		Egress:  p.egress.Load(),
+	}
+}
+
 // Info gathers and returns a collection of metadata known about a peer.
 func (p *Peer) Info() *PeerInfo {
 	// Gather the protocol capabilities
@@ -168,6 +168,43 @@ func TestPeerProtoEncodeMsg(t *testing.T) {
 	}
 }
 
+func TestPeerBandwidth(t *testing.T) {
+	sent, done := make(chan struct{}), make(chan struct{})
+	proto := Protocol{
+		Name:   "a",
+		Length: 5,
+		Run: func(peer *Peer, rw MsgReadWriter) error {
+			if err := ExpectMsg(rw, 2, []uint{1}); err != nil {
+				t.Error(err)
+			}
+			if err := SendItems(rw, 3, "foo"); err != nil {
+				t.Error(err)
+			}
+			close(sent)
+			<-done
+			return nil
+		},
+	}
+	closer, rw, peer, _ := testPeer([]Protocol{proto})
+	defer closer()
+	defer close(done)
+
+	if err := Send(rw, baseProtocolLength+2, []uint{1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ExpectMsg(rw, baseProtocolLength+3, []string{"foo"}); err != nil {
+		t.Fatal(err)
+	}
+	<-sent
+	bw := peer.Bandwidth()
+	if bw.Ingress == 0 {
+		t.Error("no ingress bytes recorded")
+	}
+	if bw.Egress == 0 {
+		t.Error("no egress bytes recorded")
+	}
+}
+
 func TestPeerPing(t *testing.T) {
 	closer, rw, _, _ := testPeer(nil)
 	defer closer()
@@ -89,6 +89,11 @@ type Config struct {
 	// Setting DialRatio to zero defaults it to 3.
 	DialRatio int `toml:",omitempty"`
 
+	// MaxStaticBackoff bounds the exponential backoff between reconnection
+	// attempts to static peers which fail to connect or drop quickly. Zero
+	// disables the backoff, redialing such peers at a fixed interval.
+	MaxStaticBackoff time.Duration `toml:",omitempty"`
+
 	// NoDiscovery can be used to disable the peer discovery mechanism.
 	// Disabling is useful for protocol debugging (manual topology).
 	NoDiscovery bool
@@ -601,6 +606,9 @@ func (srv *Server) setupDialScheduler() {
 		netRestrict:    srv.NetRestrict,
 		dialer:         srv.Dialer,
 		clock:          srv.clock,
+		dialResult:     srv.reportDialResult,
+
+		maxStaticBackoff: srv.MaxStaticBackoff,
 	}
 	if srv.ntab != nil {
 		config.resolver = srv.ntab
@@ -614,6 +622,36 @@ func (srv *Server) setupDialScheduler() {
 	}
 }
 
+// dialTracker is implemented by dial candidate iterators which want to learn
+// about the outcome of connection attempts, such as enode.CooldownIter.
+type dialTracker interface {
+	DialFailed(id enode.ID)
+	DialSucceeded(id enode.ID)
+}
+
+// reportDialResult forwards the outcome of a dynamic dial to the protocol dial
+// candidate iterators tracking them. Only failures to establish the connection
+// itself count as failed dials.
+func (srv *Server) reportDialResult(id enode.ID, err error) {
+	var dialErr *dialError
+	if err != nil && !errors.As(err, &dialErr) {
+		return
+	}
+	seen := make(map[dialTracker]bool)
+	for _, proto := range srv.Protocols {
+		tracker, ok := proto.DialCandidates.(dialTracker)
+		if !ok || seen[tracker] {
+			continue
+		}
+		seen[tracker] = true
+		if err != nil {
+			tracker.DialFailed(id)
+		} else {
+			tracker.DialSucceeded(id)
+		}
+	}
+}
+
 func (srv *Server) maxInboundConns() int {
 	return srv.MaxPeers - srv.maxDialedConns()
 }
@@ -1132,3 +1170,16 @@ func (srv *Server) PeersInfo() []*PeerInfo {
 	}
 	return infos
 }
+
+// PeersBandwidth returns the traffic exchanged with each connected peer, keyed
+// by node identifier.
+func (srv *Server) PeersBandwidth() map[string]PeerBandwidth {
+	peers := srv.Peers()
+	bandwidth := make(map[string]PeerBandwidth, len(peers))
+	for _, peer := range peers {
+		if peer != nil {
+			bandwidth[peer.ID().String()] = peer.Bandwidth()
+		}
+	}
+	return bandwidth
+}
@@ -29,6 +29,12 @@ var (
 	BetaNetGenesisHash = common.HexToHash("0xdbad833547c0da0aa80a33f575833fb6a5931e6d14060c96de96a681eefc669c")
 )
 
+// Network IDs of the preconfigured networks.
+const (
+	MainnetNetworkID uint64 = 1
+	BetaNetNetworkID uint64 = 2
+)
+
 func newUint64(val uint64) *uint64 { return &val }
 
 var (
@@ -81,6 +87,20 @@ type ChainConfig struct {
 	ChainID *big.Int `json:"chainId"` // chainId identifies the current chain and is used for replay protection
 
 	IsDevMode bool `json:"isDev,omitempty"`
+
+	// GasLimitBoundDivisor bounds the gas limit change between consecutive
+	// blocks to parentGasLimit/GasLimitBoundDivisor. Zero means the protocol
+	// default, params.GasLimitBoundDivisor.
+	GasLimitBoundDivisor uint64 `json:"gasLimitBoundDivisor,omitempty"`
+
+	// AllowEFCodePrefix disables EIP-3541, permitting contract creation to
+	// deploy code starting with the 0xEF byte. Intended for alternate networks
+	// which do not reserve the prefix for EOF.
+	AllowEFCodePrefix bool `json:"allowEFCodePrefix,omitempty"`
+
+	// BlockHashWindow is the number of most recent block hashes the BLOCKHASH
+	// opcode can access. Zero means the protocol default, params.BlockHashWindow.
+	BlockHashWindow uint64 `json:"blockHashWindow,omitempty"`
 }
 
 // Description returns a human-readable description of ChainConfig.
@@ -99,6 +119,50 @@ func (c *ChainConfig) Description() string {
 	return banner
 }
 
+// Fork describes a protocol upgrade and the point at which it activates.
+// Forks up to and including the merge are scheduled by block number, later
+// ones by timestamp; exactly one of Block and Timestamp is set, or neither if
+// the fork is disabled by the chain config.
+type Fork struct {
+	Name      string
+	Block     *big.Int
+	Timestamp *uint64
+}
+
+// Active reports whether the fork is active at the given block number and time.
+func (f Fork) Active(num *big.Int, time uint64) bool {
+	if f.Block != nil {
+		return f.Block.Cmp(num) <= 0
+	}
+	return f.Timestamp != nil && *f.Timestamp <= time
+}
+
+// Forks returns the protocol upgrades of the chain in activation order. Zond
+// launched with the Shanghai ruleset, so every upgrade folded into it is active
+// from genesis, unless the chain config opts out of it.
+func (c *ChainConfig) Forks() []Fork {
+	eip3541 := Fork{Name: "eip3541"}
+	if !c.AllowEFCodePrefix {
+		eip3541.Block = big.NewInt(0)
+	}
+	genesisTime := uint64(0)
+	return []Fork{
+		{Name: "homestead", Block: big.NewInt(0)},
+		{Name: "eip150", Block: big.NewInt(0)},
+		{Name: "eip155", Block: big.NewInt(0)},
+		{Name: "eip158", Block: big.NewInt(0)},
+		{Name: "byzantium", Block: big.NewInt(0)},
+		{Name: "constantinople", Block: big.NewInt(0)},
+		{Name: "petersburg", Block: big.NewInt(0)},
+		{Name: "istanbul", Block: big.NewInt(0)},
+		{Name: "berlin", Block: big.NewInt(0)},
+		{Name: "london", Block: big.NewInt(0)},
+		eip3541,
+		{Name: "merge", Block: big.NewInt(0)},
+		{Name: "shanghai", Timestamp: &genesisTime},
+	}
+}
+
 // CheckCompatible checks whether scheduled fork transitions have been imported
 // with a mismatching chain configuration.
 func (c *ChainConfig) CheckCompatible(newcfg *ChainConfig, height uint64, time uint64) *ConfigCompatError {
@@ -176,6 +240,15 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig /*, headNumber *big.In
 	if !configBlockEqual(c.ChainID, newcfg.ChainID) {
 		return newBlockCompatError("chain ID", c.ChainID, newcfg.ChainID)
 	}
+	if c.GasLimitDivisor() != newcfg.GasLimitDivisor() {
+		return newGenesisCompatError("gas limit bound divisor", c.GasLimitDivisor(), newcfg.GasLimitDivisor())
+	}
+	if c.AllowEFCodePrefix != newcfg.AllowEFCodePrefix {
+		return newGenesisCompatError("EF code prefix allowance", c.AllowEFCodePrefix, newcfg.AllowEFCodePrefix)
+	}
+	if c.BlockHashLookback() != newcfg.BlockHashLookback() {
+		return newGenesisCompatError("block hash window", c.BlockHashLookback(), newcfg.BlockHashLookback())
+	}
 
 	return nil
 }
@@ -190,6 +263,24 @@ func (c *ChainConfig) ElasticityMultiplier() uint64 {
 	return DefaultElasticityMultiplier
 }
 
+// GasLimitDivisor returns the bound divisor of the gas limit, used to limit the
+// gas limit change between blocks.
+func (c *ChainConfig) GasLimitDivisor() uint64 {
+	if c.GasLimitBoundDivisor == 0 {
+		return GasLimitBoundDivisor
+	}
+	return c.GasLimitBoundDivisor
+}
+
+// BlockHashLookback returns the number of most recent block hashes accessible
+// by the BLOCKHASH opcode.
+func (c *ChainConfig) BlockHashLookback() uint64 {
+	if c.BlockHashWindow == 0 {
+		return BlockHashWindow
+	}
+	return c.BlockHashWindow
+}
+
 // isForkBlockIncompatible returns true if a fork scheduled at block s1 cannot be
 // rescheduled to block s2 because head is already past the fork.
 func isForkBlockIncompatible(s1, s2, head *big.Int) bool {
@@ -258,6 +349,10 @@ type ConfigCompatError struct {
 
 	// the timestamp to which the local chain must be rewound to correct the error
 	RewindToTime uint64
+
+	// whether the mismatching parameter has been in effect since genesis, in
+	// which case the error cannot be corrected by rewinding the local chain
+	Genesis bool
 }
 
 func newBlockCompatError(what string, storedblock, newblock *big.Int) *ConfigCompatError {
@@ -282,6 +377,14 @@ func newBlockCompatError(what string, storedblock, newblock *big.Int) *ConfigCom
 	return err
 }
 
+// newGenesisCompatError creates a compatibility error for a chain parameter in
+// effect since the genesis block, which no rewind can correct.
+func newGenesisCompatError(what string, storedval, newval interface{}) *ConfigCompatError {
+	err := newBlockCompatError(fmt.Sprintf("%s (have %v, want %v)", what, storedval, newval), new(big.Int), new(big.Int))
+	err.Genesis = true
+	return err
+}
+
 func newTimestampCompatError(what string, storedtime, newtime *uint64) *ConfigCompatError {
 	var rew *uint64
 	switch {
@@ -317,7 +420,10 @@ func (err *ConfigCompatError) Error() string {
 // Rules is a one time interface meaning that it shouldn't be used in between transition
 // phases.
 type Rules struct {
-	ChainID *big.Int
+	ChainID         *big.Int
+	IsShanghai      bool // Always set, Zond launched with the Shanghai ruleset
+	IsEIP3541       bool
+	BlockHashWindow uint64
 }
 
 // Rules ensures c's ChainID is not nil.
@@ -327,6 +433,9 @@ func (c *ChainConfig) Rules(num *big.Int, timestamp uint64) Rules {
 		chainID = new(big.Int)
 	}
 	return Rules{
-		ChainID: new(big.Int).Set(chainID),
+		ChainID:         new(big.Int).Set(chainID),
+		IsShanghai:      true,
+		IsEIP3541:       !c.AllowEFCodePrefix,
+		BlockHashWindow: c.BlockHashLookback(),
 	}
 }
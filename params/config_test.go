@@ -107,6 +107,54 @@ func TestCheckCompatible(t *testing.T) {
 				RewindToTime: 9,
 			},
 		},
+		{
+			stored:    &ChainConfig{GasLimitBoundDivisor: 2048},
+			new:       &ChainConfig{GasLimitBoundDivisor: 2048},
+			headBlock: 10,
+			wantErr:   nil,
+		},
+		{
+			stored:    &ChainConfig{},
+			new:       &ChainConfig{GasLimitBoundDivisor: GasLimitBoundDivisor},
+			headBlock: 10,
+			wantErr:   nil,
+		},
+		{
+			stored:    &ChainConfig{},
+			new:       &ChainConfig{GasLimitBoundDivisor: 2048},
+			headBlock: 10,
+			wantErr: &ConfigCompatError{
+				What:          "gas limit bound divisor (have 1024, want 2048)",
+				StoredBlock:   big.NewInt(0),
+				NewBlock:      big.NewInt(0),
+				RewindToBlock: 0,
+				Genesis:       true,
+			},
+		},
+		{
+			stored:    &ChainConfig{AllowEFCodePrefix: true},
+			new:       &ChainConfig{},
+			headBlock: 10,
+			wantErr: &ConfigCompatError{
+				What:          "EF code prefix allowance (have true, want false)",
+				StoredBlock:   big.NewInt(0),
+				NewBlock:      big.NewInt(0),
+				RewindToBlock: 0,
+				Genesis:       true,
+			},
+		},
+		{
+			stored:    &ChainConfig{BlockHashWindow: 8192},
+			new:       &ChainConfig{},
+			headBlock: 10,
+			wantErr: &ConfigCompatError{
+				What:          "block hash window (have 8192, want 256)",
+				StoredBlock:   big.NewInt(0),
+				NewBlock:      big.NewInt(0),
+				RewindToBlock: 0,
+				Genesis:       true,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -137,3 +185,36 @@ func TestConfigRules(t *testing.T) {
 	}
 }
 */
+
+func TestForks(t *testing.T) {
+	// Every fork of the mainnet schedule is active from genesis.
+	for _, fork := range MainnetChainConfig.Forks() {
+		if !fork.Active(big.NewInt(0), 0) {
+			t.Errorf("fork %s not active at genesis", fork.Name)
+		}
+	}
+	// Forks opted out of by the config are never active.
+	for _, fork := range (&ChainConfig{AllowEFCodePrefix: true}).Forks() {
+		if have := fork.Active(big.NewInt(1000), 1000); have != (fork.Name != "eip3541") {
+			t.Errorf("fork %s: active mismatch with EF code prefix allowed: have %v", fork.Name, have)
+		}
+	}
+	// Forks scheduled past genesis activate at their block or timestamp.
+	timestamp := uint64(100)
+	for _, tt := range []struct {
+		fork   Fork
+		number int64
+		time   uint64
+		want   bool
+	}{
+		{Fork{Name: "block", Block: big.NewInt(10)}, 9, 1000, false},
+		{Fork{Name: "block", Block: big.NewInt(10)}, 10, 0, true},
+		{Fork{Name: "time", Timestamp: &timestamp}, 1000, 99, false},
+		{Fork{Name: "time", Timestamp: &timestamp}, 0, 100, true},
+		{Fork{Name: "unscheduled"}, 1000, 1000, false},
+	} {
+		if have := tt.fork.Active(big.NewInt(tt.number), tt.time); have != tt.want {
+			t.Errorf("fork %s at block %d, time %d: active mismatch: have %v, want %v", tt.fork.Name, tt.number, tt.time, have, tt.want)
+		}
+	}
+}
@@ -19,6 +19,7 @@ package rpc
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"reflect"
 	"strconv"
 	"strings"
@@ -224,7 +225,7 @@ func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 			if msg == nil {
 				break
 			}
-			resp := h.handleCallMsg(cp, msg)
+			resp := h.handleBatchCallMsg(cp, msg)
 			callBuffer.pushResponse(resp)
 			if resp != nil && h.batchResponseMaxSize != 0 {
 				responseBytes += len(resp.Result)
@@ -261,6 +262,38 @@ func (h *handler) respondWithBatchTooLarge(cp *callProc, batch []*jsonrpcMessage
 	h.conn.writeJSON(cp.ctx, []*jsonrpcMessage{resp}, true)
 }
 
+// methodTimeout returns the request timeout of a call to the given method. The
+// per-method override applies if configured, but it never extends the timeout
+// derived from the request context.
+func (h *handler) methodTimeout(ctx context.Context, method string) (time.Duration, bool) {
+	timeout, ok := ContextRequestTimeout(ctx)
+	if override, set := h.reg.timeout(method); set && (!ok || override < timeout) {
+		return override, true
+	}
+	return timeout, ok
+}
+
+// handleBatchCallMsg handles a call which is part of a batch, cutting it off at
+// the timeout override of its method. The timeout of the batch as a whole is
+// enforced by the caller.
+func (h *handler) handleBatchCallMsg(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
+	override, ok := h.reg.timeout(msg.Method)
+	if !ok {
+		return h.handleCallMsg(cp, msg)
+	}
+	ctx, cancel := context.WithTimeout(cp.ctx, override)
+	defer cancel()
+
+	callCp := &callProc{ctx: ctx}
+	resp := h.handleCallMsg(callCp, msg)
+	cp.notifiers = append(cp.notifiers, callCp.notifiers...)
+
+	if resp != nil && cp.ctx.Err() == nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		resp = msg.errorResponse(&internalServerError{errcodeTimeout, errMsgTimeout})
+	}
+	return resp
+}
+
 // handleMsg handles a single non-batch message.
 func (h *handler) handleMsg(msg *jsonrpcMessage) {
 	msgs := []*jsonrpcMessage{msg}
@@ -283,7 +316,7 @@ func (h *handler) handleNonBatchCall(cp *callProc, msg *jsonrpcMessage) {
 	// Cancel the request context after timeout and send an error response. Since the
 	// running method might not return immediately on timeout, we must wait for the
 	// timeout concurrently with processing the request.
-	if timeout, ok := ContextRequestTimeout(cp.ctx); ok {
+	if timeout, ok := h.methodTimeout(cp.ctx, msg.Method); ok {
 		timer = time.AfterFunc(timeout, func() {
 			cancel()
 			responded.Do(func() {
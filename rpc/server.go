@@ -21,6 +21,7 @@ import (
 	"io"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/theQRL/go-zond/log"
 )
@@ -70,6 +71,17 @@ func (s *Server) SetBatchLimits(itemLimit, maxResponseSize int) {
 	s.batchResponseLimit = maxResponseSize
 }
 
+// SetMethodTimeouts sets per-method request timeouts, keyed by the full method name
+// (e.g. "zond_call"). A configured timeout applies to both single and batched calls,
+// but it never exceeds the one derived from the request context. Notably, calls over
+// HTTP are still cut off shortly before the write timeout of the HTTP server.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetMethodTimeouts(timeouts map[string]time.Duration) {
+	s.services.setTimeouts(timeouts)
+}
+
 // RegisterName creates a service for the given receiver type under the given name. When no
 // methods on the given receiver match the criteria to be either a RPC method or a
 // subscription an error is returned. Otherwise a new service is created and added to the
@@ -19,6 +19,7 @@ package rpc
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"io"
 	"net"
 	"os"
@@ -191,3 +192,51 @@ func TestServerBatchResponseSizeLimit(t *testing.T) {
 		}
 	}
 }
+
+func TestServerMethodTimeouts(t *testing.T) {
+	server := newTestServer()
+	defer server.Stop()
+	server.SetMethodTimeouts(map[string]time.Duration{"test_block": 100 * time.Millisecond})
+	client := DialInProc(server)
+	defer client.Close()
+
+	// The method with a configured timeout is cut off.
+	err := client.Call(nil, "test_block")
+	re, ok := err.(Error)
+	if !ok {
+		t.Fatalf("wrong error for timed out call: %v", err)
+	}
+	if re.ErrorCode() != errcodeTimeout {
+		t.Errorf("wrong error code, have %d want %d", re.ErrorCode(), errcodeTimeout)
+	}
+	// Methods without a configured timeout are unaffected.
+	if err := client.Call(nil, "test_sleep", 200*time.Millisecond); err != nil {
+		t.Fatalf("unexpected error for call without timeout: %v", err)
+	}
+	// The timeout also applies to calls within a batch.
+	batch := []BatchElem{
+		{Method: "test_block"},
+		{Method: "test_sleep", Args: []interface{}{10 * time.Millisecond}, Result: new(interface{})},
+	}
+	if err := client.BatchCall(batch); err != nil {
+		t.Fatalf("batch call failed: %v", err)
+	}
+	if re, ok := batch[0].Error.(Error); !ok || re.ErrorCode() != errcodeTimeout {
+		t.Errorf("wrong error for timed out batch call: %v", batch[0].Error)
+	}
+	if batch[1].Error != nil {
+		t.Errorf("unexpected error for batch call without timeout: %v", batch[1].Error)
+	}
+	// Overrides never extend the timeout of the request context.
+	server.SetMethodTimeouts(map[string]time.Duration{"test_block": time.Minute})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	h := &handler{reg: &server.services}
+	if timeout, ok := h.methodTimeout(ctx, "test_block"); !ok || timeout > time.Second {
+		t.Errorf("wrong timeout with request deadline: have %v, want at most %v", timeout, time.Second)
+	}
+	if timeout, ok := h.methodTimeout(context.Background(), "test_block"); !ok || timeout != time.Minute {
+		t.Errorf("wrong timeout without request deadline: have %v, want %v", timeout, time.Minute)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"time"
 	"unicode"
 
 	"github.com/theQRL/go-zond/log"
@@ -38,6 +39,7 @@ var (
 type serviceRegistry struct {
 	mu       sync.Mutex
 	services map[string]service
+	timeouts map[string]time.Duration // per-method request timeout overrides
 }
 
 // service represents a registered object.
@@ -57,6 +59,26 @@ type callback struct {
 	isSubscribe bool           // true if this is a subscription callback
 }
 
+// setTimeouts replaces the per-method request timeout overrides.
+func (r *serviceRegistry) setTimeouts(timeouts map[string]time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.timeouts = make(map[string]time.Duration, len(timeouts))
+	for method, timeout := range timeouts {
+		r.timeouts[method] = timeout
+	}
+}
+
+// timeout returns the request timeout override configured for the given method.
+func (r *serviceRegistry) timeout(method string) (time.Duration, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	timeout, ok := r.timeouts[method]
+	return timeout, ok
+}
+
 func (r *serviceRegistry) registerName(name string, rcvr interface{}) error {
 	rcvrVal := reflect.ValueOf(rcvr)
 	if name == "" {
@@ -18,6 +18,9 @@ package zond
 
 import (
 	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/common/hexutil"
+	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/params"
 )
 
 // ZondAPI provides an API to access Zond full node-related information.
@@ -44,3 +47,73 @@ func (api *ZondAPI) Coinbase() (common.Address, error) {
 func (api *ZondAPI) Mining() bool {
 	return api.z.IsMining()
 }
+
+// SyncStatus describes the effective synchronisation mode of the node along
+// with the progress of the running sync.
+type SyncStatus struct {
+	Mode          string          `json:"mode"`
+	SnapSyncing   bool            `json:"snapSyncing"`
+	Pivot         *hexutil.Uint64 `json:"pivot"`
+	StartingBlock hexutil.Uint64  `json:"startingBlock"`
+	CurrentBlock  hexutil.Uint64  `json:"currentBlock"`
+	HighestBlock  hexutil.Uint64  `json:"highestBlock"`
+
+	SyncedAccounts   hexutil.Uint64 `json:"syncedAccounts"`
+	SyncedBytecodes  hexutil.Uint64 `json:"syncedBytecodes"`
+	SyncedStorage    hexutil.Uint64 `json:"syncedStorage"`
+	HealedTrienodes  hexutil.Uint64 `json:"healedTrienodes"`
+	HealingTrienodes hexutil.Uint64 `json:"healingTrienodes"`
+}
+
+// SyncStatus returns the current sync mode, whether snap sync is active, the
+// snap sync pivot block (if any) and the downloader progress.
+func (api *ZondAPI) SyncStatus() *SyncStatus {
+	progress := api.z.handler.downloader.Progress()
+	status := &SyncStatus{
+		Mode:             api.z.SyncMode().String(),
+		SnapSyncing:      api.z.handler.snapSync.Load(),
+		StartingBlock:    hexutil.Uint64(progress.StartingBlock),
+		CurrentBlock:     hexutil.Uint64(progress.CurrentBlock),
+		HighestBlock:     hexutil.Uint64(progress.HighestBlock),
+		SyncedAccounts:   hexutil.Uint64(progress.SyncedAccounts),
+		SyncedBytecodes:  hexutil.Uint64(progress.SyncedBytecodes),
+		SyncedStorage:    hexutil.Uint64(progress.SyncedStorage),
+		HealedTrienodes:  hexutil.Uint64(progress.HealedTrienodes),
+		HealingTrienodes: hexutil.Uint64(progress.HealingTrienodes),
+	}
+	// Prefer the live pivot of a running sync over the last committed one
+	if pivot := api.z.handler.downloader.Pivot(); pivot != nil {
+		number := hexutil.Uint64(pivot.Number.Uint64())
+		status.Pivot = &number
+	} else if pivot := rawdb.ReadLastPivotNumber(api.z.chainDb); pivot != nil {
+		number := hexutil.Uint64(*pivot)
+		status.Pivot = &number
+	}
+	return status
+}
+
+// NetworkInfo describes the network the node is configured to join.
+type NetworkInfo struct {
+	NetworkID   hexutil.Uint64      `json:"networkId"`
+	ChainID     *hexutil.Big        `json:"chainId"`
+	GenesisHash common.Hash         `json:"genesisHash"`
+	Config      *params.ChainConfig `json:"config"`
+}
+
+// NetworkInfo returns the network ID, chain ID, genesis hash and chain config of
+// the node in a single call, to simplify bootstrapping clients.
+func (api *ZondAPI) NetworkInfo() *NetworkInfo {
+	config := api.z.blockchain.Config()
+	return &NetworkInfo{
+		NetworkID:   hexutil.Uint64(api.z.networkID),
+		ChainID:     (*hexutil.Big)(config.ChainID),
+		GenesisHash: api.z.blockchain.Genesis().Hash(),
+		Config:      config,
+	}
+}
+
+// SnapshotEnabled reports whether the state snapshot is in use, allowing the
+// operators to confirm the effective setting after cache adjustments.
+func (api *ZondAPI) SnapshotEnabled() bool {
+	return api.z.blockchain.Snapshots() != nil
+}
@@ -23,9 +23,13 @@ import (
 	"io"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/core"
 	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/p2p"
+	"github.com/theQRL/go-zond/params"
 	"github.com/theQRL/go-zond/rlp"
 )
 
@@ -141,3 +145,36 @@ func (api *AdminAPI) ImportChain(file string) (bool, error) {
 	}
 	return true, nil
 }
+
+// NodeInfoExtended is the node info augmented with the version and lifetime of
+// the running Zond service.
+type NodeInfoExtended struct {
+	*p2p.NodeInfo
+	Version   string    `json:"version"`
+	StartTime time.Time `json:"startTime"`
+	Uptime    float64   `json:"uptime"` // Seconds elapsed since the start time
+}
+
+// NodeInfoExtended retrieves the node info along with the client version, the
+// time the service was started at and its uptime.
+func (api *AdminAPI) NodeInfoExtended() *NodeInfoExtended {
+	return &NodeInfoExtended{
+		NodeInfo:  api.zond.p2pServer.NodeInfo(),
+		Version:   params.VersionWithMeta,
+		StartTime: api.zond.startTime,
+		Uptime:    time.Since(api.zond.startTime).Seconds(),
+	}
+}
+
+// AddLocal marks the given account as local in the transaction pool, exempting
+// its transactions from pricing constraints and eviction.
+func (api *AdminAPI) AddLocal(addr common.Address) bool {
+	api.zond.TxPool().AddLocal(addr)
+	return true
+}
+
+// RemoveLocal stops treating the given account as local in the transaction pool.
+func (api *AdminAPI) RemoveLocal(addr common.Address) bool {
+	api.zond.TxPool().RemoveLocal(addr)
+	return true
+}
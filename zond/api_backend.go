@@ -277,6 +277,10 @@ func (b *ZondAPIBackend) SendTx(ctx context.Context, signedTx *types.Transaction
 	return b.zond.txPool.Add([]*types.Transaction{signedTx}, true, false)[0]
 }
 
+func (b *ZondAPIBackend) ValidateTx(ctx context.Context, signedTx *types.Transaction) error {
+	return b.zond.txPool.Validate(signedTx, true)
+}
+
 func (b *ZondAPIBackend) GetPoolTransactions() (types.Transactions, error) {
 	pending := b.zond.txPool.Pending(false)
 	var txs types.Transactions
@@ -315,6 +319,22 @@ func (b *ZondAPIBackend) TxPoolContentFrom(addr common.Address) ([]*types.Transa
 	return b.zond.txPool.ContentFrom(addr)
 }
 
+func (b *ZondAPIBackend) TxPoolPending(enforceTips bool) map[common.Address][]*txpool.LazyTransaction {
+	return b.zond.txPool.Pending(enforceTips)
+}
+
+func (b *ZondAPIBackend) TxPoolLocals() []common.Address {
+	return b.zond.txPool.Locals()
+}
+
+func (b *ZondAPIBackend) TxPoolRecentlyDropped(limit int) []*txpool.DroppedTx {
+	return b.zond.txPool.RecentlyDropped(limit)
+}
+
+func (b *ZondAPIBackend) TxPoolReplacementHistory(addr common.Address, nonce uint64) []*txpool.ReplacedTx {
+	return b.zond.txPool.ReplacementHistory(addr, nonce)
+}
+
 func (b *ZondAPIBackend) TxPool() *txpool.TxPool {
 	return b.zond.txPool
 }
@@ -331,6 +351,10 @@ func (b *ZondAPIBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error)
 	return b.gpo.SuggestTipCap(ctx)
 }
 
+func (b *ZondAPIBackend) SuggestGasTipCapAtPercentile(ctx context.Context, percentile int) (*big.Int, error) {
+	return b.gpo.SuggestTipCapAtPercentile(ctx, percentile)
+}
+
 func (b *ZondAPIBackend) FeeHistory(ctx context.Context, blockCount uint64, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (firstBlock *big.Int, reward [][]*big.Int, baseFee []*big.Int, gasUsedRatio []float64, err error) {
 	return b.gpo.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
 }
@@ -355,6 +379,10 @@ func (b *ZondAPIBackend) RPCGasCap() uint64 {
 	return b.zond.config.RPCGasCap
 }
 
+func (b *ZondAPIBackend) RPCTraceGasCap() uint64 {
+	return b.zond.config.RPCTraceGasCap
+}
+
 func (b *ZondAPIBackend) RPCEVMTimeout() time.Duration {
 	return b.zond.config.RPCEVMTimeout
 }
@@ -363,6 +391,14 @@ func (b *ZondAPIBackend) RPCTxFeeCap() float64 {
 	return b.zond.config.RPCTxFeeCap
 }
 
+func (b *ZondAPIBackend) RPCMaxTxSize() uint64 {
+	return b.zond.config.RPCMaxTxSize
+}
+
+func (b *ZondAPIBackend) TxPoolMaxFutureNonce() uint64 {
+	return b.zond.config.TxPoolMaxFutureNonce
+}
+
 func (b *ZondAPIBackend) BloomStatus() (uint64, uint64) {
 	sections, _, _ := b.zond.bloomIndexer.Sections()
 	return params.BloomBitsBlocks, sections
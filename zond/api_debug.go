@@ -20,12 +20,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/common/hexutil"
+	"github.com/theQRL/go-zond/common/lru"
+	"github.com/theQRL/go-zond/core"
 	"github.com/theQRL/go-zond/core/rawdb"
 	"github.com/theQRL/go-zond/core/state"
+	"github.com/theQRL/go-zond/core/state/snapshot"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/crypto"
 	"github.com/theQRL/go-zond/internal/zondapi"
@@ -39,11 +45,16 @@ import (
 // protocol.
 type DebugAPI struct {
 	zond *Zond
+
+	accountCounts *lru.Cache[common.Hash, uint64] // Account counts of recently queried state roots
 }
 
 // NewDebugAPI creates a new DebugAPI instance.
 func NewDebugAPI(zond *Zond) *DebugAPI {
-	return &DebugAPI{zond: zond}
+	return &DebugAPI{
+		zond:          zond,
+		accountCounts: lru.NewCache[common.Hash, uint64](accountCountCacheSize),
+	}
 }
 
 // DumpBlock retrieves the entire state of the database at a given block.
@@ -443,3 +454,248 @@ func (api *DebugAPI) GetTrieFlushInterval() (string, error) {
 	}
 	return api.zond.blockchain.GetTrieFlushInterval().String(), nil
 }
+
+// SimulateReorg reorganises the chain onto the given known side chain block,
+// exercising the reorg path without going through the engine API. It is only
+// available on dev mode chains.
+func (api *DebugAPI) SimulateReorg(hash common.Hash) error {
+	if !api.zond.blockchain.Config().IsDevMode {
+		return errors.New("reorg simulation is only available in dev mode")
+	}
+	block := api.zond.blockchain.GetBlockByHash(hash)
+	if block == nil {
+		return fmt.Errorf("block %#x not found", hash)
+	}
+	if rawdb.ReadCanonicalHash(api.zond.chainDb, block.NumberU64()) == hash {
+		return fmt.Errorf("block %#x is already canonical", hash)
+	}
+	_, err := api.zond.blockchain.SetCanonical(block)
+	return err
+}
+
+// ExportFlatState writes the accounts and storage slots of the state at the
+// given block into file as a stream of length-prefixed key/value records, as
+// produced by the snapshot iterators. The output can be re-imported with
+// snapshot.ImportFlat, which also recomputes and verifies the state root.
+func (api *DebugAPI) ExportFlatState(blockNrOrHash rpc.BlockNumberOrHash, file string) (bool, error) {
+	snaps := api.zond.blockchain.Snapshots()
+	if snaps == nil {
+		return false, errors.New("state snapshots are disabled")
+	}
+	header, err := api.headerByNumberOrHash(blockNrOrHash)
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(file); err == nil {
+		// File already exists. Allowing overwrite could be a DoS vector,
+		// since the 'file' may point to arbitrary paths on the drive.
+		return false, errors.New("location would overwrite an existing file")
+	}
+	out, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return false, err
+	}
+	defer out.Close()
+
+	start := time.Now()
+	accounts, slots, err := snapshot.ExportFlat(snaps, header.Root, out)
+	if err != nil {
+		return false, err
+	}
+	log.Info("Exported flat state", "number", header.Number, "root", header.Root, "accounts", accounts, "slots", slots, "elapsed", common.PrettyDuration(time.Since(start)))
+	return true, nil
+}
+
+// accountCountCacheSize is the number of state roots whose account count is
+// retained by debug_accountCount.
+const accountCountCacheSize = 16
+
+// AccountCount returns the number of accounts in the state at the given block.
+// The accounts are counted from the snapshot if it covers the state, falling
+// back to iterating the account trie otherwise. Results are cached per state
+// root, as a full count visits every account.
+func (api *DebugAPI) AccountCount(blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Uint64, error) {
+	header, err := api.headerByNumberOrHash(blockNrOrHash)
+	if err != nil {
+		return 0, err
+	}
+	if count, ok := api.accountCounts.Get(header.Root); ok {
+		return hexutil.Uint64(count), nil
+	}
+	count, err := api.countAccounts(header.Root)
+	if err != nil {
+		return 0, err
+	}
+	api.accountCounts.Add(header.Root, count)
+	return hexutil.Uint64(count), nil
+}
+
+// countAccounts counts the accounts in the state with the given root.
+func (api *DebugAPI) countAccounts(root common.Hash) (uint64, error) {
+	if snaps := api.zond.blockchain.Snapshots(); snaps != nil {
+		if it, err := snaps.AccountIterator(root, common.Hash{}); err == nil {
+			defer it.Release()
+
+			var count uint64
+			for it.Next() {
+				count++
+			}
+			return count, it.Error()
+		}
+	}
+	tr, err := api.zond.blockchain.StateCache().OpenTrie(root)
+	if err != nil {
+		return 0, err
+	}
+	nodeIt, err := tr.NodeIterator(nil)
+	if err != nil {
+		return 0, err
+	}
+	var (
+		it    = trie.NewIterator(nodeIt)
+		count uint64
+	)
+	for it.Next() {
+		count++
+	}
+	return count, it.Err
+}
+
+// GenesisAlloc returns the account allocations of the genesis block, as stored
+// in the database when the chain was initialized.
+func (api *DebugAPI) GenesisAlloc() (core.GenesisAlloc, error) {
+	genesis, err := core.ReadGenesis(api.zond.chainDb)
+	if err != nil {
+		return nil, err
+	}
+	return genesis.Alloc, nil
+}
+
+// dbStatsKeySamples is the number of database entries sampled by debug_dbStats
+// to estimate the total number of keys.
+const dbStatsKeySamples = 10000
+
+// DBStats contains the engine and size information of the chain database.
+type DBStats struct {
+	Engine      string         `json:"engine"`
+	Size        hexutil.Uint64 `json:"size"`
+	Keys        hexutil.Uint64 `json:"keys"`
+	FreezerSize hexutil.Uint64 `json:"freezerSize"`
+}
+
+// DBStats returns the engine backing the chain database along with its size on
+// disk, an estimate of the number of keys stored and the size of the freezer.
+// The key count is exact for small databases, larger ones are extrapolated from
+// the average entry size of a sample. Note, the extrapolation divides the
+// compressed on-disk size by raw key and value sizes, so it is only a rough
+// estimate, usually undercounting the keys of a well compressed database.
+func (api *DebugAPI) DBStats() (*DBStats, error) {
+	db := api.zond.chainDb
+
+	engine := rawdb.PreexistingDatabase(api.zond.chainDbPath)
+	if engine == "" {
+		engine = "memorydb"
+	}
+	stats := &DBStats{Engine: engine}
+
+	var freezerDir string
+	if dir, err := db.AncientDatadir(); err == nil && dir != "" {
+		freezerDir = filepath.Clean(dir)
+		size, err := dirSize(freezerDir, "")
+		if err != nil {
+			return nil, err
+		}
+		stats.FreezerSize = hexutil.Uint64(size)
+	}
+	if api.zond.chainDbPath != "" && engine != "memorydb" {
+		size, err := dirSize(api.zond.chainDbPath, freezerDir)
+		if err != nil {
+			return nil, err
+		}
+		stats.Size = hexutil.Uint64(size)
+	}
+	// Count the keys of a sample, extrapolating to the whole database if the
+	// sample does not cover it.
+	var (
+		it      = db.NewIterator(nil, nil)
+		keys    uint64
+		sampled uint64
+	)
+	defer it.Release()
+
+	for keys < dbStatsKeySamples && it.Next() {
+		keys++
+		sampled += uint64(len(it.Key()) + len(it.Value()))
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	if keys == dbStatsKeySamples && it.Next() && sampled > 0 && uint64(stats.Size) > sampled {
+		keys = uint64(stats.Size) * keys / sampled
+	}
+	stats.Keys = hexutil.Uint64(keys)
+	return stats, nil
+}
+
+// dirSize returns the total size of the files in the given directory and its
+// subdirectories, leaving out the excluded directory if any. Files vanishing
+// during the walk, e.g. due to a concurrent compaction, are skipped.
+func dirSize(dir string, exclude string) (uint64, error) {
+	var size uint64
+	err := filepath.WalkDir(filepath.Clean(dir), func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path == exclude {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		info, err := d.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		size += uint64(info.Size())
+		return nil
+	})
+	return size, err
+}
+
+// headerByNumberOrHash resolves a block number or hash into a header of a
+// block known to the local chain. The pending block is not supported.
+func (api *DebugAPI) headerByNumberOrHash(blockNrOrHash rpc.BlockNumberOrHash) (*types.Header, error) {
+	if number, ok := blockNrOrHash.Number(); ok {
+		var header *types.Header
+		switch number {
+		case rpc.PendingBlockNumber:
+			return nil, errors.New("pending block is not supported")
+		case rpc.LatestBlockNumber:
+			header = api.zond.blockchain.CurrentBlock()
+		case rpc.FinalizedBlockNumber:
+			header = api.zond.blockchain.CurrentFinalBlock()
+		case rpc.SafeBlockNumber:
+			header = api.zond.blockchain.CurrentSafeBlock()
+		default:
+			header = api.zond.blockchain.GetHeaderByNumber(uint64(number))
+		}
+		if header == nil {
+			return nil, fmt.Errorf("block #%d not found", number)
+		}
+		return header, nil
+	}
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		header := api.zond.blockchain.GetHeaderByHash(hash)
+		if header == nil {
+			return nil, fmt.Errorf("block %s not found", hash.Hex())
+		}
+		return header, nil
+	}
+	return nil, errors.New("either block number or block hash must be specified")
+}
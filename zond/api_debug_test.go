@@ -20,15 +20,25 @@ import (
 	"bytes"
 	"fmt"
 	"math/big"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/consensus/beacon"
+	"github.com/theQRL/go-zond/core"
 	"github.com/theQRL/go-zond/core/rawdb"
 	"github.com/theQRL/go-zond/core/state"
+	"github.com/theQRL/go-zond/core/state/snapshot"
 	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/core/vm"
 	"github.com/theQRL/go-zond/crypto"
+	"github.com/theQRL/go-zond/crypto/pqcrypto"
+	"github.com/theQRL/go-zond/params"
+	"github.com/theQRL/go-zond/rpc"
 	"github.com/theQRL/go-zond/trie"
 	"golang.org/x/exp/slices"
 )
@@ -219,3 +229,292 @@ func TestStorageRangeAt(t *testing.T) {
 		}
 	}
 }
+
+func TestSimulateReorg(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _ = pqcrypto.HexToDilithium("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr   = key.GetAddress()
+		config = *params.AllDevChainProtocolChanges
+		gspec  = &core.Genesis{Config: &config, Alloc: core.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}}}
+		signer = types.LatestSigner(gspec.Config)
+		engine = beacon.NewFaker()
+
+		// Init code emitting a single empty log
+		logCode = []byte{byte(vm.PUSH1), 0x0, byte(vm.PUSH1), 0x0, byte(vm.LOG0)}
+	)
+	// makeChain generates a chain with the given number of logs in each block
+	makeChain := func(logs []int) []*types.Block {
+		_, blocks, _ := core.GenerateChainWithGenesis(gspec, engine, len(logs), func(i int, gen *core.BlockGen) {
+			for j := 0; j < logs[i]; j++ {
+				tx, err := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+					Nonce:     gen.TxNonce(addr),
+					Gas:       100000,
+					GasFeeCap: gen.BaseFee(),
+					Data:      logCode,
+				})
+				if err != nil {
+					t.Fatalf("failed to create tx: %v", err)
+				}
+				gen.AddTx(tx)
+			}
+		})
+		return blocks
+	}
+	var (
+		canonical = makeChain([]int{1, 2, 0})
+		side      = makeChain([]int{3, 0, 1, 1})
+	)
+	db := rawdb.NewMemoryDatabase()
+	chain, err := core.NewBlockChain(db, nil, gspec, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create blockchain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(canonical); err != nil {
+		t.Fatalf("failed to insert canonical chain: %v", err)
+	}
+	for _, block := range side {
+		if err := chain.InsertBlockWithoutSetHead(block); err != nil {
+			t.Fatalf("failed to insert side block %d: %v", block.NumberU64(), err)
+		}
+	}
+	logsCh := make(chan []*types.Log, 10)
+	rmLogsCh := make(chan core.RemovedLogsEvent, 10)
+	chain.SubscribeLogsEvent(logsCh)
+	chain.SubscribeRemovedLogsEvent(rmLogsCh)
+
+	api := NewDebugAPI(&Zond{blockchain: chain, chainDb: db})
+	if err := api.SimulateReorg(common.Hash{0x01}); err == nil {
+		t.Fatal("expected error reorging to unknown block")
+	}
+	if err := api.SimulateReorg(canonical[1].Hash()); err == nil {
+		t.Fatal("expected error reorging to canonical block")
+	}
+	head := side[len(side)-1]
+	if err := api.SimulateReorg(head.Hash()); err != nil {
+		t.Fatalf("failed to simulate reorg: %v", err)
+	}
+	if have := chain.CurrentBlock().Hash(); have != head.Hash() {
+		t.Fatalf("head mismatch: have %x, want %x", have, head.Hash())
+	}
+	// The logs of the old chain are removed, the side chain logs added
+	var added, removed int
+	for timeout := time.After(time.Second); added < 5 || removed < 3; {
+		select {
+		case logs := <-logsCh:
+			added += len(logs)
+		case ev := <-rmLogsCh:
+			removed += len(ev.Logs)
+		case <-timeout:
+			t.Fatalf("missing log events: added %d, removed %d", added, removed)
+		}
+	}
+	if added != 5 || removed != 3 {
+		t.Fatalf("log event mismatch: added %d, want 5, removed %d, want 3", added, removed)
+	}
+	// Reorg simulation is not allowed outside of dev mode
+	chain.Config().IsDevMode = false
+	if err := api.SimulateReorg(canonical[len(canonical)-1].Hash()); err == nil {
+		t.Fatal("expected error outside of dev mode")
+	}
+}
+
+func TestExportFlatState(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _   = pqcrypto.HexToDilithium("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr     = key.GetAddress()
+		contract = common.Address{0xc0, 0xde}
+		gspec    = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: core.GenesisAlloc{
+				addr: {Balance: big.NewInt(params.Ether)},
+				contract: {
+					Balance: big.NewInt(1),
+					Code:    []byte{byte(vm.PUSH1), 0x1, byte(vm.PUSH1), 0x1, byte(vm.SLOAD), byte(vm.ADD), byte(vm.PUSH1), 0x1, byte(vm.SSTORE)},
+					Storage: map[common.Hash]common.Hash{{0x1}: {0x1}, {0x2}: {0x2}},
+				},
+			},
+		}
+		signer = types.LatestSigner(gspec.Config)
+		engine = beacon.NewFaker()
+	)
+	// Touch the contract storage in every block so the head state lives in the
+	// snapshot diff layers
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, engine, 2, func(i int, gen *core.BlockGen) {
+		tx, err := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+			Nonce:     gen.TxNonce(addr),
+			To:        &contract,
+			Gas:       100000,
+			GasFeeCap: gen.BaseFee(),
+		})
+		if err != nil {
+			t.Fatalf("failed to create tx: %v", err)
+		}
+		gen.AddTx(tx)
+	})
+	db := rawdb.NewMemoryDatabase()
+	chain, err := core.NewBlockChain(db, nil, gspec, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create blockchain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	api := NewDebugAPI(&Zond{blockchain: chain, chainDb: db})
+
+	for _, block := range []*types.Block{chain.Genesis(), blocks[len(blocks)-1]} {
+		file := fmt.Sprintf("%s/state-%d.flat", t.TempDir(), block.NumberU64())
+		if _, err := api.ExportFlatState(rpc.BlockNumberOrHashWithHash(block.Hash(), false), file); err != nil {
+			t.Fatalf("block %d: failed to export flat state: %v", block.NumberU64(), err)
+		}
+		in, err := os.Open(file)
+		if err != nil {
+			t.Fatalf("block %d: failed to open export: %v", block.NumberU64(), err)
+		}
+		root, err := snapshot.ImportFlat(in, rawdb.NewMemoryDatabase())
+		in.Close()
+		if err != nil {
+			t.Fatalf("block %d: failed to import flat state: %v", block.NumberU64(), err)
+		}
+		if root != block.Root() {
+			t.Fatalf("block %d: state root mismatch: have %x, want %x", block.NumberU64(), root, block.Root())
+		}
+		if _, err := api.ExportFlatState(rpc.BlockNumberOrHashWithHash(block.Hash(), false), file); err == nil {
+			t.Fatalf("block %d: expected error overwriting existing export", block.NumberU64())
+		}
+	}
+}
+
+func TestAccountCount(t *testing.T) {
+	t.Parallel()
+
+	alloc := make(core.GenesisAlloc)
+	for i := 0; i < 10; i++ {
+		alloc[common.Address{byte(i + 1)}] = core.GenesisAccount{Balance: big.NewInt(int64(i + 1))}
+	}
+	alloc[common.Address{0xc0, 0xde}] = core.GenesisAccount{
+		Balance: new(big.Int),
+		Code:    []byte{byte(vm.STOP)},
+		Storage: map[common.Hash]common.Hash{{0x1}: {0x1}},
+	}
+	gspec := &core.Genesis{Config: params.TestChainConfig, Alloc: alloc}
+
+	// Count both from the snapshot and, with snapshots disabled, from the trie
+	for _, snapshots := range []bool{true, false} {
+		cacheConfig := core.DefaultCacheConfigWithScheme(rawdb.HashScheme)
+		if !snapshots {
+			cacheConfig.SnapshotLimit = 0
+		}
+		db := rawdb.NewMemoryDatabase()
+		chain, err := core.NewBlockChain(db, cacheConfig, gspec, beacon.NewFaker(), vm.Config{}, nil, nil)
+		if err != nil {
+			t.Fatalf("snapshots %v: failed to create blockchain: %v", snapshots, err)
+		}
+		api := NewDebugAPI(&Zond{blockchain: chain, chainDb: db})
+
+		// Query twice, the second answer is served from the cache
+		for i := 0; i < 2; i++ {
+			count, err := api.AccountCount(rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber))
+			if err != nil {
+				t.Fatalf("snapshots %v: failed to count accounts: %v", snapshots, err)
+			}
+			if int(count) != len(alloc) {
+				t.Fatalf("snapshots %v: account count mismatch: have %d, want %d", snapshots, count, len(alloc))
+			}
+		}
+		if _, err := api.AccountCount(rpc.BlockNumberOrHashWithNumber(1)); err == nil {
+			t.Fatalf("snapshots %v: expected error for unknown block", snapshots)
+		}
+		chain.Stop()
+	}
+}
+
+func TestGenesisAlloc(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _ = pqcrypto.HexToDilithium("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		dev    = key.GetAddress()
+		gspec  = core.DeveloperGenesisBlock(11_500_000, dev)
+		db     = rawdb.NewMemoryDatabase()
+	)
+	chain, err := core.NewBlockChain(db, nil, gspec, beacon.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create blockchain: %v", err)
+	}
+	defer chain.Stop()
+
+	alloc, err := NewDebugAPI(&Zond{blockchain: chain, chainDb: db}).GenesisAlloc()
+	if err != nil {
+		t.Fatalf("failed to retrieve genesis alloc: %v", err)
+	}
+	account, ok := alloc[dev]
+	if !ok {
+		t.Fatalf("developer account %v missing from genesis alloc", dev)
+	}
+	if want := gspec.Alloc[dev].Balance; account.Balance.Cmp(want) != 0 {
+		t.Fatalf("developer balance mismatch: have %v, want %v", account.Balance, want)
+	}
+	if len(alloc) != len(gspec.Alloc) {
+		t.Fatalf("genesis alloc size mismatch: have %d, want %d", len(alloc), len(gspec.Alloc))
+	}
+}
+
+func TestDBStats(t *testing.T) {
+	t.Parallel()
+
+	engines := []string{"leveldb"}
+	if rawdb.PebbleEnabled {
+		engines = append(engines, "pebble")
+	}
+	for _, engine := range engines {
+		dir := t.TempDir()
+		db, err := rawdb.Open(rawdb.OpenOptions{
+			Type:              engine,
+			Directory:         dir,
+			AncientsDirectory: filepath.Join(dir, "ancient"),
+			Ephemeral:         true,
+		})
+		if err != nil {
+			t.Fatalf("%s: failed to open database: %v", engine, err)
+		}
+		for i := 0; i < 100; i++ {
+			if err := db.Put([]byte(fmt.Sprintf("key-%d", i)), []byte{byte(i)}); err != nil {
+				t.Fatalf("%s: failed to write key: %v", engine, err)
+			}
+		}
+		stats, err := NewDebugAPI(&Zond{chainDb: db, chainDbPath: dir}).DBStats()
+		db.Close()
+		if err != nil {
+			t.Fatalf("%s: failed to retrieve database stats: %v", engine, err)
+		}
+		if stats.Engine != engine {
+			t.Errorf("engine mismatch: have %s, want %s", stats.Engine, engine)
+		}
+		if stats.Keys < 100 {
+			t.Errorf("%s: key count too low: have %d, want at least 100", engine, stats.Keys)
+		}
+		if stats.Size == 0 {
+			t.Errorf("%s: database size not reported", engine)
+		}
+	}
+	// In-memory databases have no size on disk.
+	stats, err := NewDebugAPI(&Zond{chainDb: rawdb.NewMemoryDatabase()}).DBStats()
+	if err != nil {
+		t.Fatalf("failed to retrieve memory database stats: %v", err)
+	}
+	if stats.Engine != "memorydb" || stats.Size != 0 || stats.FreezerSize != 0 {
+		t.Errorf("memory database stats mismatch: %+v", stats)
+	}
+	// Vanished directories are skipped rather than failing the stats.
+	if size, err := dirSize(filepath.Join(t.TempDir(), "missing"), ""); err != nil || size != 0 {
+		t.Errorf("missing directory size mismatch: have %d, %v, want 0, nil", size, err)
+	}
+}
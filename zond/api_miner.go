@@ -22,6 +22,7 @@ import (
 
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/common/hexutil"
+	"github.com/theQRL/go-zond/miner"
 )
 
 // MinerAPI provides an API to control the miner.
@@ -64,6 +65,18 @@ func (api *MinerAPI) SetEtherbase(etherbase common.Address) bool {
 	return true
 }
 
+// GetFeeRecipient returns the fee recipient currently configured for the
+// pending block.
+func (api *MinerAPI) GetFeeRecipient() common.Address {
+	return api.z.Miner().Etherbase()
+}
+
+// Status returns whether the miner is building blocks, along with the number,
+// transaction count and gas used of the pending block and the fee recipient.
+func (api *MinerAPI) Status() *miner.Status {
+	return api.z.Miner().Status()
+}
+
 // SetRecommitInterval updates the interval for miner sealing work recommitting.
 func (api *MinerAPI) SetRecommitInterval(interval int) {
 	api.z.Miner().SetRecommitInterval(time.Duration(interval) * time.Millisecond)
@@ -0,0 +1,142 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package zond
+
+import (
+	"testing"
+	"time"
+
+	"github.com/theQRL/go-zond/consensus/beacon"
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/vm"
+	"github.com/theQRL/go-zond/crypto"
+	"github.com/theQRL/go-zond/p2p"
+	"github.com/theQRL/go-zond/params"
+)
+
+// Tests that the sync status reports snap sync along with the stored pivot
+// while a node without any blocks is snap syncing.
+func TestSyncStatus(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestHandler()
+	defer handler.close()
+
+	rawdb.WriteLastPivotNumber(handler.db, 64)
+	zond := &Zond{
+		handler:    handler.handler,
+		blockchain: handler.chain,
+		chainDb:    handler.db,
+	}
+	status := NewZondAPI(zond).SyncStatus()
+	if status.Mode != "snap" {
+		t.Errorf("sync mode mismatch: have %s, want snap", status.Mode)
+	}
+	if !status.SnapSyncing {
+		t.Error("snap sync not reported as active")
+	}
+	if status.Pivot == nil || uint64(*status.Pivot) != 64 {
+		t.Errorf("pivot mismatch: have %v, want 64", status.Pivot)
+	}
+	if status.CurrentBlock != 0 {
+		t.Errorf("current block mismatch: have %d, want 0", status.CurrentBlock)
+	}
+}
+
+// Tests that the network info reports the identifiers of the betanet preset.
+func TestNetworkInfo(t *testing.T) {
+	t.Parallel()
+
+	db := rawdb.NewMemoryDatabase()
+	chain, err := core.NewBlockChain(db, nil, core.DefaultBetaNetGenesisBlock(), beacon.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create betanet chain: %v", err)
+	}
+	defer chain.Stop()
+
+	zond := &Zond{
+		networkID:  params.BetaNetNetworkID,
+		blockchain: chain,
+		chainDb:    db,
+	}
+	info := NewZondAPI(zond).NetworkInfo()
+	if uint64(info.NetworkID) != params.BetaNetNetworkID {
+		t.Errorf("network ID mismatch: have %d, want %d", info.NetworkID, params.BetaNetNetworkID)
+	}
+	if info.ChainID.ToInt().Cmp(params.BetaNetChainConfig.ChainID) != 0 {
+		t.Errorf("chain ID mismatch: have %v, want %v", info.ChainID, params.BetaNetChainConfig.ChainID)
+	}
+	if info.GenesisHash != params.BetaNetGenesisHash {
+		t.Errorf("genesis hash mismatch: have %x, want %x", info.GenesisHash, params.BetaNetGenesisHash)
+	}
+	if info.Config != chain.Config() {
+		t.Errorf("chain config mismatch: have %v, want %v", info.Config, chain.Config())
+	}
+}
+
+// Tests that the snapshot status reflects whether the chain maintains snapshots.
+func TestSnapshotEnabled(t *testing.T) {
+	t.Parallel()
+
+	for _, enabled := range []bool{true, false} {
+		cacheConfig := *core.DefaultCacheConfigWithScheme(rawdb.HashScheme)
+		if !enabled {
+			cacheConfig.SnapshotLimit = 0
+		}
+		chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), &cacheConfig, core.DefaultBetaNetGenesisBlock(), beacon.NewFaker(), vm.Config{}, nil, nil)
+		if err != nil {
+			t.Fatalf("failed to create chain: %v", err)
+		}
+		if have := NewZondAPI(&Zond{blockchain: chain}).SnapshotEnabled(); have != enabled {
+			t.Errorf("snapshot status mismatch: have %v, want %v", have, enabled)
+		}
+		chain.Stop()
+	}
+}
+
+// Tests that the extended node info reports the version and a growing uptime.
+func TestNodeInfoExtended(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate node key: %v", err)
+	}
+	zond := &Zond{
+		p2pServer: &p2p.Server{Config: p2p.Config{PrivateKey: key, Name: "test"}},
+		startTime: time.Now(),
+	}
+	api := NewAdminAPI(zond)
+
+	first := api.NodeInfoExtended()
+	time.Sleep(10 * time.Millisecond)
+	second := api.NodeInfoExtended()
+
+	if first.Name != "test" {
+		t.Errorf("node name mismatch: have %s, want test", first.Name)
+	}
+	if first.Version != params.VersionWithMeta {
+		t.Errorf("version mismatch: have %s, want %s", first.Version, params.VersionWithMeta)
+	}
+	if !first.StartTime.Equal(zond.startTime) || !second.StartTime.Equal(zond.startTime) {
+		t.Errorf("start time mismatch: have %v and %v, want %v", first.StartTime, second.StartTime, zond.startTime)
+	}
+	if second.Uptime <= first.Uptime {
+		t.Errorf("uptime did not increase: first %v, second %v", first.Uptime, second.Uptime)
+	}
+}
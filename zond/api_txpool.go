@@ -0,0 +1,37 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package zond
+
+import (
+	"github.com/theQRL/go-zond/common"
+)
+
+// TxPoolLocalsAPI provides a read-only API to inspect the set of accounts the
+// transaction pool treats as local. The set is adjusted via the admin API.
+type TxPoolLocalsAPI struct {
+	zond *Zond
+}
+
+// NewTxPoolLocalsAPI creates a new instance of TxPoolLocalsAPI.
+func NewTxPoolLocalsAPI(zond *Zond) *TxPoolLocalsAPI {
+	return &TxPoolLocalsAPI{zond: zond}
+}
+
+// Locals returns the accounts currently considered local by the pool.
+func (api *TxPoolLocalsAPI) Locals() []common.Address {
+	return api.zond.TxPool().Locals()
+}
@@ -23,6 +23,7 @@ import (
 	"math/big"
 	"runtime"
 	"sync"
+	"time"
 
 	"github.com/theQRL/go-zond/accounts"
 	"github.com/theQRL/go-zond/common"
@@ -69,7 +70,8 @@ type Zond struct {
 	snapDialCandidates enode.Iterator
 
 	// DB interfaces
-	chainDb zonddb.Database // Block chain database
+	chainDb     zonddb.Database // Block chain database
+	chainDbPath string          // Directory of the chain database, empty if held in memory
 
 	eventMux       *event.TypeMux
 	engine         consensus.Engine
@@ -93,6 +95,8 @@ type Zond struct {
 	lock sync.RWMutex // Protects the variadic fields (e.g. gas price and etherbase)
 
 	shutdownTracker *shutdowncheck.ShutdownTracker // Tracks if and when the node has shutdown ungracefully
+
+	startTime time.Time // Time the service was created at, immutable afterwards
 }
 
 // New creates a new Zond object (including the
@@ -118,7 +122,7 @@ func New(stack *node.Node, config *zondconfig.Config) (*Zond, error) {
 	log.Info("Allocated trie memory caches", "clean", common.StorageSize(config.TrieCleanCache)*1024*1024, "dirty", common.StorageSize(config.TrieDirtyCache)*1024*1024)
 
 	// Assemble the Zond object
-	chainDb, err := stack.OpenDatabaseWithFreezer("chaindata", config.DatabaseCache, config.DatabaseHandles, config.DatabaseFreezer, "eth/db/chaindata/", false)
+	chainDb, err := stack.OpenDatabaseWithFreezer("chaindata", config.DatabaseCache, config.DatabaseHandles, config.DatabaseFreezer, config.DatabaseFreezerThreshold, "eth/db/chaindata/", false)
 	if err != nil {
 		return nil, err
 	}
@@ -133,13 +137,14 @@ func New(stack *node.Node, config *zondconfig.Config) (*Zond, error) {
 	if err != nil {
 		return nil, err
 	}
-	engine, err := zondconfig.CreateConsensusEngine(chainConfig, chainDb)
+	engine, err := zondconfig.CreateConsensusEngine(config.ConsensusEngine, chainConfig, chainDb)
 	if err != nil {
 		return nil, err
 	}
 	zond := &Zond{
 		config:            config,
 		chainDb:           chainDb,
+		chainDbPath:       stack.ResolvePath("chaindata"),
 		eventMux:          stack.EventMux(),
 		accountManager:    stack.AccountManager(),
 		engine:            engine,
@@ -151,6 +156,7 @@ func New(stack *node.Node, config *zondconfig.Config) (*Zond, error) {
 		bloomIndexer:      core.NewBloomIndexer(chainDb, params.BloomBitsBlocks, params.BloomConfirms),
 		p2pServer:         stack.Server(),
 		shutdownTracker:   shutdowncheck.NewShutdownTracker(chainDb),
+		startTime:         time.Now(),
 	}
 	bcVersion := rawdb.ReadDatabaseVersion(chainDb)
 	var dbVer = "<nil>"
@@ -179,10 +185,14 @@ func New(stack *node.Node, config *zondconfig.Config) (*Zond, error) {
 			TrieDirtyLimit:      config.TrieDirtyCache,
 			TrieDirtyDisabled:   config.NoPruning,
 			TrieTimeLimit:       config.TrieTimeout,
+			TrieBlockInterval:   config.TrieBlockInterval,
+			TriePartialFlush:    config.TriePartialFlush,
 			SnapshotLimit:       config.SnapshotCache,
+			SnapshotVerify:      config.SnapshotVerify,
 			Preimages:           config.Preimages,
 			StateHistory:        config.StateHistory,
 			StateScheme:         config.StateScheme,
+			SenderNonceIndex:    config.SenderNonceIndex,
 		}
 	)
 	// Override the chain config with provided settings.
@@ -190,6 +200,9 @@ func New(stack *node.Node, config *zondconfig.Config) (*Zond, error) {
 	if err != nil {
 		return nil, err
 	}
+	if config.CheckpointHash != (common.Hash{}) {
+		zond.blockchain.SetCheckpoint(config.CheckpointNumber, config.CheckpointHash)
+	}
 	zond.bloomIndexer.Start(zond.blockchain)
 
 	if config.TxPool.Journal != "" {
@@ -208,6 +221,7 @@ func New(stack *node.Node, config *zondconfig.Config) (*Zond, error) {
 		TxPool:         zond.txPool,
 		Network:        config.NetworkId,
 		Sync:           config.SyncMode,
+		SnapRangeSize:  config.SnapRangeSize,
 		BloomCache:     uint64(cacheLimit),
 		EventMux:       zond.eventMux,
 		RequiredBlocks: config.RequiredBlocks,
@@ -236,6 +250,11 @@ func New(stack *node.Node, config *zondconfig.Config) (*Zond, error) {
 	if err != nil {
 		return nil, err
 	}
+	if limit := zond.config.DialFailures; limit > 0 {
+		cooldown := zond.config.DialCooldown
+		zond.ethDialCandidates = enode.NewCooldownIter(zond.ethDialCandidates, limit, cooldown)
+		zond.snapDialCandidates = enode.NewCooldownIter(zond.snapDialCandidates, limit, cooldown)
+	}
 
 	// Start the RPC service
 	zond.netRPCService = zondapi.NewNetAPI(zond.p2pServer, config.NetworkId)
@@ -290,6 +309,9 @@ func (s *Zond) APIs() []rpc.API {
 		}, {
 			Namespace: "admin",
 			Service:   NewAdminAPI(s),
+		}, {
+			Namespace: "txpool",
+			Service:   NewTxPoolLocalsAPI(s),
 		}, {
 			Namespace: "debug",
 			Service:   NewDebugAPI(s),
@@ -0,0 +1,66 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package zond
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/theQRL/go-zond/consensus/beacon"
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/node"
+	"github.com/theQRL/go-zond/p2p"
+	"github.com/theQRL/go-zond/params"
+	"github.com/theQRL/go-zond/zond/zondconfig"
+)
+
+// Tests that the consensus engine of the node is selected via the config.
+func TestConsensusEngineSelection(t *testing.T) {
+	newNode := func(engine string) (*node.Node, *Zond, error) {
+		stack, err := node.New(&node.Config{
+			P2P: p2p.Config{
+				ListenAddr:  "0.0.0.0:0",
+				NoDiscovery: true,
+				MaxPeers:    25,
+			},
+		})
+		if err != nil {
+			t.Fatalf("can't create node: %v", err)
+		}
+		config := zondconfig.Defaults
+		config.Genesis = &core.Genesis{Config: params.AllBeaconProtocolChanges}
+		config.ConsensusEngine = engine
+		backend, err := New(stack, &config)
+		return stack, backend, err
+	}
+	stack, backend, err := newNode(zondconfig.FullFakerEngine)
+	if err != nil {
+		t.Fatalf("can't create zond service: %v", err)
+	}
+	defer stack.Close()
+	if err := stack.Start(); err != nil {
+		t.Fatalf("can't start node: %v", err)
+	}
+	if !reflect.DeepEqual(backend.Engine(), beacon.NewFullFaker()) {
+		t.Fatalf("consensus engine mismatch: have %+v, want full faker", backend.Engine())
+	}
+	stack, _, err = newNode("unknown")
+	defer stack.Close()
+	if err == nil {
+		t.Fatal("expected error for unknown consensus engine")
+	}
+}
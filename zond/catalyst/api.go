@@ -20,6 +20,8 @@ package catalyst
 import (
 	"errors"
 	"fmt"
+	"math"
+	"reflect"
 	"sync"
 	"time"
 
@@ -121,8 +123,28 @@ type ConsensusAPI struct {
 	lastNewPayloadUpdate time.Time
 	lastNewPayloadLock   sync.Mutex
 
+	// Forkchoice updates are fully serialized, so concurrent updates pointing to
+	// conflicting heads are applied one after the other and the last one to
+	// acquire the lock wins, never leaving the chain in a partially reorged state.
 	forkchoiceLock sync.Mutex // Lock for the forkChoiceUpdated method
 	newPayloadLock sync.Mutex // Lock for the NewPayload method
+
+	// Identical NewPayload requests arriving concurrently (e.g. a CL retrying a
+	// timed out call) are coalesced onto a single execution, tracked by the hash
+	// of the block being imported.
+	payloadCalls     map[common.Hash]*payloadCall                                // In-flight payload executions
+	payloadCallsLock sync.Mutex                                                  // Protects the in-flight payload executions
+	executePayload   func(engine.ExecutableData) (engine.PayloadStatusV1, error) // Payload executor, replaceable in tests
+}
+
+// payloadCall is an in-flight payload execution that duplicate requests can
+// wait on instead of re-executing the same block.
+type payloadCall struct {
+	params  engine.ExecutableData // Payload being executed
+	done    chan struct{}         // Closed when the execution finishes
+	waiters int                   // Number of duplicate requests waiting on the result
+	status  engine.PayloadStatusV1
+	err     error
 }
 
 // NewConsensusAPI creates a new consensus api for the given backend.
@@ -141,7 +163,9 @@ func newConsensusAPIWithoutHeartbeat(zond *zond.Zond) *ConsensusAPI {
 		localBlocks:       newPayloadQueue(),
 		invalidBlocksHits: make(map[common.Hash]int),
 		invalidTipsets:    make(map[common.Hash]*types.Header),
+		payloadCalls:      make(map[common.Hash]*payloadCall),
 	}
+	api.executePayload = api.newPayload
 	zond.Downloader().SetBadBlockCallback(api.setInvalidAncestor)
 	return api
 }
@@ -168,6 +192,23 @@ func (api *ConsensusAPI) ForkchoiceUpdatedV2(update engine.ForkchoiceStateV1, pa
 	return api.forkchoiceUpdated(update, payloadAttributes)
 }
 
+// ComputePayloadId implements engine_computePayloadId, returning the deterministic
+// identifier a forkchoice update with the given attributes on top of the parent
+// block would assign to the payload being built.
+func (api *ConsensusAPI) ComputePayloadId(parent common.Hash, payloadAttributes engine.PayloadAttributes) (engine.PayloadID, error) {
+	if err := api.verifyPayloadAttributes(&payloadAttributes); err != nil {
+		return engine.PayloadID{}, engine.InvalidParams.With(err)
+	}
+	args := &miner.BuildPayloadArgs{
+		Parent:       parent,
+		Timestamp:    payloadAttributes.Timestamp,
+		FeeRecipient: payloadAttributes.SuggestedFeeRecipient,
+		Random:       payloadAttributes.Random,
+		Withdrawals:  payloadAttributes.Withdrawals,
+	}
+	return args.Id(), nil
+}
+
 func (api *ConsensusAPI) verifyPayloadAttributes(attr *engine.PayloadAttributes) error {
 	// Verify withdrawals attribute for Shanghai.
 	if err := checkAttribute(func(uint64) bool { return true }, attr.Withdrawals != nil, attr.Timestamp); err != nil {
@@ -245,7 +286,12 @@ func (api *ConsensusAPI) forkchoiceUpdated(update engine.ForkchoiceStateV1, payl
 		}
 	}
 	if rawdb.ReadCanonicalHash(api.zond.ChainDb(), block.NumberU64()) != update.HeadBlockHash {
-		// Block is not canonical, set head.
+		// Block is not canonical, make sure the reorg does not cross finality
+		if finalized := api.zond.BlockChain().CurrentFinalBlock(); finalized != nil && !api.descendsFrom(block.Header(), finalized) {
+			log.Warn("Forkchoice requested reorg past finalized block", "number", block.NumberU64(), "hash", update.HeadBlockHash, "finalized", finalized.Number)
+			return engine.STATUS_INVALID, engine.InvalidForkChoiceState.With(errors.New("head does not descend from finalized block"))
+		}
+		// Set head.
 		if latestValid, err := api.zond.BlockChain().SetCanonical(block); err != nil {
 			return engine.ForkChoiceResponse{PayloadStatus: engine.PayloadStatusV1{Status: engine.INVALID, LatestValidHash: &latestValid}}, err
 		}
@@ -318,6 +364,18 @@ func (api *ConsensusAPI) forkchoiceUpdated(update engine.ForkchoiceStateV1, payl
 	return valid(nil), nil
 }
 
+// descendsFrom reports whether the given header is the ancestor block itself,
+// or one of its descendants.
+func (api *ConsensusAPI) descendsFrom(header *types.Header, ancestor *types.Header) bool {
+	number, anchor := header.Number.Uint64(), ancestor.Number.Uint64()
+	if number < anchor {
+		return false
+	}
+	maxNonCanonical := uint64(math.MaxUint64)
+	hash, _ := api.zond.BlockChain().GetAncestor(header.Hash(), number, number-anchor, &maxNonCanonical)
+	return hash == ancestor.Hash()
+}
+
 // GetPayloadV2 returns a cached payload by id.
 func (api *ConsensusAPI) GetPayloadV2(payloadID engine.PayloadID) (*engine.ExecutionPayloadEnvelope, error) {
 	return api.getPayload(payloadID, false)
@@ -338,7 +396,39 @@ func (api *ConsensusAPI) NewPayloadV2(params engine.ExecutableData) (engine.Payl
 		return engine.PayloadStatusV1{Status: engine.INVALID}, engine.InvalidParams.With(errors.New("nil withdrawals post-shanghai"))
 	}
 
-	return api.newPayload(params)
+	return api.coalescePayload(params)
+}
+
+// coalescePayload executes the given payload, unless an identical one is already
+// being executed, in which case it waits for and returns the result of that. A
+// payload only claiming the same block hash is executed on its own, so it gets
+// validated against its own contents.
+func (api *ConsensusAPI) coalescePayload(params engine.ExecutableData) (engine.PayloadStatusV1, error) {
+	api.payloadCallsLock.Lock()
+	if call, ok := api.payloadCalls[params.BlockHash]; ok {
+		if !reflect.DeepEqual(call.params, params) {
+			api.payloadCallsLock.Unlock()
+			return api.executePayload(params)
+		}
+		call.waiters++
+		api.payloadCallsLock.Unlock()
+
+		log.Debug("Waiting for in-flight payload execution", "number", params.Number, "hash", params.BlockHash)
+		<-call.done
+		return call.status, call.err
+	}
+	call := &payloadCall{params: params, done: make(chan struct{})}
+	api.payloadCalls[params.BlockHash] = call
+	api.payloadCallsLock.Unlock()
+
+	call.status, call.err = api.executePayload(params)
+
+	api.payloadCallsLock.Lock()
+	delete(api.payloadCalls, params.BlockHash)
+	api.payloadCallsLock.Unlock()
+
+	close(call.done)
+	return call.status, call.err
 }
 
 func (api *ConsensusAPI) newPayload(params engine.ExecutableData) (engine.PayloadStatusV1, error) {
@@ -25,6 +25,7 @@ import (
 	"math/rand"
 	"reflect"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -685,6 +686,64 @@ func TestEmptyBlocks(t *testing.T) {
 	}
 }
 
+func TestForkchoiceReorgPastFinalized(t *testing.T) {
+	genesis, preMergeBlocks := generateMergeChain(10)
+	n, zondservice := startZondService(t, genesis, preMergeBlocks)
+	defer n.Close()
+
+	commonAncestor := zondservice.BlockChain().CurrentBlock()
+	api := NewConsensusAPI(zondservice)
+
+	// Setup 10 blocks on the canonical chain, finalizing the parent of each head
+	headers := setupBlocks(t, zondservice, 10, commonAncestor, func(parent *types.Header) {}, nil)
+	head, finalized := headers[len(headers)-1], headers[len(headers)-2]
+
+	// newFork creates and imports a sibling of the canonical child of parent
+	newFork := func(parent *types.Header) *engine.ExecutableData {
+		payload, err := assembleBlock(api, parent.Hash(), &engine.PayloadAttributes{
+			Timestamp:             parent.Time + 1,
+			Random:                crypto.Keccak256Hash([]byte{byte(2)}),
+			SuggestedFeeRecipient: parent.Coinbase,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		status, err := api.NewPayloadV2(*payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status.Status != engine.VALID {
+			t.Fatalf("invalid status: expected VALID got: %v", status.Status)
+		}
+		return payload
+	}
+	// A head conflicting with the finalized block must be rejected
+	conflicting := newFork(commonAncestor)
+	fcState := engine.ForkchoiceStateV1{HeadBlockHash: conflicting.BlockHash}
+	resp, err := api.ForkchoiceUpdatedV2(fcState, nil)
+	if err == nil {
+		t.Fatal("expected error reorging past the finalized block")
+	}
+	if resp.PayloadStatus.Status != engine.INVALID {
+		t.Fatalf("invalid status: expected INVALID got: %v", resp.PayloadStatus.Status)
+	}
+	if have := zondservice.BlockChain().CurrentBlock().Hash(); have != head.Hash() {
+		t.Fatalf("chain head changed: have %x, want %x", have, head.Hash())
+	}
+	// A reorg on top of the finalized block is still allowed
+	sibling := newFork(finalized)
+	fcState = engine.ForkchoiceStateV1{HeadBlockHash: sibling.BlockHash}
+	if resp, err = api.ForkchoiceUpdatedV2(fcState, nil); err != nil {
+		t.Fatalf("failed to reorg on top of the finalized block: %v", err)
+	}
+	if resp.PayloadStatus.Status != engine.VALID {
+		t.Fatalf("invalid status: expected VALID got: %v", resp.PayloadStatus.Status)
+	}
+	if have := zondservice.BlockChain().CurrentBlock().Hash(); have != sibling.BlockHash {
+		t.Fatalf("chain head not updated: have %x, want %x", have, sibling.BlockHash)
+	}
+}
+
 func getNewPayload(t *testing.T, api *ConsensusAPI, parent *types.Header, withdrawals []*types.Withdrawal) *engine.ExecutableData {
 	params := engine.PayloadAttributes{
 		Timestamp:             parent.Time + 1,
@@ -703,26 +762,7 @@ func getNewPayload(t *testing.T, api *ConsensusAPI, parent *types.Header, withdr
 // setBlockhash sets the blockhash of a modified ExecutableData.
 // Can be used to make modified payloads look valid.
 func setBlockhash(data *engine.ExecutableData) *engine.ExecutableData {
-	txs, _ := decodeTransactions(data.Transactions)
-	number := big.NewInt(0)
-	number.SetUint64(data.Number)
-	header := &types.Header{
-		ParentHash:  data.ParentHash,
-		Coinbase:    data.FeeRecipient,
-		Root:        data.StateRoot,
-		TxHash:      types.DeriveSha(types.Transactions(txs), trie.NewStackTrie(nil)),
-		ReceiptHash: data.ReceiptsRoot,
-		Bloom:       types.BytesToBloom(data.LogsBloom),
-		Number:      number,
-		GasLimit:    data.GasLimit,
-		GasUsed:     data.GasUsed,
-		Time:        data.Timestamp,
-		BaseFee:     data.BaseFeePerGas,
-		Extra:       data.ExtraData,
-		Random:      data.Random,
-	}
-	block := types.NewBlockWithHeader(header).WithBody(txs)
-	data.BlockHash = block.Hash()
+	data.BlockHash, _ = engine.ComputeBlockHash(*data)
 	return data
 }
 
@@ -975,6 +1015,209 @@ func TestSimultaneousNewBlock(t *testing.T) {
 	}
 }
 
+// TestConcurrentConflictingForkchoice tests that forkchoice updates to competing
+// heads issued concurrently are serialized, leaving the chain consistently set
+// to one of the requested heads.
+func TestConcurrentConflictingForkchoice(t *testing.T) {
+	genesis, preMergeBlocks := generateMergeChain(10)
+	n, zondservice := startZondService(t, genesis, preMergeBlocks)
+	defer n.Close()
+
+	var (
+		api    = NewConsensusAPI(zondservice)
+		parent = preMergeBlocks[len(preMergeBlocks)-1]
+		heads  []common.Hash
+	)
+	// Create two sibling blocks on top of the same parent
+	for i := 0; i < 2; i++ {
+		execData, err := assembleBlock(api, parent.Hash(), &engine.PayloadAttributes{
+			Timestamp:             parent.Time() + 5,
+			SuggestedFeeRecipient: common.Address{byte(i + 1)},
+		})
+		if err != nil {
+			t.Fatalf("Failed to create the executable data %v", err)
+		}
+		if resp, err := api.NewPayloadV2(*execData); err != nil || resp.Status != engine.VALID {
+			t.Fatalf("Failed to insert block: %v %v", resp.Status, err)
+		}
+		heads = append(heads, execData.BlockHash)
+	}
+	if heads[0] == heads[1] {
+		t.Fatal("sibling blocks are identical")
+	}
+	var (
+		wg      sync.WaitGroup
+		testErr error
+		errMu   sync.Mutex
+	)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(head common.Hash) {
+			defer wg.Done()
+			fcState := engine.ForkchoiceStateV1{HeadBlockHash: head}
+			if resp, err := api.ForkchoiceUpdatedV2(fcState, nil); err != nil || resp.PayloadStatus.Status != engine.VALID {
+				errMu.Lock()
+				testErr = fmt.Errorf("Failed to update forkchoice: %v %v", resp.PayloadStatus.Status, err)
+				errMu.Unlock()
+			}
+		}(heads[i%2])
+	}
+	wg.Wait()
+	if testErr != nil {
+		t.Fatal(testErr)
+	}
+	head := zondservice.BlockChain().CurrentBlock()
+	if head.Hash() != heads[0] && head.Hash() != heads[1] {
+		t.Fatalf("Chain head is not one of the requested heads: %x", head.Hash())
+	}
+	if canon := zondservice.BlockChain().GetCanonicalHash(head.Number.Uint64()); canon != head.Hash() {
+		t.Fatalf("Canonical hash mismatch: have %x, want %x", canon, head.Hash())
+	}
+	if canon := zondservice.BlockChain().GetCanonicalHash(parent.NumberU64()); canon != parent.Hash() {
+		t.Fatalf("Parent not canonical: have %x, want %x", canon, parent.Hash())
+	}
+	// A subsequent update to the other sibling must take effect
+	other := heads[0]
+	if head.Hash() == heads[0] {
+		other = heads[1]
+	}
+	if _, err := api.ForkchoiceUpdatedV2(engine.ForkchoiceStateV1{HeadBlockHash: other}, nil); err != nil {
+		t.Fatalf("Failed to update forkchoice: %v", err)
+	}
+	if have := zondservice.BlockChain().CurrentBlock().Hash(); have != other {
+		t.Fatalf("Chain head mismatch: have %x, want %x", have, other)
+	}
+}
+
+// TestCoalescedNewPayload tests that identical concurrent NewPayload calls are
+// coalesced onto a single execution, with all callers receiving its result.
+func TestCoalescedNewPayload(t *testing.T) {
+	genesis, preMergeBlocks := generateMergeChain(10)
+	n, zondservice := startZondService(t, genesis, preMergeBlocks)
+	defer n.Close()
+
+	var (
+		api    = NewConsensusAPI(zondservice)
+		parent = preMergeBlocks[len(preMergeBlocks)-1]
+	)
+	execData, err := assembleBlock(api, parent.Hash(), &engine.PayloadAttributes{
+		Timestamp: parent.Time() + 5,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create the executable data %v", err)
+	}
+	// Count the executions and hold the first one until all duplicates queue up
+	var (
+		executions atomic.Int32
+		release    = make(chan struct{})
+		execute    = api.executePayload
+	)
+	api.executePayload = func(params engine.ExecutableData) (engine.PayloadStatusV1, error) {
+		executions.Add(1)
+		<-release
+		return execute(params)
+	}
+	var (
+		wg       sync.WaitGroup
+		statuses = make([]engine.PayloadStatusV1, 10)
+		errs     = make([]error, 10)
+	)
+	wg.Add(len(statuses))
+	for i := 0; i < len(statuses); i++ {
+		go func(i int) {
+			defer wg.Done()
+			statuses[i], errs[i] = api.NewPayloadV2(*execData)
+		}(i)
+	}
+	for {
+		api.payloadCallsLock.Lock()
+		call := api.payloadCalls[execData.BlockHash]
+		queued := call != nil && call.waiters == len(statuses)-1
+		api.payloadCallsLock.Unlock()
+
+		if queued {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if have := executions.Load(); have != 1 {
+		t.Fatalf("payload executed %d times, want 1", have)
+	}
+	for i := range statuses {
+		if errs[i] != nil {
+			t.Fatalf("call %d: failed to insert block: %v", i, errs[i])
+		}
+		if statuses[i].Status != engine.VALID {
+			t.Fatalf("call %d: invalid status: %v", i, statuses[i].Status)
+		}
+	}
+}
+
+// TestCoalescedNewPayloadMismatch tests that a NewPayload call only sharing the
+// block hash of an in-flight one is not coalesced onto it, but validated itself.
+func TestCoalescedNewPayloadMismatch(t *testing.T) {
+	genesis, preMergeBlocks := generateMergeChain(10)
+	n, zondservice := startZondService(t, genesis, preMergeBlocks)
+	defer n.Close()
+
+	var (
+		api    = NewConsensusAPI(zondservice)
+		parent = preMergeBlocks[len(preMergeBlocks)-1]
+	)
+	execData, err := assembleBlock(api, parent.Hash(), &engine.PayloadAttributes{
+		Timestamp: parent.Time() + 5,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create the executable data %v", err)
+	}
+	tampered := *execData
+	tampered.ExtraData = []byte{0x01}
+
+	// Count the executions and hold them until both calls are in flight
+	var (
+		executions atomic.Int32
+		release    = make(chan struct{})
+		execute    = api.executePayload
+	)
+	api.executePayload = func(params engine.ExecutableData) (engine.PayloadStatusV1, error) {
+		executions.Add(1)
+		<-release
+		return execute(params)
+	}
+	var (
+		wg       sync.WaitGroup
+		statuses = make([]engine.PayloadStatusV1, 2)
+		errs     = make([]error, 2)
+	)
+	wg.Add(len(statuses))
+	go func() {
+		defer wg.Done()
+		statuses[0], errs[0] = api.NewPayloadV2(*execData)
+	}()
+	for executions.Load() != 1 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	go func() {
+		defer wg.Done()
+		statuses[1], errs[1] = api.NewPayloadV2(tampered)
+	}()
+	for executions.Load() != 2 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if errs[0] != nil || statuses[0].Status != engine.VALID {
+		t.Fatalf("original payload: status %v, err %v", statuses[0].Status, errs[0])
+	}
+	if errs[1] != nil || statuses[1].Status != engine.INVALID {
+		t.Fatalf("tampered payload: status %v, err %v", statuses[1].Status, errs[1])
+	}
+}
+
 // TestWithdrawals creates and verifies two post-Shanghai blocks. The first
 // includes zero withdrawals and the second includes two.
 func TestWithdrawals(t *testing.T) {
@@ -1429,6 +1672,49 @@ func TestGetBlockBodiesByRangeInvalidParams(t *testing.T) {
 	}
 }
 
+func TestComputePayloadId(t *testing.T) {
+	genesis, blocks := generateMergeChain(10)
+	n, zondservice := startZondService(t, genesis, blocks)
+	defer n.Close()
+
+	api := NewConsensusAPI(zondservice)
+	parent := zondservice.BlockChain().CurrentBlock()
+	attrs := engine.PayloadAttributes{
+		Timestamp:             parent.Time + 5,
+		Random:                common.Hash{0x01},
+		SuggestedFeeRecipient: common.Address{0x02},
+		Withdrawals:           []*types.Withdrawal{{Index: 0, Validator: 1, Address: common.Address{0x03}, Amount: 10}},
+	}
+	id, err := api.ComputePayloadId(parent.Hash(), attrs)
+	if err != nil {
+		t.Fatalf("error computing payload id: %v", err)
+	}
+	want := (&miner.BuildPayloadArgs{
+		Parent:       parent.Hash(),
+		Timestamp:    attrs.Timestamp,
+		FeeRecipient: attrs.SuggestedFeeRecipient,
+		Random:       attrs.Random,
+		Withdrawals:  attrs.Withdrawals,
+	}).Id()
+	if id != want {
+		t.Fatalf("payload id mismatch: have %v, want %v", id, want)
+	}
+	// The id must match the one assigned by an actual forkchoice update.
+	fcState := engine.ForkchoiceStateV1{HeadBlockHash: parent.Hash()}
+	resp, err := api.ForkchoiceUpdatedV2(fcState, &attrs)
+	if err != nil {
+		t.Fatalf("error preparing payload: %v", err)
+	}
+	if resp.PayloadID == nil || *resp.PayloadID != id {
+		t.Fatalf("forkchoice payload id mismatch: have %v, want %v", resp.PayloadID, id)
+	}
+	// Attributes without withdrawals are rejected like in a forkchoice update.
+	attrs.Withdrawals = nil
+	if _, err := api.ComputePayloadId(parent.Hash(), attrs); err == nil {
+		t.Fatal("expected error for missing withdrawals")
+	}
+}
+
 func equalBody(a *types.Body, b *engine.ExecutionPayloadBodyV1) bool {
 	if a == nil && b == nil {
 		return true
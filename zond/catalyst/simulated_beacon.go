@@ -221,7 +221,8 @@ func (c *SimulatedBeacon) loopOnDemand() {
 	}
 }
 
-// loop runs the block production loop for non-zero period configuration
+// loop runs the block production loop for non-zero period configuration. A block
+// is sealed every period, even if it ends up empty.
 func (c *SimulatedBeacon) loop() {
 	timer := time.NewTimer(0)
 	for {
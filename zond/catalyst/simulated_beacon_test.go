@@ -139,3 +139,38 @@ func TestSimulatedBeaconSendWithdrawals(t *testing.T) {
 		}
 	}
 }
+
+// TestSimulatedBeaconEmptyBlocks tests that with a non-zero period, blocks are
+// sealed at the configured interval even if no transactions are pending.
+func TestSimulatedBeaconEmptyBlocks(t *testing.T) {
+	testAddr := common.Address{0x01}
+	genesis := core.DeveloperGenesisBlock(10_000_000, testAddr)
+	node, zondService, _ := startSimulatedBeaconZondService(t, genesis)
+	defer node.Close()
+
+	chainHeadCh := make(chan core.ChainHeadEvent, 10)
+	subscription := zondService.BlockChain().SubscribeChainHeadEvent(chainHeadCh)
+	defer subscription.Unsubscribe()
+
+	var (
+		timer = time.NewTimer(10 * time.Second)
+		last  *types.Block
+	)
+	defer timer.Stop()
+
+	for sealed := 0; sealed < 3; {
+		select {
+		case evt := <-chainHeadCh:
+			if n := len(evt.Block.Transactions()); n != 0 {
+				t.Fatalf("block %d: unexpected transactions: %d", evt.Block.NumberU64(), n)
+			}
+			if last != nil && evt.Block.Time() < last.Time()+1 {
+				t.Fatalf("block %d: sealed before the period elapsed: time %d, parent time %d", evt.Block.NumberU64(), evt.Block.Time(), last.Time())
+			}
+			last = evt.Block
+			sealed++
+		case <-timer.C:
+			t.Fatal("timed out waiting for empty blocks")
+		}
+	}
+}
@@ -227,6 +227,15 @@ func New(stateDb zonddb.Database, mux *event.TypeMux, chain BlockChain, lightcha
 	return dl
 }
 
+// Pivot retrieves the pivot header of the running snap sync, or nil if none
+// has been selected yet.
+func (d *Downloader) Pivot() *types.Header {
+	d.pivotLock.RLock()
+	defer d.pivotLock.RUnlock()
+
+	return d.pivotHeader
+}
+
 // Progress retrieves the synchronisation boundaries, specifically the origin
 // block where synchronisation started at (may have failed/suspended); the block
 // or header sync is currently at; and the latest known block which the sync targets.
@@ -30,14 +30,27 @@ import (
 	"github.com/theQRL/go-zond/common/hexutil"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/internal/zondapi"
+	"github.com/theQRL/go-zond/params"
 	"github.com/theQRL/go-zond/rpc"
 )
 
 var (
-	errInvalidTopic   = errors.New("invalid topic(s)")
-	errFilterNotFound = errors.New("filter not found")
+	errInvalidTopic        = errors.New("invalid topic(s)")
+	errFilterNotFound      = errors.New("filter not found")
+	errExceedMaxAddresses  = errors.New("exceed max addresses")
+	errExceedMaxBlockRange = errors.New("exceed max block range")
 )
 
+// maxContractLogs is the maximum number of logs returned in a single page by
+// zond_getContractLogs. Pages are cut at block boundaries, so a page may hold a
+// few more logs if the last block included emitted several of them.
+const maxContractLogs = 10000
+
+// maxContractLogsBlocks is the maximum number of blocks scanned for a single
+// page by zond_getContractLogs, unless the configured maximum block range of
+// log queries is lower.
+const maxContractLogsBlocks = 100000
+
 // filter is a helper struct that holds meta information over the filter type
 // and associated subscription in the event system.
 type filter struct {
@@ -59,21 +72,32 @@ type FilterAPI struct {
 	filtersMu sync.Mutex
 	filters   map[rpc.ID]*filter
 	timeout   time.Duration
+	maxAddrs  int
 }
 
 // NewFilterAPI returns a new FilterAPI instance.
 func NewFilterAPI(system *FilterSystem) *FilterAPI {
 	api := &FilterAPI{
-		sys:     system,
-		events:  NewEventSystem(system),
-		filters: make(map[rpc.ID]*filter),
-		timeout: system.cfg.Timeout,
+		sys:      system,
+		events:   NewEventSystem(system),
+		filters:  make(map[rpc.ID]*filter),
+		timeout:  system.cfg.Timeout,
+		maxAddrs: system.cfg.MaxAddresses,
 	}
 	go api.timeoutLoop(system.cfg.Timeout)
 
 	return api
 }
 
+// checkAddresses returns an error if the given criteria contains more addresses
+// than the configured limit.
+func (api *FilterAPI) checkAddresses(crit FilterCriteria) error {
+	if api.maxAddrs > 0 && len(crit.Addresses) > api.maxAddrs {
+		return fmt.Errorf("%w: have %d, max %d", errExceedMaxAddresses, len(crit.Addresses), api.maxAddrs)
+	}
+	return nil
+}
+
 // timeoutLoop runs at the interval set by 'timeout' and deletes filters
 // that have not been recently used. It is started when the API is created.
 func (api *FilterAPI) timeoutLoop(timeout time.Duration) {
@@ -253,6 +277,9 @@ func (api *FilterAPI) Logs(ctx context.Context, crit FilterCriteria) (*rpc.Subsc
 		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
 	}
 
+	if err := api.checkAddresses(crit); err != nil {
+		return nil, err
+	}
 	var (
 		rpcSub      = notifier.CreateSubscription()
 		matchedLogs = make(chan []*types.Log)
@@ -300,6 +327,9 @@ type FilterCriteria zond.FilterQuery
 //
 // In case "fromBlock" > "toBlock" an error is returned.
 func (api *FilterAPI) NewFilter(crit FilterCriteria) (rpc.ID, error) {
+	if err := api.checkAddresses(crit); err != nil {
+		return "", err
+	}
 	logs := make(chan []*types.Log)
 	logsSub, err := api.events.SubscribeLogs(zond.FilterQuery(crit), logs)
 	if err != nil {
@@ -333,6 +363,9 @@ func (api *FilterAPI) NewFilter(crit FilterCriteria) (rpc.ID, error) {
 
 // GetLogs returns logs matching the given argument that are stored within the state.
 func (api *FilterAPI) GetLogs(ctx context.Context, crit FilterCriteria) ([]*types.Log, error) {
+	if err := api.checkAddresses(crit); err != nil {
+		return nil, err
+	}
 	var filter *Filter
 	if crit.BlockHash != nil {
 		// Block filter requested, construct a single-shot filter
@@ -358,6 +391,81 @@ func (api *FilterAPI) GetLogs(ctx context.Context, crit FilterCriteria) ([]*type
 	return returnLogs(logs), err
 }
 
+// ContractLogs is a page of logs emitted by a single contract.
+type ContractLogs struct {
+	Logs []*types.Log    `json:"logs"`
+	Next *hexutil.Uint64 `json:"next"` // Block to resume from, nil if the head was reached
+}
+
+// GetContractLogs returns the logs emitted by the given contract, starting at
+// fromBlock. The blocks are scanned in bloom section sized chunks until either
+// the chain head is reached, the page limit is exceeded or the maximum number
+// of blocks per page has been scanned. Unless the head was reached, the block
+// to resume from is returned along with the page.
+func (api *FilterAPI) GetContractLogs(ctx context.Context, address common.Address, fromBlock rpc.BlockNumber, limit *hexutil.Uint64) (*ContractLogs, error) {
+	head, err := api.sys.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if head == nil {
+		return nil, errors.New("chain head is not available")
+	}
+	headNum := head.Number.Uint64()
+
+	begin := uint64(fromBlock.Int64())
+	if fromBlock == rpc.LatestBlockNumber {
+		begin = headNum
+	} else if fromBlock < 0 {
+		return nil, fmt.Errorf("unsupported block number %d", fromBlock.Int64())
+	}
+	pageLimit := uint64(maxContractLogs)
+	if limit != nil && uint64(*limit) > 0 && uint64(*limit) < pageLimit {
+		pageLimit = uint64(*limit)
+	}
+	// Bound the blocks scanned by a page, as sparse contracts would otherwise
+	// have a single page span the whole chain
+	scanLimit := uint64(maxContractLogsBlocks)
+	if limit := api.sys.cfg.MaxBlockRange; limit > 0 && limit < scanLimit {
+		scanLimit = limit
+	}
+	scanEnd := headNum
+	if begin+scanLimit-1 < scanEnd {
+		scanEnd = begin + scanLimit - 1
+	}
+	var logs []*types.Log
+	for begin <= scanEnd {
+		end := (begin/params.BloomBitsBlocks+1)*params.BloomBitsBlocks - 1
+		if end > scanEnd {
+			end = scanEnd
+		}
+		found, err := api.sys.NewRangeFilter(int64(begin), int64(end), []common.Address{address}, nil).Logs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, found...)
+		if uint64(len(logs)) >= pageLimit {
+			// Page full, cut it after all logs of the block reaching the limit
+			last, n := logs[pageLimit-1].BlockNumber, int(pageLimit)
+			for n < len(logs) && logs[n].BlockNumber == last {
+				n++
+			}
+			page := &ContractLogs{Logs: returnLogs(logs[:n])}
+			if last < headNum {
+				next := hexutil.Uint64(last + 1)
+				page.Next = &next
+			}
+			return page, nil
+		}
+		begin = end + 1
+	}
+	page := &ContractLogs{Logs: returnLogs(logs)}
+	if scanEnd < headNum {
+		next := hexutil.Uint64(scanEnd + 1)
+		page.Next = &next
+	}
+	return page, nil
+}
+
 // UninstallFilter removes the filter with the given filter id.
 func (api *FilterAPI) UninstallFilter(id rpc.ID) bool {
 	api.filtersMu.Lock()
@@ -460,6 +568,23 @@ func (api *FilterAPI) GetFilterChanges(id rpc.ID) (interface{}, error) {
 	return []interface{}{}, errFilterNotFound
 }
 
+// AdminAPI offers administrative insight into the filters and subscriptions
+// managed by a FilterAPI.
+type AdminAPI struct {
+	events *EventSystem
+}
+
+// NewAdminAPI returns a new AdminAPI instance backed by the given filter API.
+func NewAdminAPI(api *FilterAPI) *AdminAPI {
+	return &AdminAPI{events: api.events}
+}
+
+// SubscriptionCount returns the number of active filters and subscriptions,
+// grouped into new heads, logs and pending transactions.
+func (api *AdminAPI) SubscriptionCount() SubscriptionCounts {
+	return api.events.SubscriptionCounts()
+}
+
 // returnHashes is a helper that will return an empty hash array case the given hash array is nil,
 // otherwise the given hashes array is returned.
 func returnHashes(hashes []common.Hash) []common.Hash {
@@ -19,6 +19,7 @@ package filters
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/theQRL/go-zond/common"
@@ -156,6 +157,11 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 	if f.end, err = resolveSpecial(f.end); err != nil {
 		return nil, err
 	}
+	// Reject ranges spanning more blocks than allowed, clients are expected to
+	// split them up into smaller chunks
+	if limit := f.sys.cfg.MaxBlockRange; limit > 0 && f.end >= f.begin && uint64(f.end-f.begin)+1 > limit {
+		return nil, fmt.Errorf("%w: have %d, max %d", errExceedMaxBlockRange, f.end-f.begin+1, limit)
+	}
 
 	logChan, errChan := f.rangeLogsAsync(ctx)
 	var logs []*types.Log
@@ -41,8 +41,10 @@ import (
 
 // Config represents the configuration of the filter system.
 type Config struct {
-	LogCacheSize int           // maximum number of cached blocks (default: 32)
-	Timeout      time.Duration // how long filters stay active (default: 5min)
+	LogCacheSize  int           // maximum number of cached blocks (default: 32)
+	Timeout       time.Duration // how long filters stay active (default: 5min)
+	MaxAddresses  int           // maximum number of addresses in a log filter (0 = no limit)
+	MaxBlockRange uint64        // maximum number of blocks spanned by a log query (0 = no limit)
 }
 
 func (cfg Config) withDefaults() Config {
@@ -203,13 +205,22 @@ type EventSystem struct {
 	chainSub       event.Subscription // Subscription for new chain event
 
 	// Channels
-	install       chan *subscription         // install filter for event notification
-	uninstall     chan *subscription         // remove filter for event notification
-	txsCh         chan core.NewTxsEvent      // Channel to receive new transactions event
-	logsCh        chan []*types.Log          // Channel to receive new log event
-	pendingLogsCh chan []*types.Log          // Channel to receive new log event
-	rmLogsCh      chan core.RemovedLogsEvent // Channel to receive removed log event
-	chainCh       chan core.ChainEvent       // Channel to receive new chain event
+	install       chan *subscription           // install filter for event notification
+	uninstall     chan *subscription           // remove filter for event notification
+	txsCh         chan core.NewTxsEvent        // Channel to receive new transactions event
+	logsCh        chan []*types.Log            // Channel to receive new log event
+	pendingLogsCh chan []*types.Log            // Channel to receive new log event
+	rmLogsCh      chan core.RemovedLogsEvent   // Channel to receive removed log event
+	chainCh       chan core.ChainEvent         // Channel to receive new chain event
+	countsCh      chan chan SubscriptionCounts // Channel to request the active subscription counts
+}
+
+// SubscriptionCounts is the number of active filters and subscriptions, grouped
+// by the kind of events they deliver.
+type SubscriptionCounts struct {
+	NewHeads   int `json:"newHeads"`
+	Logs       int `json:"logs"`
+	PendingTxs int `json:"pendingTxs"`
 }
 
 // NewEventSystem creates a new manager that listens for event on the given mux,
@@ -229,6 +240,7 @@ func NewEventSystem(sys *FilterSystem) *EventSystem {
 		rmLogsCh:      make(chan core.RemovedLogsEvent, rmLogsChanSize),
 		pendingLogsCh: make(chan []*types.Log, logsChanSize),
 		chainCh:       make(chan core.ChainEvent, chainEvChanSize),
+		countsCh:      make(chan chan SubscriptionCounts),
 	}
 
 	// Subscribe events
@@ -285,6 +297,14 @@ func (sub *Subscription) Unsubscribe() {
 	})
 }
 
+// SubscriptionCounts returns the number of filters and subscriptions currently
+// installed in the event broadcast loop.
+func (es *EventSystem) SubscriptionCounts() SubscriptionCounts {
+	ch := make(chan SubscriptionCounts)
+	es.countsCh <- ch
+	return <-ch
+}
+
 // subscribe installs the subscription in the event broadcast loop.
 func (es *EventSystem) subscribe(sub *subscription) *Subscription {
 	es.install <- sub
@@ -416,6 +436,22 @@ func (es *EventSystem) SubscribePendingTxs(txs chan []*types.Transaction) *Subsc
 
 type filterIndex map[Type]map[rpc.ID]*subscription
 
+// counts returns the number of installed filters by kind. Subscriptions to both
+// mined and pending logs are indexed under both log types but counted once.
+func (filters filterIndex) counts() SubscriptionCounts {
+	logs := len(filters[LogsSubscription])
+	for id := range filters[PendingLogsSubscription] {
+		if _, ok := filters[LogsSubscription][id]; !ok {
+			logs++
+		}
+	}
+	return SubscriptionCounts{
+		NewHeads:   len(filters[BlocksSubscription]),
+		Logs:       logs,
+		PendingTxs: len(filters[PendingTransactionsSubscription]),
+	}
+}
+
 func (es *EventSystem) handleLogs(filters filterIndex, ev []*types.Log) {
 	if len(ev) == 0 {
 		return
@@ -501,6 +537,9 @@ func (es *EventSystem) eventLoop() {
 			}
 			close(f.err)
 
+		case ch := <-es.countsCh:
+			ch <- index.counts()
+
 		// System stopped
 		case <-es.txsSub.Err():
 			return
@@ -29,6 +29,7 @@ import (
 
 	"github.com/theQRL/go-zond"
 	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/common/hexutil"
 	"github.com/theQRL/go-zond/consensus/beacon"
 	"github.com/theQRL/go-zond/core"
 	"github.com/theQRL/go-zond/core/bloombits"
@@ -38,6 +39,7 @@ import (
 	"github.com/theQRL/go-zond/internal/zondapi"
 	"github.com/theQRL/go-zond/params"
 	"github.com/theQRL/go-zond/rpc"
+	"github.com/theQRL/go-zond/trie"
 	"github.com/theQRL/go-zond/zonddb"
 )
 
@@ -450,6 +452,121 @@ func TestInvalidGetLogsRequest(t *testing.T) {
 	}
 }
 
+// TestExceedMaxAddresses tests that log filter queries with more addresses than
+// the configured limit are rejected.
+func TestExceedMaxAddresses(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db     = rawdb.NewMemoryDatabase()
+		_, sys = newTestFilterSystem(t, db, Config{MaxAddresses: 2})
+		api    = NewFilterAPI(sys)
+		addrs  = []common.Address{{0x01}, {0x02}, {0x03}}
+	)
+	if _, err := api.GetLogs(context.Background(), FilterCriteria{Addresses: addrs}); !errors.Is(err, errExceedMaxAddresses) {
+		t.Errorf("GetLogs: have error %v, want %v", err, errExceedMaxAddresses)
+	}
+	if _, err := api.NewFilter(FilterCriteria{Addresses: addrs}); !errors.Is(err, errExceedMaxAddresses) {
+		t.Errorf("NewFilter: have error %v, want %v", err, errExceedMaxAddresses)
+	}
+	if _, err := api.GetLogs(context.Background(), FilterCriteria{Addresses: addrs[:2]}); errors.Is(err, errExceedMaxAddresses) {
+		t.Errorf("GetLogs: unexpected rejection of query within the limit")
+	}
+}
+
+// TestContractLogsMaxBlockRange tests that zond_getContractLogs scans no more
+// blocks per page than the configured maximum block range.
+func TestContractLogsMaxBlockRange(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db     = rawdb.NewMemoryDatabase()
+		_, sys = newTestFilterSystem(t, db, Config{MaxBlockRange: 10})
+		api    = NewFilterAPI(sys)
+		addr   = common.HexToAddress("0x1111111111111111111111111111111111111111")
+		gspec  = &core.Genesis{
+			Config:  params.TestChainConfig,
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+	)
+	_, chain, receipts := core.GenerateChainWithGenesis(gspec, beacon.NewFaker(), 30, func(i int, gen *core.BlockGen) {
+		if i%10 == 4 {
+			gen.AddUncheckedReceipt(makeReceipt(addr))
+		}
+	})
+	gspec.MustCommit(db, trie.NewDatabase(db, trie.HashDefaults))
+	for i, block := range chain {
+		rawdb.WriteBlock(db, block)
+		rawdb.WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+		rawdb.WriteHeadBlockHash(db, block.Hash())
+		rawdb.WriteReceipts(db, block.Hash(), block.NumberU64(), receipts[i])
+	}
+	var (
+		logs  []*types.Log
+		from  uint64
+		pages int
+	)
+	for {
+		page, err := api.GetContractLogs(context.Background(), addr, rpc.BlockNumber(from), nil)
+		if err != nil {
+			t.Fatalf("failed to retrieve contract logs: %v", err)
+		}
+		logs = append(logs, page.Logs...)
+		pages++
+		if page.Next == nil {
+			break
+		}
+		if scanned := uint64(*page.Next) - from; scanned != 10 {
+			t.Fatalf("page %d: scanned block count mismatch: have %d, want 10", pages, scanned)
+		}
+		from = uint64(*page.Next)
+	}
+	if len(logs) != 3 {
+		t.Fatalf("log count mismatch: have %d, want 3", len(logs))
+	}
+	if pages != 4 {
+		t.Fatalf("page count mismatch: have %d, want 4", pages)
+	}
+	limit := hexutil.Uint64(1)
+	page, err := api.GetContractLogs(context.Background(), addr, 0, &limit)
+	if err != nil {
+		t.Fatalf("failed to retrieve contract logs page: %v", err)
+	}
+	if len(page.Logs) != 1 {
+		t.Fatalf("page log count mismatch: have %d, want 1", len(page.Logs))
+	}
+	if page.Next == nil || *page.Next != 6 {
+		t.Fatalf("next block mismatch: have %v, want 6", page.Next)
+	}
+}
+
+// TestExceedMaxBlockRange tests that log queries spanning more blocks than the
+// configured limit are rejected.
+func TestExceedMaxBlockRange(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db     = rawdb.NewMemoryDatabase()
+		_, sys = newTestFilterSystem(t, db, Config{MaxBlockRange: 10})
+		api    = NewFilterAPI(sys)
+	)
+	crit := FilterCriteria{FromBlock: big.NewInt(0), ToBlock: big.NewInt(10)}
+	if _, err := api.GetLogs(context.Background(), crit); !errors.Is(err, errExceedMaxBlockRange) {
+		t.Errorf("GetLogs: have error %v, want %v", err, errExceedMaxBlockRange)
+	}
+	id, err := api.NewFilter(crit)
+	if err != nil {
+		t.Fatalf("NewFilter: unexpected error: %v", err)
+	}
+	if _, err := api.GetFilterLogs(context.Background(), id); !errors.Is(err, errExceedMaxBlockRange) {
+		t.Errorf("GetFilterLogs: have error %v, want %v", err, errExceedMaxBlockRange)
+	}
+	crit.ToBlock = big.NewInt(9)
+	if _, err := api.GetLogs(context.Background(), crit); errors.Is(err, errExceedMaxBlockRange) {
+		t.Errorf("GetLogs: unexpected rejection of query within the limit")
+	}
+}
+
 // TestLogFilter tests whether log filters match the correct logs that are posted to the event feed.
 func TestLogFilter(t *testing.T) {
 	t.Parallel()
@@ -812,6 +929,54 @@ func TestPendingTxFilterDeadlock(t *testing.T) {
 	}
 }
 
+// TestSubscriptionCount tests that the admin API reports the number of active
+// filters and subscriptions of each kind.
+func TestSubscriptionCount(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db     = rawdb.NewMemoryDatabase()
+		_, sys = newTestFilterSystem(t, db, Config{})
+		api    = NewFilterAPI(sys)
+		admin  = NewAdminAPI(api)
+	)
+	if have, want := admin.SubscriptionCount(), (SubscriptionCounts{}); have != want {
+		t.Fatalf("subscription counts mismatch: have %+v, want %+v", have, want)
+	}
+	heads := api.events.SubscribeNewHeads(make(chan *types.Header))
+	defer heads.Unsubscribe()
+
+	logs, err := api.events.SubscribeLogs(zond.FilterQuery{}, make(chan []*types.Log))
+	if err != nil {
+		t.Fatalf("failed to subscribe to logs: %v", err)
+	}
+	defer logs.Unsubscribe()
+
+	// Mined and pending logs subscriptions must only be counted once
+	minedAndPending, err := api.events.SubscribeLogs(zond.FilterQuery{
+		FromBlock: big.NewInt(rpc.LatestBlockNumber.Int64()),
+		ToBlock:   big.NewInt(rpc.PendingBlockNumber.Int64()),
+	}, make(chan []*types.Log))
+	if err != nil {
+		t.Fatalf("failed to subscribe to mined and pending logs: %v", err)
+	}
+	defer minedAndPending.Unsubscribe()
+
+	txs := api.events.SubscribePendingTxs(make(chan []*types.Transaction))
+	fid := api.NewPendingTransactionFilter(nil)
+
+	if have, want := admin.SubscriptionCount(), (SubscriptionCounts{NewHeads: 1, Logs: 2, PendingTxs: 2}); have != want {
+		t.Fatalf("subscription counts mismatch: have %+v, want %+v", have, want)
+	}
+	// Removing subscriptions and filters is reflected in the counts
+	txs.Unsubscribe()
+	api.UninstallFilter(fid)
+
+	if have, want := admin.SubscriptionCount(), (SubscriptionCounts{NewHeads: 1, Logs: 2}); have != want {
+		t.Fatalf("subscription counts mismatch: have %+v, want %+v", have, want)
+	}
+}
+
 func flattenLogs(pl [][]*types.Log) []*types.Log {
 	var logs []*types.Log
 	for _, l := range pl {
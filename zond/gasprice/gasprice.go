@@ -18,6 +18,7 @@ package gasprice
 
 import (
 	"context"
+	"fmt"
 	"math/big"
 	"sync"
 
@@ -65,6 +66,7 @@ type Oracle struct {
 	backend     OracleBackend
 	lastHead    common.Hash
 	lastPrice   *big.Int
+	lastSamples []*big.Int // Sorted tips sampled for lastHead
 	maxPrice    *big.Int
 	ignorePrice *big.Int
 	cacheLock   sync.RWMutex
@@ -168,6 +170,57 @@ func (oracle *Oracle) SuggestTipCap(ctx context.Context) (*big.Int, error) {
 	if headHash == lastHead {
 		return new(big.Int).Set(lastPrice), nil
 	}
+	samples, err := oracle.sampleTips(ctx, head, lastPrice)
+	if err != nil {
+		return new(big.Int).Set(lastPrice), err
+	}
+	price := oracle.pickTip(samples, oracle.percentile, lastPrice)
+
+	oracle.cacheLock.Lock()
+	oracle.lastHead = headHash
+	oracle.lastPrice = price
+	oracle.lastSamples = samples
+	oracle.cacheLock.Unlock()
+
+	return new(big.Int).Set(price), nil
+}
+
+// SuggestTipCapAtPercentile returns the tip cap at the given percentile of the
+// tips sampled from recent blocks, capped at the configured maximum price. The
+// samples are shared with SuggestTipCap, so a higher percentile never results
+// in a lower tip for the same chain head.
+func (oracle *Oracle) SuggestTipCapAtPercentile(ctx context.Context, percentile int) (*big.Int, error) {
+	if percentile < 0 || percentile > 100 {
+		return nil, fmt.Errorf("invalid percentile: %d", percentile)
+	}
+	// Make sure the samples of the current head are available
+	if _, err := oracle.SuggestTipCap(ctx); err != nil {
+		return nil, err
+	}
+	oracle.cacheLock.RLock()
+	samples, lastPrice := oracle.lastSamples, oracle.lastPrice
+	oracle.cacheLock.RUnlock()
+
+	return new(big.Int).Set(oracle.pickTip(samples, percentile, lastPrice)), nil
+}
+
+// pickTip returns the tip at the given percentile of the sorted samples, or the
+// fallback price if there are none. The result is capped at the maximum price.
+func (oracle *Oracle) pickTip(samples []*big.Int, percentile int, fallback *big.Int) *big.Int {
+	price := fallback
+	if len(samples) > 0 {
+		price = samples[(len(samples)-1)*percentile/100]
+	}
+	if price.Cmp(oracle.maxPrice) > 0 {
+		price = new(big.Int).Set(oracle.maxPrice)
+	}
+	return price
+}
+
+// sampleTips collects the lowest tips paid in the blocks leading up to the given
+// head, returning them in ascending order. Blocks without meaningful values are
+// sampled as the last suggested price.
+func (oracle *Oracle) sampleTips(ctx context.Context, head *types.Header, lastPrice *big.Int) ([]*big.Int, error) {
 	var (
 		sent, exp int
 		number    = head.Number.Uint64()
@@ -185,7 +238,7 @@ func (oracle *Oracle) SuggestTipCap(ctx context.Context) (*big.Int, error) {
 		res := <-result
 		if res.err != nil {
 			close(quit)
-			return new(big.Int).Set(lastPrice), res.err
+			return nil, res.err
 		}
 		exp--
 		// Nothing returned. There are two special cases here:
@@ -206,20 +259,8 @@ func (oracle *Oracle) SuggestTipCap(ctx context.Context) (*big.Int, error) {
 		}
 		results = append(results, res.values...)
 	}
-	price := lastPrice
-	if len(results) > 0 {
-		slices.SortFunc(results, func(a, b *big.Int) int { return a.Cmp(b) })
-		price = results[(len(results)-1)*oracle.percentile/100]
-	}
-	if price.Cmp(oracle.maxPrice) > 0 {
-		price = new(big.Int).Set(oracle.maxPrice)
-	}
-	oracle.cacheLock.Lock()
-	oracle.lastHead = headHash
-	oracle.lastPrice = price
-	oracle.cacheLock.Unlock()
-
-	return new(big.Int).Set(price), nil
+	slices.SortFunc(results, func(a, b *big.Int) int { return a.Cmp(b) })
+	return results, nil
 }
 
 type results struct {
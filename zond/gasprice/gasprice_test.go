@@ -198,3 +198,45 @@ func TestSuggestTipCap(t *testing.T) {
 		}
 	}
 }
+
+func TestSuggestTipCapAtPercentile(t *testing.T) {
+	config := Config{
+		Blocks:     3,
+		Percentile: 60,
+		Default:    big.NewInt(params.GWei),
+	}
+	backend := newTestBackend(t, false)
+	defer backend.teardown()
+	oracle := NewOracle(backend, config)
+
+	// The gas price sampled is: 32G, 31G, 30G, 29G, 28G, 27G
+	var (
+		percentiles = []int{0, 30, 60, 90, 100}
+		expect      = []int64{27, 28, 30, 31, 32}
+		last        *big.Int
+	)
+	for i, percentile := range percentiles {
+		got, err := oracle.SuggestTipCapAtPercentile(context.Background(), percentile)
+		if err != nil {
+			t.Fatalf("Failed to retrieve recommended tip at percentile %d: %v", percentile, err)
+		}
+		if want := big.NewInt(expect[i] * params.GWei); got.Cmp(want) != 0 {
+			t.Fatalf("Tip mismatch at percentile %d, want %d, got %d", percentile, want, got)
+		}
+		if last != nil && got.Cmp(last) < 0 {
+			t.Fatalf("Tip at percentile %d lower than at percentile %d: %d < %d", percentile, percentiles[i-1], got, last)
+		}
+		last = got
+	}
+	// The default percentile should match the plain suggestion
+	tip, err := oracle.SuggestTipCap(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to retrieve recommended tip: %v", err)
+	}
+	if want := big.NewInt(30 * params.GWei); tip.Cmp(want) != 0 {
+		t.Fatalf("Tip mismatch, want %d, got %d", want, tip)
+	}
+	if _, err := oracle.SuggestTipCapAtPercentile(context.Background(), 101); err == nil {
+		t.Fatal("Expected error for invalid percentile")
+	}
+}
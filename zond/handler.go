@@ -87,6 +87,7 @@ type handlerConfig struct {
 	TxPool         txPool                 // Transaction pool to propagate from
 	Network        uint64                 // Network identifier to adfvertise
 	Sync           downloader.SyncMode    // Whether to snap or full sync
+	SnapRangeSize  uint64                 // Maximum bytes per snap account range request (0 = default)
 	BloomCache     uint64                 // Megabytes to alloc for snap sync bloom
 	EventMux       *event.TypeMux         // Legacy event mux, deprecate for `feed`
 	RequiredBlocks map[uint64]common.Hash // Hard coded map of required block hashes for sync challenges
@@ -180,6 +181,7 @@ func newHandler(config *handlerConfig) (*handler, error) {
 	}
 	// Construct the downloader (long sync)
 	h.downloader = downloader.New(config.Database, h.eventMux, h.chain, nil, h.removePeer, success)
+	h.downloader.SnapSyncer.SetAccountRangeSize(config.SnapRangeSize)
 
 	fetchTx := func(peer string, hashes []common.Hash) error {
 		p := h.peers.peer(peer)
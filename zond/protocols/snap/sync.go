@@ -422,6 +422,8 @@ type Syncer struct {
 	peerDrop *event.Feed         // Event feed to react to peers dropping
 	rates    *msgrate.Trackers   // Message throughput rates for peers
 
+	accountRangeSize uint64 // Maximum number of bytes to request per account range
+
 	// Request tracking during syncing phase
 	statelessPeers map[string]struct{} // Peers that failed to deliver state data
 	accountIdlers  map[string]struct{} // Peers that aren't serving account requests
@@ -488,6 +490,8 @@ func NewSyncer(db zonddb.KeyValueStore, scheme string) *Syncer {
 		rates:    msgrate.NewTrackers(log.New("proto", "snap")),
 		update:   make(chan struct{}, 1),
 
+		accountRangeSize: maxRequestSize,
+
 		accountIdlers:  make(map[string]struct{}),
 		storageIdlers:  make(map[string]struct{}),
 		bytecodeIdlers: make(map[string]struct{}),
@@ -508,6 +512,19 @@ func NewSyncer(db zonddb.KeyValueStore, scheme string) *Syncer {
 	}
 }
 
+// SetAccountRangeSize sets the maximum number of bytes to request from a remote
+// peer in a single account range request. Zero restores the default. It should
+// be called before syncing starts.
+func (s *Syncer) SetAccountRangeSize(size uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if size == 0 {
+		size = maxRequestSize
+	}
+	s.accountRangeSize = size
+}
+
 // Register injects a new data source into the syncer's peerset.
 func (s *Syncer) Register(peer SyncPeer) error {
 	// Make sure the peer is not registered yet
@@ -1002,21 +1019,21 @@ func (s *Syncer) assignAccountTasks(success chan *accountResponse, fail chan *ac
 		delete(s.accountIdlers, idle)
 
 		s.pend.Add(1)
-		go func(root common.Hash) {
+		go func(root common.Hash, maxBytes uint64) {
 			defer s.pend.Done()
 
 			// Attempt to send the remote request and revert if it fails
-			if cap > maxRequestSize {
-				cap = maxRequestSize
-			}
 			if cap < minRequestSize { // Don't bother with peers below a bare minimum performance
 				cap = minRequestSize
 			}
+			if uint64(cap) > maxBytes {
+				cap = int(maxBytes)
+			}
 			if err := peer.RequestAccountRange(reqid, root, req.origin, req.limit, uint64(cap)); err != nil {
 				peer.Log().Debug("Failed to request account range", "err", err)
 				s.scheduleRevertAccountRequest(req)
 			}
-		}(s.root)
+		}(s.root, s.accountRangeSize)
 
 		// Inject the request into the task to block further assignments
 		task.req = req
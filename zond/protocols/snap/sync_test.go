@@ -672,6 +672,58 @@ func testSync(t *testing.T, scheme string) {
 	verifyTrie(scheme, syncer.db, sourceAccountTrie.Hash(), t)
 }
 
+// TestSyncAccountRangeSize tests that account range requests are capped at the
+// configured range size.
+func TestSyncAccountRangeSize(t *testing.T) {
+	t.Parallel()
+
+	testSyncAccountRangeSize(t, rawdb.HashScheme)
+	testSyncAccountRangeSize(t, rawdb.PathScheme)
+}
+
+func testSyncAccountRangeSize(t *testing.T, scheme string) {
+	var (
+		once   sync.Once
+		cancel = make(chan struct{})
+		term   = func() {
+			once.Do(func() {
+				close(cancel)
+			})
+		}
+		lock      sync.Mutex
+		requested []uint64
+		rangeSize = uint64(16 * 1024)
+	)
+	nodeScheme, sourceAccountTrie, elems := makeAccountTrieNoStorage(100, scheme)
+
+	source := newTestPeer("source", t, term)
+	source.accountTrie = sourceAccountTrie.Copy()
+	source.accountValues = elems
+	source.accountRequestHandler = func(t *testPeer, id uint64, root common.Hash, origin common.Hash, limit common.Hash, cap uint64) error {
+		lock.Lock()
+		requested = append(requested, cap)
+		lock.Unlock()
+		return defaultAccountRequestHandler(t, id, root, origin, limit, cap)
+	}
+	syncer := setupSyncer(nodeScheme, source)
+	syncer.SetAccountRangeSize(rangeSize)
+	if err := syncer.Sync(sourceAccountTrie.Hash(), cancel); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	verifyTrie(scheme, syncer.db, sourceAccountTrie.Hash(), t)
+
+	lock.Lock()
+	defer lock.Unlock()
+	if len(requested) == 0 {
+		t.Fatal("no account ranges requested")
+	}
+	for i, size := range requested {
+		if size != rangeSize {
+			t.Errorf("request %d: range size mismatch: have %d, want %d", i, size, rangeSize)
+		}
+	}
+}
+
 // TestSyncTinyTriePanic tests a basic sync with one peer, and a tiny trie. This caused a
 // panic within the prover
 func TestSyncTinyTriePanic(t *testing.T) {
@@ -35,6 +35,7 @@ import (
 	"github.com/theQRL/go-zond/core/state"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/core/vm"
+	"github.com/theQRL/go-zond/crypto"
 	"github.com/theQRL/go-zond/internal/zondapi"
 	"github.com/theQRL/go-zond/log"
 	"github.com/theQRL/go-zond/params"
@@ -67,7 +68,10 @@ const (
 	maximumPendingTraceStates = 128
 )
 
-var errTxNotFound = errors.New("transaction not found")
+var (
+	errTxNotFound          = errors.New("transaction not found")
+	errTraceGasCapExceeded = errors.New("gas exceeds trace gas cap")
+)
 
 // StateReleaseFunc is used to deallocate resources held by constructing a
 // historical state for tracing purposes.
@@ -82,6 +86,7 @@ type Backend interface {
 	BlockByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Block, error)
 	GetTransaction(ctx context.Context, txHash common.Hash) (*types.Transaction, common.Hash, uint64, uint64, error)
 	RPCGasCap() uint64
+	RPCTraceGasCap() uint64
 	ChainConfig() *params.ChainConfig
 	Engine() consensus.Engine
 	ChainDb() zonddb.Database
@@ -150,6 +155,9 @@ type TraceConfig struct {
 	Tracer  *string
 	Timeout *string
 	Reexec  *uint64
+	// MaxDepth hides call frames nested deeper than the given call depth from
+	// the tracer, the top level call having depth 1 (0 = unlimited).
+	MaxDepth int
 	// Config specific to given tracer. Note struct logger
 	// config are historically embedded in main object.
 	TracerConfig json.RawMessage
@@ -619,6 +627,112 @@ func (api *API) traceBlock(ctx context.Context, block *types.Block, config *Trac
 	return results, nil
 }
 
+// TraceBlockByAddress returns the structured logs created during the execution
+// of the transactions in the given block which interact with the given address,
+// either as sender, recipient or through internal calls and state accesses.
+// Transactions not touching the address are executed but left out of the result.
+func (api *API) TraceBlockByAddress(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, address common.Address, config *TraceConfig) ([]*txTraceResult, error) {
+	var (
+		err   error
+		block *types.Block
+	)
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		block, err = api.blockByHash(ctx, hash)
+	} else if number, ok := blockNrOrHash.Number(); ok {
+		block, err = api.blockByNumber(ctx, number)
+	} else {
+		return nil, errors.New("invalid arguments; neither block nor hash specified")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return api.traceBlockByAddress(ctx, block, address, config)
+}
+
+// traceBlockByAddress executes all the transactions contained within the block,
+// tracing only the ones interacting with the given address. Transactions whose
+// sender or recipient is not the address are first executed with an access list
+// tracer to detect internal interactions, and re-executed on a copy of their
+// prestate with the requested tracer if the address was touched.
+func (api *API) traceBlockByAddress(ctx context.Context, block *types.Block, address common.Address, config *TraceConfig) ([]*txTraceResult, error) {
+	if block.NumberU64() == 0 {
+		return nil, errors.New("genesis is not traceable")
+	}
+	// Prepare base state
+	parent, err := api.blockByNumberAndHash(ctx, rpc.BlockNumber(block.NumberU64()-1), block.ParentHash())
+	if err != nil {
+		return nil, err
+	}
+	reexec := defaultTraceReexec
+	if config != nil && config.Reexec != nil {
+		reexec = *config.Reexec
+	}
+	statedb, release, err := api.backend.StateAtBlock(ctx, parent, reexec, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var (
+		blockHash   = block.Hash()
+		blockCtx    = core.NewEVMBlockContext(block.Header(), api.chainContext(ctx), nil)
+		chainConfig = api.backend.ChainConfig()
+		signer      = types.MakeSigner(chainConfig)
+		precompiles = vm.ActivePrecompiles(chainConfig.Rules(block.Number(), block.Time()))
+		results     = []*txTraceResult{}
+	)
+	for i, tx := range block.Transactions() {
+		msg, _ := core.TransactionToMessage(tx, signer, block.BaseFee())
+		txctx := &Context{
+			BlockHash:   blockHash,
+			BlockNumber: block.Number(),
+			TxIndex:     i,
+			TxHash:      tx.Hash(),
+		}
+		var to common.Address
+		if msg.To != nil {
+			to = *msg.To
+		} else {
+			to = crypto.CreateAddress(msg.From, msg.Nonce)
+		}
+		// Trace the transaction directly if the address is the sender or recipient
+		if msg.From == address || to == address {
+			res, err := api.traceTx(ctx, msg, txctx, blockCtx, statedb, config)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, &txTraceResult{TxHash: tx.Hash(), Result: res})
+			statedb.Finalise(true)
+			continue
+		}
+		// Otherwise execute it while collecting the touched accounts, keeping the
+		// prestate around in case it needs to be traced
+		var (
+			prestate = statedb.Copy()
+			tracer   = logger.NewAccessListTracer(nil, msg.From, to, precompiles)
+			vmenv    = vm.NewEVM(blockCtx, core.NewEVMTxContext(msg), statedb, chainConfig, vm.Config{Tracer: tracer, NoBaseFee: true})
+		)
+		statedb.SetTxContext(tx.Hash(), i)
+		if _, err := core.ApplyMessage(vmenv, msg, new(core.GasPool).AddGas(msg.GasLimit)); err != nil {
+			return nil, fmt.Errorf("transaction %#x failed: %w", tx.Hash(), err)
+		}
+		statedb.Finalise(true)
+
+		for _, tuple := range tracer.AccessList() {
+			if tuple.Address != address {
+				continue
+			}
+			res, err := api.traceTx(ctx, msg, txctx, blockCtx, prestate, config)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, &txTraceResult{TxHash: tx.Hash(), Result: res})
+			break
+		}
+	}
+	return results, nil
+}
+
 // traceBlockParallel is for tracers that have a high overhead (read JS tracers). One thread
 // runs along and executes txes without tracing enabled to generate their prestate.
 // Worker threads take the tasks and the prestate and trace them.
@@ -856,6 +970,51 @@ func (api *API) TraceTransaction(ctx context.Context, hash common.Hash, config *
 	return api.traceTx(ctx, msg, txctx, vmctx, statedb, config)
 }
 
+// AccessListOf replays the given mined transaction and returns the access list
+// it actually used, i.e. the accounts and storage slots touched during its
+// execution. Like zond_createAccessList, the sender, the recipient and the
+// precompiles are left out unless storage slots of theirs were accessed.
+func (api *API) AccessListOf(ctx context.Context, hash common.Hash) (types.AccessList, error) {
+	tx, blockHash, blockNumber, index, err := api.backend.GetTransaction(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	// Only mined txes are supported
+	if tx == nil {
+		return nil, errTxNotFound
+	}
+	// It shouldn't happen in practice.
+	if blockNumber == 0 {
+		return nil, errors.New("genesis is not traceable")
+	}
+	block, err := api.blockByNumberAndHash(ctx, rpc.BlockNumber(blockNumber), blockHash)
+	if err != nil {
+		return nil, err
+	}
+	msg, vmctx, statedb, release, err := api.backend.StateAtTransaction(ctx, block, int(index), defaultTraceReexec)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var to common.Address
+	if msg.To != nil {
+		to = *msg.To
+	} else {
+		to = crypto.CreateAddress(msg.From, msg.Nonce)
+	}
+	var (
+		precompiles = vm.ActivePrecompiles(api.backend.ChainConfig().Rules(block.Number(), block.Time()))
+		tracer      = logger.NewAccessListTracer(nil, msg.From, to, precompiles)
+		vmenv       = vm.NewEVM(vmctx, core.NewEVMTxContext(msg), statedb, api.backend.ChainConfig(), vm.Config{Tracer: tracer, NoBaseFee: true})
+	)
+	statedb.SetTxContext(hash, int(index))
+	if _, err := core.ApplyMessage(vmenv, msg, new(core.GasPool).AddGas(msg.GasLimit)); err != nil {
+		return nil, fmt.Errorf("tracing failed: %w", err)
+	}
+	return tracer.AccessList(), nil
+}
+
 // TraceCall lets you trace a given zond_call. It collects the structured logs
 // created during the execution of EVM if the given transaction was added on
 // top of the provided block and returns them as a JSON object.
@@ -902,8 +1061,16 @@ func (api *API) TraceCall(ctx context.Context, args zondapi.TransactionArgs, blo
 		}
 		config.BlockOverrides.Apply(&vmctx)
 	}
+	// Reject calls above the trace gas cap instead of silently capping them
+	gasCap := api.backend.RPCGasCap()
+	if traceCap := api.backend.RPCTraceGasCap(); traceCap != 0 {
+		if args.Gas != nil && uint64(*args.Gas) > traceCap {
+			return nil, fmt.Errorf("%w: have %d, max %d", errTraceGasCapExceeded, uint64(*args.Gas), traceCap)
+		}
+		gasCap = traceCap
+	}
 	// Execute the trace
-	msg, err := args.ToMessage(api.backend.RPCGasCap(), block.BaseFee())
+	msg, err := args.ToMessage(gasCap, block.BaseFee())
 	if err != nil {
 		return nil, err
 	}
@@ -936,6 +1103,9 @@ func (api *API) traceTx(ctx context.Context, message *core.Message, txctx *Conte
 			return nil, err
 		}
 	}
+	if config.MaxDepth > 0 {
+		tracer = newDepthLimiter(tracer, config.MaxDepth)
+	}
 	vmenv := vm.NewEVM(vmctx, txContext, statedb, api.backend.ChainConfig(), vm.Config{Tracer: tracer, NoBaseFee: true})
 
 	// Define a meaningful timeout of a single transaction trace
@@ -971,6 +1141,10 @@ func APIs(backend Backend) []rpc.API {
 			Namespace: "debug",
 			Service:   NewAPI(backend),
 		},
+		{
+			Namespace: "zond",
+			Service:   NewGasAPI(backend),
+		},
 	}
 }
 
@@ -0,0 +1,131 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/common/hexutil"
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/vm"
+	"github.com/theQRL/go-zond/rpc"
+)
+
+// GasAPI exposes the gas accounting of mined transactions, derived by replaying
+// them on top of their parent state.
+type GasAPI struct {
+	api *API
+}
+
+// NewGasAPI creates a new API definition for the gas accounting methods of the
+// Zond service.
+func NewGasAPI(backend Backend) *GasAPI {
+	return &GasAPI{api: NewAPI(backend)}
+}
+
+// GasBreakdown is the gas accounting of a single transaction. The total gas used
+// equals the intrinsic gas plus the execution gas minus the refund.
+type GasBreakdown struct {
+	Intrinsic hexutil.Uint64 `json:"intrinsic"`
+	Execution hexutil.Uint64 `json:"execution"`
+	Refund    hexutil.Uint64 `json:"refund"`
+	Total     hexutil.Uint64 `json:"total"`
+}
+
+// GetGasBreakdown replays the given mined transaction and returns how its gas
+// usage is split between intrinsic cost, execution and refund.
+func (api *GasAPI) GetGasBreakdown(ctx context.Context, hash common.Hash) (*GasBreakdown, error) {
+	tx, blockHash, blockNumber, index, err := api.api.backend.GetTransaction(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	// Only mined txes are supported
+	if tx == nil {
+		return nil, errTxNotFound
+	}
+	// It shouldn't happen in practice.
+	if blockNumber == 0 {
+		return nil, errors.New("genesis is not traceable")
+	}
+	block, err := api.api.blockByNumberAndHash(ctx, rpc.BlockNumber(blockNumber), blockHash)
+	if err != nil {
+		return nil, err
+	}
+	msg, vmctx, statedb, release, err := api.api.backend.StateAtTransaction(ctx, block, int(index), defaultTraceReexec)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	intrinsic, err := core.IntrinsicGas(msg.Data, msg.AccessList, msg.To == nil)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		tracer = new(gasBreakdownTracer)
+		vmenv  = vm.NewEVM(vmctx, core.NewEVMTxContext(msg), statedb, api.api.backend.ChainConfig(), vm.Config{Tracer: tracer, NoBaseFee: true})
+	)
+	statedb.SetTxContext(hash, int(index))
+	if _, err := core.ApplyMessage(vmenv, msg, new(core.GasPool).AddGas(msg.GasLimit)); err != nil {
+		return nil, fmt.Errorf("tracing failed: %w", err)
+	}
+	total := tracer.gasLimit - tracer.restGas
+	return &GasBreakdown{
+		Intrinsic: hexutil.Uint64(intrinsic),
+		Execution: hexutil.Uint64(tracer.execution),
+		Refund:    hexutil.Uint64(intrinsic + tracer.execution - total),
+		Total:     hexutil.Uint64(total),
+	}, nil
+}
+
+// gasBreakdownTracer is a vm.EVMLogger which records the gas consumed by the top
+// level call frame and the gas left over after refunds.
+type gasBreakdownTracer struct {
+	gasLimit  uint64 // Gas limit of the transaction
+	execution uint64 // Gas used by the top call frame, excluding intrinsic gas
+	restGas   uint64 // Gas left over after refunds were applied
+}
+
+func (t *gasBreakdownTracer) CaptureTxStart(gasLimit uint64) {
+	t.gasLimit = gasLimit
+}
+
+func (t *gasBreakdownTracer) CaptureTxEnd(restGas uint64) {
+	t.restGas = restGas
+}
+
+func (t *gasBreakdownTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+}
+
+func (t *gasBreakdownTracer) CaptureEnd(output []byte, gasUsed uint64, err error) {
+	t.execution = gasUsed
+}
+
+func (t *gasBreakdownTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+}
+
+func (t *gasBreakdownTracer) CaptureExit(output []byte, gasUsed uint64, err error) {}
+
+func (t *gasBreakdownTracer) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+}
+
+func (t *gasBreakdownTracer) CaptureFault(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+}
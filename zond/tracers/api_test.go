@@ -59,6 +59,8 @@ type testBackend struct {
 
 	refHook func() // Hook is invoked when the requested state is referenced
 	relHook func() // Hook is invoked when the requested state is released
+
+	traceGasCap uint64 // Gas cap enforced on trace calls
 }
 
 // testBackend creates a new test backend. OBS: After test is done, teardown must be
@@ -122,6 +124,10 @@ func (b *testBackend) RPCGasCap() uint64 {
 	return 25000000
 }
 
+func (b *testBackend) RPCTraceGasCap() uint64 {
+	return b.traceGasCap
+}
+
 func (b *testBackend) ChainConfig() *params.ChainConfig {
 	return b.chainConfig
 }
@@ -320,6 +326,250 @@ func TestTraceCall(t *testing.T) {
 	}
 }
 
+func TestTraceCallGasCap(t *testing.T) {
+	t.Parallel()
+
+	accounts := newAccounts(2)
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: core.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+		},
+	}
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {})
+	defer backend.teardown()
+	backend.traceGasCap = 100000
+	api := NewAPI(backend)
+
+	latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	call := func(gas uint64) error {
+		_, err := api.TraceCall(context.Background(), zondapi.TransactionArgs{
+			From:  &accounts[0].addr,
+			To:    &accounts[1].addr,
+			Gas:   (*hexutil.Uint64)(&gas),
+			Value: (*hexutil.Big)(big.NewInt(1000)),
+		}, latest, nil)
+		return err
+	}
+	if err := call(backend.traceGasCap); err != nil {
+		t.Fatalf("trace call within cap failed: %v", err)
+	}
+	if err := call(backend.traceGasCap + 1); !errors.Is(err, errTraceGasCapExceeded) {
+		t.Fatalf("trace call above cap: have %v, want %v", err, errTraceGasCapExceeded)
+	}
+}
+
+func TestTraceCallMaxDepth(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(1)
+		caller   = common.HexToAddress("0x00000000000000000000000000000000000000c0")
+		callee   = common.HexToAddress("0x00000000000000000000000000000000000000c1")
+	)
+	// The caller invokes the callee with no arguments, the callee pushes and pops
+	// a value before stopping.
+	code := common.FromHex("6000600060006000600073" + "00000000000000000000000000000000000000c1" + "5af100")
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: core.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+			caller:           {Balance: common.Big0, Code: code},
+			callee:           {Balance: common.Big0, Code: common.FromHex("60015000")},
+		},
+	}
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {})
+	defer backend.teardown()
+	api := NewAPI(backend)
+
+	trace := func(maxDepth int) *logger.ExecutionResult {
+		result, err := api.TraceCall(context.Background(), zondapi.TransactionArgs{
+			From: &accounts[0].addr,
+			To:   &caller,
+		}, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber), &TraceCallConfig{TraceConfig: TraceConfig{MaxDepth: maxDepth}})
+		if err != nil {
+			t.Fatalf("failed to trace call: %v", err)
+		}
+		var res logger.ExecutionResult
+		if err := json.Unmarshal(result.(json.RawMessage), &res); err != nil {
+			t.Fatalf("failed to unmarshal result: %v", err)
+		}
+		return &res
+	}
+	maxDepth := func(res *logger.ExecutionResult) int {
+		var depth int
+		for _, log := range res.StructLogs {
+			if log.Depth > depth {
+				depth = log.Depth
+			}
+		}
+		return depth
+	}
+	full, limited := trace(0), trace(1)
+	if have := maxDepth(full); have != 2 {
+		t.Fatalf("unlimited trace depth mismatch: have %d, want 2", have)
+	}
+	if have := maxDepth(limited); have != 1 {
+		t.Fatalf("limited trace depth mismatch: have %d, want 1", have)
+	}
+	if len(limited.StructLogs) >= len(full.StructLogs) {
+		t.Fatalf("limited trace not truncated: have %d logs, full trace %d", len(limited.StructLogs), len(full.StructLogs))
+	}
+}
+
+// Tests that the gas refund is still reported if the call depth is limited.
+func TestTraceCallMaxDepthRefund(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(1)
+		contract = common.HexToAddress("0x00000000000000000000000000000000000000c0")
+	)
+	// The contract clears a storage slot, earning a refund.
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: core.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+			contract: {
+				Balance: common.Big0,
+				Code:    common.FromHex("600060015500"),
+				Storage: map[common.Hash]common.Hash{common.HexToHash("0x01"): common.HexToHash("0x01")},
+			},
+		},
+	}
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {})
+	defer backend.teardown()
+	api := NewAPI(backend)
+
+	result, err := api.TraceCall(context.Background(), zondapi.TransactionArgs{
+		From: &accounts[0].addr,
+		To:   &contract,
+	}, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber), &TraceCallConfig{TraceConfig: TraceConfig{
+		Config:   &logger.Config{EnableRefund: true},
+		MaxDepth: 1,
+	}})
+	if err != nil {
+		t.Fatalf("failed to trace call: %v", err)
+	}
+	var res logger.ExecutionResult
+	if err := json.Unmarshal(result.(json.RawMessage), &res); err != nil {
+		t.Fatalf("failed to unmarshal result: %v", err)
+	}
+	if res.Refund == nil {
+		t.Fatal("refund missing from depth limited trace")
+	}
+	if res.Refund.Uncapped != params.SstoreClearsScheduleRefundEIP3529 || res.Refund.Applied == 0 {
+		t.Fatalf("refund mismatch: have %+v, want uncapped %d and a non-zero applied refund", res.Refund, params.SstoreClearsScheduleRefundEIP3529)
+	}
+}
+
+func TestGasBreakdown(t *testing.T) {
+	t.Parallel()
+
+	// Initialize a test account and a contract which sets slot 1 and clears slot 0
+	var (
+		accounts = newAccounts(1)
+		contract = common.HexToAddress("0x00000000000000000000000000000000deadbeef")
+		genesis  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: core.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+				contract: {
+					Balance: common.Big0,
+					Code:    common.FromHex("0x6001600155600060005500"),
+					Storage: map[common.Hash]common.Hash{{}: common.BigToHash(common.Big1)},
+				},
+			},
+		}
+		signer = types.ShanghaiSigner{ChainId: big.NewInt(0)}
+		target common.Hash
+	)
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(uint64(i), contract, common.Big0, 100000, b.BaseFee(), nil), signer, accounts[0].key)
+		b.AddTx(tx)
+		target = tx.Hash()
+	})
+	defer backend.chain.Stop()
+
+	have, err := NewGasAPI(backend).GetGasBreakdown(context.Background(), target)
+	if err != nil {
+		t.Fatalf("failed to get gas breakdown: %v", err)
+	}
+	receipts := backend.chain.GetReceiptsByHash(backend.chain.GetBlockByNumber(1).Hash())
+	if len(receipts) != 1 {
+		t.Fatalf("unexpected number of receipts: %d", len(receipts))
+	}
+	if uint64(have.Intrinsic) != params.TxGas {
+		t.Errorf("intrinsic gas mismatch: have %d, want %d", have.Intrinsic, params.TxGas)
+	}
+	if have.Refund == 0 {
+		t.Errorf("expected non-zero refund for cleared storage slot")
+	}
+	if uint64(have.Total) != receipts[0].GasUsed {
+		t.Errorf("total gas mismatch: have %d, want %d", have.Total, receipts[0].GasUsed)
+	}
+	if sum := uint64(have.Intrinsic + have.Execution - have.Refund); sum != receipts[0].GasUsed {
+		t.Errorf("gas components mismatch: have %d, want %d", sum, receipts[0].GasUsed)
+	}
+}
+
+func TestAccessListOf(t *testing.T) {
+	t.Parallel()
+
+	// Initialize a test account and a contract which reads slot 2, writes slot 5
+	// and queries the balance of another account
+	var (
+		accounts = newAccounts(1)
+		contract = common.HexToAddress("0x00000000000000000000000000000000deadbeef")
+		other    = common.HexToAddress("0x000000000000000000000000000000000000cafe")
+		genesis  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: core.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+				contract: {
+					Balance: common.Big0,
+					Code:    common.FromHex("0x60025450600160055561cafe315000"),
+				},
+			},
+		}
+		signer = types.ShanghaiSigner{ChainId: big.NewInt(0)}
+		target common.Hash
+	)
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(uint64(i), contract, common.Big0, 100000, b.BaseFee(), nil), signer, accounts[0].key)
+		b.AddTx(tx)
+		target = tx.Hash()
+	})
+	defer backend.chain.Stop()
+
+	api := NewAPI(backend)
+	have, err := api.AccessListOf(context.Background(), target)
+	if err != nil {
+		t.Fatalf("failed to get access list: %v", err)
+	}
+	want := map[common.Address][]common.Hash{
+		contract: {common.BigToHash(big.NewInt(2)), common.BigToHash(big.NewInt(5))},
+		other:    nil,
+	}
+	if len(have) != len(want) {
+		t.Fatalf("access list length mismatch: have %d, want %d (%v)", len(have), len(want), have)
+	}
+	for _, tuple := range have {
+		slots, ok := want[tuple.Address]
+		if !ok {
+			t.Fatalf("unexpected address in access list: %v", tuple.Address)
+		}
+		keys := slices.Clone(tuple.StorageKeys)
+		slices.SortFunc(keys, common.Hash.Cmp)
+		if len(keys) != len(slots) || (len(keys) > 0 && !reflect.DeepEqual(keys, slots)) {
+			t.Fatalf("storage keys mismatch for %v: have %v, want %v", tuple.Address, keys, slots)
+		}
+	}
+	if _, err := api.AccessListOf(context.Background(), common.Hash{0x01}); err != errTxNotFound {
+		t.Fatalf("unexpected error for unknown transaction: have %v, want %v", err, errTxNotFound)
+	}
+}
+
 func TestTraceTransaction(t *testing.T) {
 	t.Parallel()
 
@@ -451,6 +701,79 @@ func TestTraceBlock(t *testing.T) {
 	}
 }
 
+func TestTraceBlockByAddress(t *testing.T) {
+	t.Parallel()
+
+	// Initialize test accounts and a contract which calls into another account
+	var (
+		accounts = newAccounts(3)
+		contract = common.HexToAddress("0x00000000000000000000000000000000deadbeef")
+		other    = common.HexToAddress("0x000000000000000000000000000000000000cafe")
+		genesis  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: core.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+				accounts[1].addr: {Balance: big.NewInt(params.Ether)},
+				accounts[2].addr: {Balance: big.NewInt(params.Ether)},
+				contract: {
+					Balance: common.Big0,
+					Code:    common.FromHex("0x6000600060006000600061cafe5af100"),
+				},
+			},
+		}
+		signer = types.ShanghaiSigner{ChainId: big.NewInt(0)}
+		hashes []common.Hash
+	)
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {
+		send := func(from Account, to common.Address, gas uint64) {
+			tx, _ := types.SignTx(types.NewTransaction(b.TxNonce(from.addr), to, big.NewInt(1000), gas, b.BaseFee(), nil), signer, from.key)
+			b.AddTx(tx)
+			hashes = append(hashes, tx.Hash())
+		}
+		send(accounts[0], accounts[1].addr, params.TxGas) // unrelated to other
+		send(accounts[0], contract, 100000)               // internal call into other
+		send(accounts[1], other, params.TxGas)            // direct transfer to other
+		send(accounts[2], accounts[0].addr, params.TxGas) // unrelated to other
+	})
+	defer backend.chain.Stop()
+	api := NewAPI(backend)
+
+	// Retrieve the full block traces to compare the filtered ones against
+	full, err := api.TraceBlockByNumber(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	var tests = []struct {
+		address common.Address
+		want    []int
+	}{
+		{other, []int{1, 2}},              // recipient and internal call
+		{accounts[1].addr, []int{0, 2}},   // recipient and sender
+		{accounts[2].addr, []int{3}},      // sender only
+		{contract, []int{1}},              // recipient only
+		{common.Address{0x01, 0x02}, nil}, // untouched
+	}
+	for i, tt := range tests {
+		have, err := api.TraceBlockByAddress(context.Background(), rpc.BlockNumberOrHashWithNumber(1), tt.address, nil)
+		if err != nil {
+			t.Fatalf("test %d: failed to trace block: %v", i, err)
+		}
+		if len(have) != len(tt.want) {
+			t.Fatalf("test %d: trace count mismatch: have %d, want %d", i, len(have), len(tt.want))
+		}
+		for j, index := range tt.want {
+			if have[j].TxHash != hashes[index] {
+				t.Errorf("test %d: trace %d hash mismatch: have %v, want %v", i, j, have[j].TxHash, hashes[index])
+			}
+			haveJSON, _ := json.Marshal(have[j])
+			wantJSON, _ := json.Marshal(full[index])
+			if string(haveJSON) != string(wantJSON) {
+				t.Errorf("test %d: trace %d mismatch: have\n%s\nwant\n%s", i, j, haveJSON, wantJSON)
+			}
+		}
+	}
+}
+
 func TestTracingWithOverrides(t *testing.T) {
 	t.Parallel()
 	// Initialize test accounts
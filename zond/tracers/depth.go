@@ -0,0 +1,78 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"math/big"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/vm"
+)
+
+// depthLimiter wraps a tracer, hiding all call frames nested deeper than a
+// given call depth from it. The top level call frame has depth 1.
+type depthLimiter struct {
+	Tracer
+	maxDepth int
+	depth    int
+}
+
+// newDepthLimiter wraps the given tracer so that it only observes call frames
+// up to and including maxDepth.
+func newDepthLimiter(tracer Tracer, maxDepth int) *depthLimiter {
+	return &depthLimiter{Tracer: tracer, maxDepth: maxDepth}
+}
+
+func (l *depthLimiter) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	l.depth = 1
+	l.Tracer.CaptureStart(env, from, to, create, input, gas, value)
+}
+
+func (l *depthLimiter) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	l.depth++
+	if l.depth <= l.maxDepth {
+		l.Tracer.CaptureEnter(typ, from, to, input, gas, value)
+	}
+}
+
+func (l *depthLimiter) CaptureExit(output []byte, gasUsed uint64, err error) {
+	if l.depth <= l.maxDepth {
+		l.Tracer.CaptureExit(output, gasUsed, err)
+	}
+	l.depth--
+}
+
+func (l *depthLimiter) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+	if depth <= l.maxDepth {
+		l.Tracer.CaptureState(pc, op, gas, cost, scope, rData, depth, err)
+	}
+}
+
+func (l *depthLimiter) CaptureFault(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+	if depth <= l.maxDepth {
+		l.Tracer.CaptureFault(pc, op, gas, cost, scope, depth, err)
+	}
+}
+
+// CaptureRefund implements vm.RefundLogger, forwarding the refund to the wrapped
+// tracer if it is interested in it. Refunds are reported per transaction, hence
+// not subject to the depth limit.
+func (l *depthLimiter) CaptureRefund(uncapped, applied uint64) {
+	if tracer, ok := l.Tracer.(vm.RefundLogger); ok {
+		tracer.CaptureRefund(uncapped, applied)
+	}
+}
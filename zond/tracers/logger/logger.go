@@ -52,6 +52,7 @@ type Config struct {
 	DisableStack     bool // disable stack capture
 	DisableStorage   bool // disable storage capture
 	EnableReturnData bool // enable return data capture
+	EnableRefund     bool // enable reporting of the uncapped and applied gas refund
 	Debug            bool // print output during capture end
 	Limit            int  // maximum length of output, but zero means unlimited
 	// Chain overrides, can be used to execute a trace using future fork rules
@@ -116,6 +117,9 @@ type StructLogger struct {
 	gasLimit uint64
 	usedGas  uint64
 
+	refundUncapped uint64 // Refund counter at the end of the transaction
+	refundApplied  uint64 // Refund credited after applying the refund cap
+
 	interrupt atomic.Bool // Atomic flag to signal execution interruption
 	reason    error       // Textual reason for the interruption
 }
@@ -137,6 +141,7 @@ func (l *StructLogger) Reset() {
 	l.output = make([]byte, 0)
 	l.logs = l.logs[:0]
 	l.err = nil
+	l.refundUncapped, l.refundApplied = 0, 0
 }
 
 // CaptureStart implements the EVMLogger interface to initialize the tracing operation.
@@ -247,12 +252,19 @@ func (l *StructLogger) GetResult() (json.RawMessage, error) {
 	if failed && l.err != vm.ErrExecutionReverted {
 		returnVal = ""
 	}
-	return json.Marshal(&ExecutionResult{
+	result := &ExecutionResult{
 		Gas:         l.usedGas,
 		Failed:      failed,
 		ReturnValue: returnVal,
 		StructLogs:  formatLogs(l.StructLogs()),
-	})
+	}
+	if l.cfg.EnableRefund {
+		result.Refund = &RefundResult{
+			Uncapped: l.refundUncapped,
+			Applied:  l.refundApplied,
+		}
+	}
+	return json.Marshal(result)
 }
 
 // Stop terminates execution of the tracer at the first opportune moment.
@@ -269,6 +281,13 @@ func (l *StructLogger) CaptureTxEnd(restGas uint64) {
 	l.usedGas = l.gasLimit - restGas
 }
 
+// CaptureRefund implements the vm.RefundLogger interface to record the gas
+// refund of the transaction before and after the refund cap is applied.
+func (l *StructLogger) CaptureRefund(uncapped, applied uint64) {
+	l.refundUncapped = uncapped
+	l.refundApplied = applied
+}
+
 // StructLogs returns the captured log entries.
 func (l *StructLogger) StructLogs() []StructLog { return l.logs }
 
@@ -278,6 +297,11 @@ func (l *StructLogger) Error() error { return l.err }
 // Output returns the VM return value captured by the trace.
 func (l *StructLogger) Output() []byte { return l.output }
 
+// Refund returns the uncapped and the applied gas refund captured by the trace.
+func (l *StructLogger) Refund() (uncapped, applied uint64) {
+	return l.refundUncapped, l.refundApplied
+}
+
 // WriteTrace writes a formatted trace to the given writer
 func WriteTrace(writer io.Writer, logs []StructLog) {
 	for _, log := range logs {
@@ -406,6 +430,14 @@ type ExecutionResult struct {
 	Failed      bool           `json:"failed"`
 	ReturnValue string         `json:"returnValue"`
 	StructLogs  []StructLogRes `json:"structLogs"`
+	Refund      *RefundResult  `json:"refund,omitempty"`
+}
+
+// RefundResult reports the gas refund accumulated by a transaction, and the
+// portion of it which was credited after capping it to a fraction of gas used.
+type RefundResult struct {
+	Uncapped uint64 `json:"uncapped"`
+	Applied  uint64 `json:"applied"`
 }
 
 // StructLogRes stores a structured log emitted by the EVM while replaying a
@@ -23,7 +23,10 @@ import (
 	"testing"
 
 	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/rawdb"
 	"github.com/theQRL/go-zond/core/state"
+	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/core/vm"
 	"github.com/theQRL/go-zond/params"
 )
@@ -75,6 +78,83 @@ func TestStoreCapture(t *testing.T) {
 	}
 }
 
+// Tests that the refund counter is reported both before and after the refund
+// cap is applied, for a transaction clearing storage slots.
+func TestRefundCapture(t *testing.T) {
+	var (
+		from     = common.Address{0x01}
+		contract = common.Address{0x02}
+		logger   = NewStructLogger(&Config{EnableRefund: true})
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	statedb.SetCode(contract, []byte{
+		byte(vm.PUSH1), 0x0, byte(vm.PUSH1), 0x0, byte(vm.SSTORE), // clear slot 0
+		byte(vm.PUSH1), 0x0, byte(vm.PUSH1), 0x1, byte(vm.SSTORE), // clear slot 1
+		byte(vm.STOP),
+	})
+	statedb.SetState(contract, common.Hash{0x0}, common.Hash{0x1})
+	statedb.SetState(contract, common.BigToHash(big.NewInt(1)), common.Hash{0x1})
+	statedb.Finalise(true)
+
+	blockCtx := vm.BlockContext{
+		CanTransfer: core.CanTransfer,
+		Transfer:    core.Transfer,
+		BlockNumber: new(big.Int),
+		BaseFee:     new(big.Int),
+		GasLimit:    params.GenesisGasLimit,
+		Random:      &common.Hash{},
+	}
+	msg := &core.Message{
+		From:              from,
+		To:                &contract,
+		Value:             new(big.Int),
+		GasLimit:          100000,
+		GasPrice:          new(big.Int),
+		GasFeeCap:         new(big.Int),
+		GasTipCap:         new(big.Int),
+		SkipAccountChecks: true,
+	}
+	env := vm.NewEVM(blockCtx, core.NewEVMTxContext(msg), statedb, params.TestChainConfig, vm.Config{Tracer: logger, NoBaseFee: true})
+	res, err := core.ApplyMessage(env, msg, new(core.GasPool).AddGas(msg.GasLimit))
+	if err != nil {
+		t.Fatalf("failed to apply message: %v", err)
+	}
+	if res.Failed() {
+		t.Fatalf("execution failed: %v", res.Err)
+	}
+	// Gas used before refunds: intrinsic + 4 pushes + 2 storage resets
+	var (
+		spent    = params.TxGas + 4*vm.GasFastestStep + 2*params.SstoreResetGasEIP2200
+		uncapped = 2 * params.SstoreClearsScheduleRefundEIP3529
+		applied  = spent / params.RefundQuotientEIP3529
+	)
+	if applied >= uncapped {
+		t.Fatalf("test refund is not capped: uncapped %d, applied %d", uncapped, applied)
+	}
+	haveUncapped, haveApplied := logger.Refund()
+	if haveUncapped != uncapped {
+		t.Errorf("uncapped refund mismatch: have %d, want %d", haveUncapped, uncapped)
+	}
+	if haveApplied != applied {
+		t.Errorf("applied refund mismatch: have %d, want %d", haveApplied, applied)
+	}
+	if res.UsedGas != spent-applied {
+		t.Errorf("used gas mismatch: have %d, want %d", res.UsedGas, spent-applied)
+	}
+	// Ensure the refund is also reported in the trace result
+	blob, err := logger.GetResult()
+	if err != nil {
+		t.Fatalf("failed to get trace result: %v", err)
+	}
+	var result ExecutionResult
+	if err := json.Unmarshal(blob, &result); err != nil {
+		t.Fatalf("failed to decode trace result: %v", err)
+	}
+	if result.Refund == nil || *result.Refund != (RefundResult{uncapped, applied}) {
+		t.Errorf("trace result refund mismatch: have %+v, want %+v", result.Refund, RefundResult{uncapped, applied})
+	}
+}
+
 // Tests that blank fields don't appear in logs when JSON marshalled, to reduce
 // logs bloat and confusion. See https://github.com/theQRL/go-zond/issues/24487
 func TestStructLogMarshalingOmitEmpty(t *testing.T) {
@@ -18,6 +18,7 @@
 package zondconfig
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/theQRL/go-zond/common"
@@ -46,7 +47,9 @@ var FullNodeGPO = gasprice.Config{
 // Defaults contains default settings for use on the Ethereum main net.
 var Defaults = Config{
 	SyncMode:           downloader.SnapSync,
-	NetworkId:          1,
+	NetworkId:          params.MainnetNetworkID,
+	DialFailures:       3,
+	DialCooldown:       10 * time.Minute,
 	TransactionHistory: 2350000,
 	StateHistory:       params.FullImmutabilityThreshold,
 	StateScheme:        rawdb.HashScheme,
@@ -62,6 +65,7 @@ var Defaults = Config{
 	RPCEVMTimeout:      5 * time.Second,
 	GPO:                FullNodeGPO,
 	RPCTxFeeCap:        1, // 1 ether
+	RPCMaxTxSize:       128 * 1024,
 }
 
 //go:generate go run github.com/fjl/gencodec -type Config -formats toml -out gen_config.go
@@ -76,38 +80,69 @@ type Config struct {
 	NetworkId uint64 // Network ID to use for selecting peers to connect to
 	SyncMode  downloader.SyncMode
 
+	// SnapRangeSize is the maximum number of bytes requested from a peer in a
+	// single snap sync account range request (0 = protocol default).
+	SnapRangeSize uint64 `toml:",omitempty"`
+
 	// This can be set to list of enrtree:// URLs which will be queried for
 	// for nodes to connect to.
 	ZondDiscoveryURLs []string
 	SnapDiscoveryURLs []string
 
+	// Discovered dial candidates failing to connect DialFailures times in a row
+	// are skipped until DialCooldown has passed. Zero disables skipping.
+	DialFailures int
+	DialCooldown time.Duration
+
 	NoPruning  bool // Whether to disable pruning and flush everything to disk
 	NoPrefetch bool // Whether to disable prefetching and only load state on demand
 
 	TransactionHistory uint64 `toml:",omitempty"` // The maximum number of blocks from head whose tx indices are reserved.
 	StateHistory       uint64 `toml:",omitempty"` // The maximum number of blocks from head whose state histories are reserved.
 	StateScheme        string `toml:",omitempty"` // State scheme used to store zond state and merkle trie nodes on top
+	SenderNonceIndex   bool   `toml:",omitempty"` // Whether to index imported transactions by sender and nonce
 
 	// RequiredBlocks is a set of block number -> hash mappings which must be in the
 	// canonical chain of all remote peers. Setting the option makes gzond verify the
 	// presence of these blocks for every new peer connection.
 	RequiredBlocks map[uint64]common.Hash `toml:"-"`
 
+	// CheckpointNumber and CheckpointHash define a trusted block. Imported headers
+	// proven to be its ancestors skip the consensus header verification.
+	CheckpointNumber uint64
+	CheckpointHash   common.Hash
+
 	// Database options
 	SkipBcVersionCheck bool `toml:"-"`
 	DatabaseHandles    int  `toml:"-"`
 	DatabaseCache      int
 	DatabaseFreezer    string
 
-	TrieCleanCache int
-	TrieDirtyCache int
-	TrieTimeout    time.Duration
-	SnapshotCache  int
-	Preimages      bool
+	// DatabaseFreezerThreshold is the number of recent blocks kept in the live
+	// database before moving them into the freezer (0 = default). Non-zero values
+	// must be at least params.FullImmutabilityThreshold.
+	DatabaseFreezerThreshold uint64
+
+	TrieCleanCache    int
+	TrieDirtyCache    int
+	TrieTimeout       time.Duration
+	TrieBlockInterval uint64 // Number of blocks after which to flush the dirty trie cache (0 = time based only)
+	TriePartialFlush  bool   // Whether to only persist the head state on shutdown (hash scheme)
+	SnapshotCache     int
+	SnapshotVerify    int // Number of snapshot accounts to check against the state trie on startup (0 = disabled)
+	Preimages         bool
 
 	// This is the number of blocks for which logs will be cached in the filter system.
 	FilterLogCacheSize int
 
+	// FilterMaxAddresses is the maximum number of addresses allowed in a single
+	// log filter query (0 = no limit).
+	FilterMaxAddresses int
+
+	// FilterMaxBlockRange is the maximum number of blocks a single log query
+	// may span (0 = no limit).
+	FilterMaxBlockRange uint64
+
 	// Mining options
 	Miner miner.Config
 
@@ -126,15 +161,50 @@ type Config struct {
 	// RPCGasCap is the global gas cap for eth-call variants.
 	RPCGasCap uint64
 
+	// RPCTraceGasCap is the gas cap for debug_traceCall, calls requesting more
+	// are rejected. Zero falls back to capping at RPCGasCap.
+	RPCTraceGasCap uint64
+
 	// RPCEVMTimeout is the global timeout for eth-call.
 	RPCEVMTimeout time.Duration
 
 	// RPCTxFeeCap is the global transaction fee(price * gaslimit) cap for
 	// send-transaction variants. The unit is ether.
 	RPCTxFeeCap float64
+
+	// RPCMaxTxSize is the size limit in bytes for raw transactions submitted
+	// via send-raw-transaction. Zero disables the limit.
+	RPCMaxTxSize uint64
+
+	// TxPoolMaxFutureNonce is the maximum gap between the nonce of a raw
+	// transaction submitted via send-raw-transaction and the next nonce of
+	// its sender. Zero disables the limit.
+	TxPoolMaxFutureNonce uint64
+
+	// ConsensusEngine selects the consensus engine of the node, BeaconEngine
+	// if empty. The faker engines are only meant for in-process test nodes, so
+	// the field can't be set from a TOML config file.
+	ConsensusEngine string `toml:"-"`
 }
 
-// CreateConsensusEngine creates a consensus engine for the given chain config.
-func CreateConsensusEngine(config *params.ChainConfig, db zonddb.Database) (consensus.Engine, error) {
-	return beacon.New(), nil
+// Consensus engines selectable via Config.ConsensusEngine.
+const (
+	BeaconEngine    = "beacon"    // Proof-of-stake beacon engine
+	FakerEngine     = "faker"     // Beacon engine for testing
+	FullFakerEngine = "fullfaker" // Beacon engine accepting all headers as valid
+)
+
+// CreateConsensusEngine creates the named consensus engine for the given chain
+// config, defaulting to BeaconEngine if no name is given.
+func CreateConsensusEngine(engine string, config *params.ChainConfig, db zonddb.Database) (consensus.Engine, error) {
+	switch engine {
+	case "", BeaconEngine:
+		return beacon.New(), nil
+	case FakerEngine:
+		return beacon.NewFaker(), nil
+	case FullFakerEngine:
+		return beacon.NewFullFaker(), nil
+	default:
+		return nil, fmt.Errorf("unknown consensus engine %q", engine)
+	}
 }
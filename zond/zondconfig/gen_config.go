@@ -19,64 +19,96 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		Genesis                 *core.Genesis `toml:",omitempty"`
 		NetworkId               uint64
 		SyncMode                downloader.SyncMode
+		SnapRangeSize           uint64
 		ZondDiscoveryURLs        []string
 		SnapDiscoveryURLs       []string
+		DialFailures            int
+		DialCooldown            time.Duration
 		NoPruning               bool
 		NoPrefetch              bool
 		TxLookupLimit           uint64                 `toml:",omitempty"`
 		TransactionHistory      uint64                 `toml:",omitempty"`
 		StateHistory            uint64                 `toml:",omitempty"`
 		StateScheme             string                 `toml:",omitempty"`
+		SenderNonceIndex        bool                   `toml:",omitempty"`
 		RequiredBlocks          map[uint64]common.Hash `toml:"-"`
+		CheckpointNumber        uint64
+		CheckpointHash          common.Hash
 		SkipBcVersionCheck      bool                   `toml:"-"`
 		DatabaseHandles         int                    `toml:"-"`
 		DatabaseCache           int
 		DatabaseFreezer         string
+		DatabaseFreezerThreshold uint64
 		TrieCleanCache          int
 		TrieDirtyCache          int
 		TrieTimeout             time.Duration
+		TrieBlockInterval       uint64
+		TriePartialFlush        bool
 		SnapshotCache           int
+		SnapshotVerify          int
 		Preimages               bool
 		FilterLogCacheSize      int
+		FilterMaxAddresses      int
+		FilterMaxBlockRange     uint64
 		Miner                   miner.Config
 		TxPool                  legacypool.Config
 		GPO                     gasprice.Config
 		EnablePreimageRecording bool
 		DocRoot                 string `toml:"-"`
 		RPCGasCap               uint64
+		RPCTraceGasCap          uint64
 		RPCEVMTimeout           time.Duration
 		RPCTxFeeCap             float64
+		RPCMaxTxSize            uint64
+		TxPoolMaxFutureNonce    uint64
+		ConsensusEngine         string                 `toml:"-"`
 	}
 	var enc Config
 	enc.Genesis = c.Genesis
 	enc.NetworkId = c.NetworkId
 	enc.SyncMode = c.SyncMode
+	enc.SnapRangeSize = c.SnapRangeSize
 	enc.ZondDiscoveryURLs = c.ZondDiscoveryURLs
 	enc.SnapDiscoveryURLs = c.SnapDiscoveryURLs
+	enc.DialFailures = c.DialFailures
+	enc.DialCooldown = c.DialCooldown
 	enc.NoPruning = c.NoPruning
 	enc.NoPrefetch = c.NoPrefetch
 	enc.TransactionHistory = c.TransactionHistory
 	enc.StateHistory = c.StateHistory
 	enc.StateScheme = c.StateScheme
+	enc.SenderNonceIndex = c.SenderNonceIndex
 	enc.RequiredBlocks = c.RequiredBlocks
+	enc.CheckpointNumber = c.CheckpointNumber
+	enc.CheckpointHash = c.CheckpointHash
 	enc.SkipBcVersionCheck = c.SkipBcVersionCheck
 	enc.DatabaseHandles = c.DatabaseHandles
 	enc.DatabaseCache = c.DatabaseCache
 	enc.DatabaseFreezer = c.DatabaseFreezer
+	enc.DatabaseFreezerThreshold = c.DatabaseFreezerThreshold
 	enc.TrieCleanCache = c.TrieCleanCache
 	enc.TrieDirtyCache = c.TrieDirtyCache
 	enc.TrieTimeout = c.TrieTimeout
+	enc.TrieBlockInterval = c.TrieBlockInterval
+	enc.TriePartialFlush = c.TriePartialFlush
 	enc.SnapshotCache = c.SnapshotCache
+	enc.SnapshotVerify = c.SnapshotVerify
 	enc.Preimages = c.Preimages
 	enc.FilterLogCacheSize = c.FilterLogCacheSize
+	enc.FilterMaxAddresses = c.FilterMaxAddresses
+	enc.FilterMaxBlockRange = c.FilterMaxBlockRange
 	enc.Miner = c.Miner
 	enc.TxPool = c.TxPool
 	enc.GPO = c.GPO
 	enc.EnablePreimageRecording = c.EnablePreimageRecording
 	enc.DocRoot = c.DocRoot
 	enc.RPCGasCap = c.RPCGasCap
+	enc.RPCTraceGasCap = c.RPCTraceGasCap
 	enc.RPCEVMTimeout = c.RPCEVMTimeout
 	enc.RPCTxFeeCap = c.RPCTxFeeCap
+	enc.RPCMaxTxSize = c.RPCMaxTxSize
+	enc.TxPoolMaxFutureNonce = c.TxPoolMaxFutureNonce
+	enc.ConsensusEngine = c.ConsensusEngine
 	return &enc, nil
 }
 
@@ -86,33 +118,49 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		Genesis                 *core.Genesis `toml:",omitempty"`
 		NetworkId               *uint64
 		SyncMode                *downloader.SyncMode
+		SnapRangeSize           *uint64
 		ZondDiscoveryURLs        []string
 		SnapDiscoveryURLs       []string
+		DialFailures            *int
+		DialCooldown            *time.Duration
 		NoPruning               *bool
 		NoPrefetch              *bool
 		TxLookupLimit           *uint64                `toml:",omitempty"`
 		TransactionHistory      *uint64                `toml:",omitempty"`
 		StateHistory            *uint64                `toml:",omitempty"`
 		StateScheme             *string                `toml:",omitempty"`
+		SenderNonceIndex        *bool                  `toml:",omitempty"`
 		RequiredBlocks          map[uint64]common.Hash `toml:"-"`
+		CheckpointNumber        *uint64
+		CheckpointHash          *common.Hash
 		SkipBcVersionCheck      *bool                  `toml:"-"`
 		DatabaseHandles         *int                   `toml:"-"`
 		DatabaseCache           *int
 		DatabaseFreezer         *string
+		DatabaseFreezerThreshold *uint64
 		TrieCleanCache          *int
 		TrieDirtyCache          *int
 		TrieTimeout             *time.Duration
+		TrieBlockInterval       *uint64
+		TriePartialFlush        *bool
 		SnapshotCache           *int
+		SnapshotVerify          *int
 		Preimages               *bool
 		FilterLogCacheSize      *int
+		FilterMaxAddresses      *int
+		FilterMaxBlockRange     *uint64
 		Miner                   *miner.Config
 		TxPool                  *legacypool.Config
 		GPO                     *gasprice.Config
 		EnablePreimageRecording *bool
 		DocRoot                 *string `toml:"-"`
 		RPCGasCap               *uint64
+		RPCTraceGasCap          *uint64
 		RPCEVMTimeout           *time.Duration
 		RPCTxFeeCap             *float64
+		RPCMaxTxSize            *uint64
+		TxPoolMaxFutureNonce    *uint64
+		ConsensusEngine         *string                `toml:"-"`
 	}
 	var dec Config
 	if err := unmarshal(&dec); err != nil {
@@ -127,12 +175,21 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.SyncMode != nil {
 		c.SyncMode = *dec.SyncMode
 	}
+	if dec.SnapRangeSize != nil {
+		c.SnapRangeSize = *dec.SnapRangeSize
+	}
 	if dec.ZondDiscoveryURLs != nil {
 		c.ZondDiscoveryURLs = dec.ZondDiscoveryURLs
 	}
 	if dec.SnapDiscoveryURLs != nil {
 		c.SnapDiscoveryURLs = dec.SnapDiscoveryURLs
 	}
+	if dec.DialFailures != nil {
+		c.DialFailures = *dec.DialFailures
+	}
+	if dec.DialCooldown != nil {
+		c.DialCooldown = *dec.DialCooldown
+	}
 	if dec.NoPruning != nil {
 		c.NoPruning = *dec.NoPruning
 	}
@@ -148,9 +205,18 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.StateScheme != nil {
 		c.StateScheme = *dec.StateScheme
 	}
+	if dec.SenderNonceIndex != nil {
+		c.SenderNonceIndex = *dec.SenderNonceIndex
+	}
 	if dec.RequiredBlocks != nil {
 		c.RequiredBlocks = dec.RequiredBlocks
 	}
+	if dec.CheckpointNumber != nil {
+		c.CheckpointNumber = *dec.CheckpointNumber
+	}
+	if dec.CheckpointHash != nil {
+		c.CheckpointHash = *dec.CheckpointHash
+	}
 	if dec.SkipBcVersionCheck != nil {
 		c.SkipBcVersionCheck = *dec.SkipBcVersionCheck
 	}
@@ -163,6 +229,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.DatabaseFreezer != nil {
 		c.DatabaseFreezer = *dec.DatabaseFreezer
 	}
+	if dec.DatabaseFreezerThreshold != nil {
+		c.DatabaseFreezerThreshold = *dec.DatabaseFreezerThreshold
+	}
 	if dec.TrieCleanCache != nil {
 		c.TrieCleanCache = *dec.TrieCleanCache
 	}
@@ -172,15 +241,30 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.TrieTimeout != nil {
 		c.TrieTimeout = *dec.TrieTimeout
 	}
+	if dec.TrieBlockInterval != nil {
+		c.TrieBlockInterval = *dec.TrieBlockInterval
+	}
+	if dec.TriePartialFlush != nil {
+		c.TriePartialFlush = *dec.TriePartialFlush
+	}
 	if dec.SnapshotCache != nil {
 		c.SnapshotCache = *dec.SnapshotCache
 	}
+	if dec.SnapshotVerify != nil {
+		c.SnapshotVerify = *dec.SnapshotVerify
+	}
 	if dec.Preimages != nil {
 		c.Preimages = *dec.Preimages
 	}
 	if dec.FilterLogCacheSize != nil {
 		c.FilterLogCacheSize = *dec.FilterLogCacheSize
 	}
+	if dec.FilterMaxAddresses != nil {
+		c.FilterMaxAddresses = *dec.FilterMaxAddresses
+	}
+	if dec.FilterMaxBlockRange != nil {
+		c.FilterMaxBlockRange = *dec.FilterMaxBlockRange
+	}
 	if dec.Miner != nil {
 		c.Miner = *dec.Miner
 	}
@@ -199,11 +283,23 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.RPCGasCap != nil {
 		c.RPCGasCap = *dec.RPCGasCap
 	}
+	if dec.RPCTraceGasCap != nil {
+		c.RPCTraceGasCap = *dec.RPCTraceGasCap
+	}
 	if dec.RPCEVMTimeout != nil {
 		c.RPCEVMTimeout = *dec.RPCEVMTimeout
 	}
 	if dec.RPCTxFeeCap != nil {
 		c.RPCTxFeeCap = *dec.RPCTxFeeCap
 	}
+	if dec.RPCMaxTxSize != nil {
+		c.RPCMaxTxSize = *dec.RPCMaxTxSize
+	}
+	if dec.TxPoolMaxFutureNonce != nil {
+		c.TxPoolMaxFutureNonce = *dec.TxPoolMaxFutureNonce
+	}
+	if dec.ConsensusEngine != nil {
+		c.ConsensusEngine = *dec.ConsensusEngine
+	}
 	return nil
 }
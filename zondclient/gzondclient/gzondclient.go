@@ -30,6 +30,7 @@ import (
 	"github.com/theQRL/go-zond/common/hexutil"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/p2p"
+	"github.com/theQRL/go-zond/rlp"
 	"github.com/theQRL/go-zond/rpc"
 )
 
@@ -166,6 +167,142 @@ func (ec *Client) CallContractWithBlockOverrides(ctx context.Context, msg zond.C
 	return hex, err
 }
 
+// CanonicalHash returns the hash of the canonical block at the given height.
+// The block number can be nil, in which case the hash of the latest known block
+// is returned.
+func (ec *Client) CanonicalHash(ctx context.Context, number *big.Int) (common.Hash, error) {
+	var hash *common.Hash
+	if err := ec.c.CallContext(ctx, &hash, "zond_getCanonicalHash", toBlockNumArg(number)); err != nil {
+		return common.Hash{}, err
+	}
+	if hash == nil {
+		return common.Hash{}, zond.NotFound
+	}
+	return *hash, nil
+}
+
+// IsCanonical reports whether the block with the given hash is part of the
+// canonical chain. If the block is unknown to the node, zond.NotFound is returned.
+func (ec *Client) IsCanonical(ctx context.Context, hash common.Hash) (bool, error) {
+	var canonical *bool
+	if err := ec.c.CallContext(ctx, &canonical, "zond_isCanonical", hash); err != nil {
+		return false, err
+	}
+	if canonical == nil {
+		return false, zond.NotFound
+	}
+	return *canonical, nil
+}
+
+// EarliestBlock returns the number of the lowest block for which the node still
+// has the full block data available.
+func (ec *Client) EarliestBlock(ctx context.Context) (uint64, error) {
+	var number hexutil.Uint64
+	err := ec.c.CallContext(ctx, &number, "zond_earliestBlock")
+	return uint64(number), err
+}
+
+// HeadersByRange returns up to count consecutive canonical headers starting at
+// the given block number. The server rejects requests above its range limit.
+func (ec *Client) HeadersByRange(ctx context.Context, start uint64, count uint64) ([]*types.Header, error) {
+	var headers []*types.Header
+	err := ec.c.CallContext(ctx, &headers, "zond_getHeadersByRange", hexutil.Uint64(start), hexutil.Uint64(count))
+	return headers, err
+}
+
+// BlocksByRange returns up to count consecutive canonical blocks starting at the
+// given block number. The blocks are retrieved RLP encoded and decoded locally.
+// The server rejects requests above its range limit.
+func (ec *Client) BlocksByRange(ctx context.Context, start uint64, count uint64) ([]*types.Block, error) {
+	var blobs []hexutil.Bytes
+	if err := ec.c.CallContext(ctx, &blobs, "zond_getRawBlocksByRange", hexutil.Uint64(start), hexutil.Uint64(count)); err != nil {
+		return nil, err
+	}
+	blocks := make([]*types.Block, len(blobs))
+	for i, blob := range blobs {
+		block := new(types.Block)
+		if err := rlp.DecodeBytes(blob, block); err != nil {
+			return nil, fmt.Errorf("invalid block %d: %w", start+uint64(i), err)
+		}
+		blocks[i] = block
+	}
+	return blocks, nil
+}
+
+// CodeSizeAt returns the size of the contract code of the given account, without
+// retrieving the code itself. The block number can be nil, in which case the
+// code size is taken from the latest known block.
+func (ec *Client) CodeSizeAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	var size hexutil.Uint64
+	err := ec.c.CallContext(ctx, &size, "zond_getCodeSize", account, toBlockNumArg(blockNumber))
+	return uint64(size), err
+}
+
+// ContractLogs returns all logs emitted by the given contract from the given
+// block number up to the chain head. The logs are retrieved in pages of at least
+// pageSize logs each. A zero page size uses the server side page limit.
+func (ec *Client) ContractLogs(ctx context.Context, contract common.Address, fromBlock uint64, pageSize uint64) ([]types.Log, error) {
+	var (
+		logs []types.Log
+		next = hexutil.Uint64(fromBlock)
+	)
+	for {
+		var page struct {
+			Logs []types.Log     `json:"logs"`
+			Next *hexutil.Uint64 `json:"next"`
+		}
+		var limit *hexutil.Uint64
+		if pageSize > 0 {
+			limit = (*hexutil.Uint64)(&pageSize)
+		}
+		if err := ec.c.CallContext(ctx, &page, "zond_getContractLogs", contract, next, limit); err != nil {
+			return nil, err
+		}
+		logs = append(logs, page.Logs...)
+		if page.Next == nil {
+			return logs, nil
+		}
+		next = *page.Next
+	}
+}
+
+// RawReceipt returns the binary-encoded consensus receipt of the transaction
+// with the given hash, suitable for building receipt proofs.
+func (ec *Client) RawReceipt(ctx context.Context, txHash common.Hash) ([]byte, error) {
+	var raw hexutil.Bytes
+	if err := ec.c.CallContext(ctx, &raw, "zond_getRawReceipt", txHash); err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, zond.NotFound
+	}
+	return raw, nil
+}
+
+// PendingBaseFee returns the base fee of the pending block the node's miner is
+// assembling, i.e. the base fee transactions in the next block will pay.
+func (ec *Client) PendingBaseFee(ctx context.Context) (*big.Int, error) {
+	var fee hexutil.Big
+	if err := ec.c.CallContext(ctx, &fee, "zond_pendingBaseFee"); err != nil {
+		return nil, err
+	}
+	return (*big.Int)(&fee), nil
+}
+
+// StateRoot returns the state root of the block with the given number. The block
+// number can be nil, in which case the state root of the latest known block is
+// returned.
+func (ec *Client) StateRoot(ctx context.Context, number *big.Int) (common.Hash, error) {
+	var root *common.Hash
+	if err := ec.c.CallContext(ctx, &root, "zond_getStateRoot", toBlockNumArg(number)); err != nil {
+		return common.Hash{}, err
+	}
+	if root == nil {
+		return common.Hash{}, zond.NotFound
+	}
+	return *root, nil
+}
+
 // GCStats retrieves the current garbage collection stats from a gzond node.
 func (ec *Client) GCStats(ctx context.Context) (*debug.GCStats, error) {
 	var result debug.GCStats
@@ -22,12 +22,15 @@ import (
 	"encoding/json"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/theQRL/go-zond"
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/consensus/beacon"
 	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/rawdb"
 	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/core/vm"
 	"github.com/theQRL/go-zond/crypto"
 	"github.com/theQRL/go-zond/crypto/pqcrypto"
 	"github.com/theQRL/go-zond/node"
@@ -45,11 +48,26 @@ var (
 	testSlot    = common.HexToHash("0xdeadbeef")
 	testValue   = crypto.Keccak256Hash(testSlot[:])
 	testBalance = big.NewInt(2e15)
+
+	testContract = common.Address{0xc0, 0xde}
+	testCode     = common.FromHex("0x6080604052348015600f57600080fd5b50")
 )
 
 func newTestBackend(t *testing.T) (*node.Node, []*types.Block) {
 	// Generate test chain.
 	genesis, blocks := generateTestChain()
+	n, zondservice := newTestNode(t, genesis)
+
+	// Import the test chain.
+	if _, err := zondservice.BlockChain().InsertChain(blocks[1:]); err != nil {
+		t.Fatalf("can't import test blocks: %v", err)
+	}
+	return n, blocks
+}
+
+// newTestNode creates and starts a node running a zond service on top of the
+// given genesis.
+func newTestNode(t *testing.T, genesis *core.Genesis) (*node.Node, *zondsvc.Zond) {
 	// Create node
 	n, err := node.New(&node.Config{})
 	if err != nil {
@@ -66,21 +84,19 @@ func newTestBackend(t *testing.T) (*node.Node, []*types.Block) {
 		Namespace: "zond",
 		Service:   filters.NewFilterAPI(filterSystem),
 	}})
-
-	// Import the test chain.
 	if err := n.Start(); err != nil {
 		t.Fatalf("can't start test node: %v", err)
 	}
-	if _, err := zondservice.BlockChain().InsertChain(blocks[1:]); err != nil {
-		t.Fatalf("can't import test blocks: %v", err)
-	}
-	return n, blocks
+	return n, zondservice
 }
 
 func generateTestChain() (*core.Genesis, []*types.Block) {
 	genesis := &core.Genesis{
-		Config:    params.AllBeaconProtocolChanges,
-		Alloc:     core.GenesisAlloc{testAddr: {Balance: testBalance, Storage: map[common.Hash]common.Hash{testSlot: testValue}}},
+		Config: params.AllBeaconProtocolChanges,
+		Alloc: core.GenesisAlloc{
+			testAddr:     {Balance: testBalance, Storage: map[common.Hash]common.Hash{testSlot: testValue}},
+			testContract: {Balance: common.Big0, Code: testCode},
+		},
 		ExtraData: []byte("test genesis"),
 		Timestamp: 9000,
 	}
@@ -94,7 +110,7 @@ func generateTestChain() (*core.Genesis, []*types.Block) {
 }
 
 func TestGzondClient(t *testing.T) {
-	backend, _ := newTestBackend(t)
+	backend, blocks := newTestBackend(t)
 	client := backend.Attach()
 	defer backend.Close()
 	defer client.Close()
@@ -109,6 +125,21 @@ func TestGzondClient(t *testing.T) {
 		}, {
 			"TestGetProofCanonicalizeKeys",
 			func(t *testing.T) { testGetProofCanonicalizeKeys(t, client) },
+		}, {
+			"TestCanonicalHash",
+			func(t *testing.T) { testCanonicalHash(t, client) },
+		}, {
+			"TestStateRoot",
+			func(t *testing.T) { testStateRoot(t, client) },
+		}, {
+			"TestCodeSizeAt",
+			func(t *testing.T) { testCodeSizeAt(t, client) },
+		}, {
+			"TestHeadersByRange",
+			func(t *testing.T) { testHeadersByRange(t, client) },
+		}, {
+			"TestBlocksByRange",
+			func(t *testing.T) { testBlocksByRange(t, client, blocks) },
 		}, {
 			"TestGCStats",
 			func(t *testing.T) { testGCStats(t, client) },
@@ -270,6 +301,155 @@ func testGetProofCanonicalizeKeys(t *testing.T, client *rpc.Client) {
 	}
 }
 
+func testCanonicalHash(t *testing.T, client *rpc.Client) {
+	ec := New(client)
+	zondcl := zondclient.NewClient(client)
+	for _, number := range []*big.Int{big.NewInt(0), big.NewInt(1), nil} {
+		hash, err := ec.CanonicalHash(context.Background(), number)
+		if err != nil {
+			t.Fatalf("block %v: %v", number, err)
+		}
+		header, err := zondcl.HeaderByNumber(context.Background(), number)
+		if err != nil {
+			t.Fatalf("block %v: %v", number, err)
+		}
+		if hash != header.Hash() {
+			t.Fatalf("block %v: hash mismatch, want: %v got: %v", number, header.Hash(), hash)
+		}
+	}
+	if _, err := ec.CanonicalHash(context.Background(), big.NewInt(100)); err != zond.NotFound {
+		t.Fatalf("unexpected error for missing block, want: %v got: %v", zond.NotFound, err)
+	}
+}
+
+func TestIsCanonical(t *testing.T) {
+	genesis, blocks := generateTestChain()
+	n, zondservice := newTestNode(t, genesis)
+	defer n.Close()
+
+	if _, err := zondservice.BlockChain().InsertChain(blocks[1:]); err != nil {
+		t.Fatalf("can't import test blocks: %v", err)
+	}
+	// Create a competing block on top of genesis and import it as a side block
+	_, sideBlocks, _ := core.GenerateChainWithGenesis(genesis, beacon.NewFaker(), 1, func(i int, g *core.BlockGen) {
+		g.OffsetTime(7)
+		g.SetExtra([]byte("side"))
+	})
+	if err := zondservice.BlockChain().InsertBlockWithoutSetHead(sideBlocks[0]); err != nil {
+		t.Fatalf("can't import side block: %v", err)
+	}
+	client := n.Attach()
+	defer client.Close()
+	ec := New(client)
+
+	for i, tt := range []struct {
+		hash common.Hash
+		want bool
+	}{
+		{blocks[1].Hash(), true},
+		{sideBlocks[0].Hash(), false},
+	} {
+		canonical, err := ec.IsCanonical(context.Background(), tt.hash)
+		if err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		if canonical != tt.want {
+			t.Errorf("test %d: canonical mismatch, want: %v got: %v", i, tt.want, canonical)
+		}
+	}
+	if _, err := ec.IsCanonical(context.Background(), common.Hash{0xff}); err != zond.NotFound {
+		t.Fatalf("unexpected error for unknown block, want: %v got: %v", zond.NotFound, err)
+	}
+}
+
+func testStateRoot(t *testing.T, client *rpc.Client) {
+	ec := New(client)
+	zondcl := zondclient.NewClient(client)
+	for _, number := range []*big.Int{big.NewInt(0), big.NewInt(1), nil} {
+		root, err := ec.StateRoot(context.Background(), number)
+		if err != nil {
+			t.Fatalf("block %v: %v", number, err)
+		}
+		header, err := zondcl.HeaderByNumber(context.Background(), number)
+		if err != nil {
+			t.Fatalf("block %v: %v", number, err)
+		}
+		if root != header.Root {
+			t.Fatalf("block %v: state root mismatch, want: %v got: %v", number, header.Root, root)
+		}
+	}
+	if _, err := ec.StateRoot(context.Background(), big.NewInt(100)); err != zond.NotFound {
+		t.Fatalf("unexpected error for missing block, want: %v got: %v", zond.NotFound, err)
+	}
+}
+
+func testCodeSizeAt(t *testing.T, client *rpc.Client) {
+	ec := New(client)
+	zondcl := zondclient.NewClient(client)
+	for _, account := range []common.Address{testContract, testAddr} {
+		size, err := ec.CodeSizeAt(context.Background(), account, nil)
+		if err != nil {
+			t.Fatalf("account %v: %v", account, err)
+		}
+		code, err := zondcl.CodeAt(context.Background(), account, nil)
+		if err != nil {
+			t.Fatalf("account %v: %v", account, err)
+		}
+		if size != uint64(len(code)) {
+			t.Fatalf("account %v: code size mismatch, want: %d got: %d", account, len(code), size)
+		}
+	}
+	if size, _ := ec.CodeSizeAt(context.Background(), testContract, nil); size != uint64(len(testCode)) {
+		t.Fatalf("contract code size mismatch, want: %d got: %d", len(testCode), size)
+	}
+}
+
+func testHeadersByRange(t *testing.T, client *rpc.Client) {
+	ec := New(client)
+	zondcl := zondclient.NewClient(client)
+	head, err := zondcl.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	headers, err := ec.HeadersByRange(context.Background(), 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := uint64(len(headers)), head.Number.Uint64()+1; have != want {
+		t.Fatalf("header count mismatch, want: %d got: %d", want, have)
+	}
+	for i, header := range headers {
+		if header.Number.Uint64() != uint64(i) {
+			t.Fatalf("header %d: number mismatch, got: %v", i, header.Number)
+		}
+		if i > 0 && header.ParentHash != headers[i-1].Hash() {
+			t.Fatalf("header %d: parent hash mismatch, want: %v got: %v", i, headers[i-1].Hash(), header.ParentHash)
+		}
+	}
+	if _, err := ec.HeadersByRange(context.Background(), 0, 1025); err == nil {
+		t.Fatal("expected error for range above the server limit")
+	}
+}
+
+func testBlocksByRange(t *testing.T, client *rpc.Client, chain []*types.Block) {
+	ec := New(client)
+	blocks, err := ec.BlocksByRange(context.Background(), 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != len(chain) {
+		t.Fatalf("block count mismatch, want: %d got: %d", len(chain), len(blocks))
+	}
+	for i, block := range blocks {
+		if block.Hash() != chain[i].Hash() {
+			t.Fatalf("block %d: hash mismatch, want: %v got: %v", i, chain[i].Hash(), block.Hash())
+		}
+	}
+	if _, err := ec.BlocksByRange(context.Background(), 0, 257); err == nil {
+		t.Fatal("expected error for range above the server limit")
+	}
+}
+
 func testGCStats(t *testing.T, client *rpc.Client) {
 	ec := New(client)
 	_, err := ec.GCStats(context.Background())
@@ -524,3 +704,235 @@ func testCallContractWithBlockOverrides(t *testing.T, client *rpc.Client) {
 		t.Fatalf("unexpected result: %x", res)
 	}
 }
+
+func TestPendingBaseFee(t *testing.T) {
+	genesis, blocks := generateTestChain()
+	backend, zondservice := newTestNode(t, genesis)
+	client := backend.Attach()
+	defer backend.Close()
+	defer client.Close()
+
+	// The miner assembles the pending block in the background, wait for it.
+	var (
+		ec  = New(client)
+		fee *big.Int
+		err error
+	)
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(50 * time.Millisecond) {
+		if fee, err = ec.PendingBaseFee(context.Background()); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("pending base fee not available: %v", err)
+		}
+	}
+	// Import the next block and check it pays the reported base fee.
+	if _, err := zondservice.BlockChain().InsertChain(blocks[1:]); err != nil {
+		t.Fatalf("can't import test blocks: %v", err)
+	}
+	head, err := zondclient.NewClient(client).HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("can't retrieve head: %v", err)
+	}
+	if head.Number.Uint64() != 1 {
+		t.Fatalf("unexpected head number: %v", head.Number)
+	}
+	if fee.Cmp(head.BaseFee) != 0 {
+		t.Fatalf("pending base fee mismatch: have %v, want %v", fee, head.BaseFee)
+	}
+}
+
+func TestContractLogs(t *testing.T) {
+	var (
+		// Emits an empty LOG0 whenever called
+		logContract   = common.Address{0x10, 0x9}
+		logCode       = []byte{byte(vm.PUSH1), 0x0, byte(vm.PUSH1), 0x0, byte(vm.LOG0), byte(vm.STOP)}
+		otherContract = common.Address{0x20, 0x9}
+	)
+	genesis := &core.Genesis{
+		Config: params.AllBeaconProtocolChanges,
+		Alloc: core.GenesisAlloc{
+			testAddr:      {Balance: testBalance},
+			logContract:   {Balance: common.Big0, Code: logCode},
+			otherContract: {Balance: common.Big0, Code: logCode},
+		},
+		Timestamp: 9000,
+	}
+	signer := types.LatestSigner(genesis.Config)
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, beacon.NewFaker(), 4, func(i int, g *core.BlockGen) {
+		g.OffsetTime(5)
+		for _, to := range []common.Address{logContract, otherContract, logContract} {
+			tx, err := types.SignNewTx(testKey, signer, &types.DynamicFeeTx{
+				Nonce:     g.TxNonce(testAddr),
+				To:        &to,
+				Gas:       50000,
+				GasFeeCap: g.BaseFee(),
+			})
+			if err != nil {
+				t.Fatalf("failed to sign tx: %v", err)
+			}
+			g.AddTx(tx)
+		}
+	})
+	backend, zondservice := newTestNode(t, genesis)
+	client := backend.Attach()
+	defer backend.Close()
+	defer client.Close()
+
+	if _, err := zondservice.BlockChain().InsertChain(blocks); err != nil {
+		t.Fatalf("can't import test blocks: %v", err)
+	}
+	ec := New(client)
+	for _, tt := range []struct {
+		from     uint64
+		pageSize uint64
+		want     int
+	}{
+		{from: 0, pageSize: 0, want: 8},
+		{from: 0, pageSize: 1, want: 8},
+		{from: 0, pageSize: 3, want: 8},
+		{from: 3, pageSize: 1, want: 4},
+		{from: 5, pageSize: 1, want: 0},
+	} {
+		logs, err := ec.ContractLogs(context.Background(), logContract, tt.from, tt.pageSize)
+		if err != nil {
+			t.Fatalf("from %d, page size %d: %v", tt.from, tt.pageSize, err)
+		}
+		if len(logs) != tt.want {
+			t.Fatalf("from %d, page size %d: log count mismatch, want: %d got: %d", tt.from, tt.pageSize, tt.want, len(logs))
+		}
+		for i, log := range logs {
+			if log.Address != logContract {
+				t.Fatalf("log %d: address mismatch, want: %v got: %v", i, logContract, log.Address)
+			}
+			if log.BlockNumber < tt.from {
+				t.Fatalf("log %d: block %d before start block %d", i, log.BlockNumber, tt.from)
+			}
+			if i > 0 && (log.BlockNumber < logs[i-1].BlockNumber || (log.BlockNumber == logs[i-1].BlockNumber && log.Index <= logs[i-1].Index)) {
+				t.Fatalf("log %d: out of order or duplicate", i)
+			}
+		}
+	}
+}
+
+func TestRawReceipt(t *testing.T) {
+	var (
+		// Emits an empty LOG0 whenever called
+		logContract = common.Address{0x10, 0x9}
+		logCode     = []byte{byte(vm.PUSH1), 0x0, byte(vm.PUSH1), 0x0, byte(vm.LOG0), byte(vm.STOP)}
+	)
+	genesis := &core.Genesis{
+		Config: params.AllBeaconProtocolChanges,
+		Alloc: core.GenesisAlloc{
+			testAddr:    {Balance: testBalance},
+			logContract: {Balance: common.Big0, Code: logCode},
+		},
+		Timestamp: 9000,
+	}
+	var (
+		signer = types.LatestSigner(genesis.Config)
+		txs    []*types.Transaction
+	)
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, beacon.NewFaker(), 1, func(i int, g *core.BlockGen) {
+		g.OffsetTime(5)
+		for j := 0; j < 2; j++ {
+			tx, err := types.SignNewTx(testKey, signer, &types.DynamicFeeTx{
+				Nonce:     g.TxNonce(testAddr),
+				To:        &logContract,
+				Gas:       50000,
+				GasFeeCap: g.BaseFee(),
+			})
+			if err != nil {
+				t.Fatalf("failed to sign tx: %v", err)
+			}
+			g.AddTx(tx)
+			txs = append(txs, tx)
+		}
+	})
+	backend, zondservice := newTestNode(t, genesis)
+	client := backend.Attach()
+	defer backend.Close()
+	defer client.Close()
+
+	if _, err := zondservice.BlockChain().InsertChain(blocks); err != nil {
+		t.Fatalf("can't import test blocks: %v", err)
+	}
+	ec := New(client)
+	for i, tx := range txs {
+		raw, err := ec.RawReceipt(context.Background(), tx.Hash())
+		if err != nil {
+			t.Fatalf("tx %d: failed to retrieve raw receipt: %v", i, err)
+		}
+		have := new(types.Receipt)
+		if err := have.UnmarshalBinary(raw); err != nil {
+			t.Fatalf("tx %d: failed to decode raw receipt: %v", i, err)
+		}
+		want, err := zondclient.NewClient(client).TransactionReceipt(context.Background(), tx.Hash())
+		if err != nil {
+			t.Fatalf("tx %d: failed to retrieve receipt: %v", i, err)
+		}
+		if have.Type != want.Type {
+			t.Errorf("tx %d: type mismatch: have %d, want %d", i, have.Type, want.Type)
+		}
+		if have.Status != want.Status {
+			t.Errorf("tx %d: status mismatch: have %d, want %d", i, have.Status, want.Status)
+		}
+		if have.CumulativeGasUsed != want.CumulativeGasUsed {
+			t.Errorf("tx %d: cumulative gas mismatch: have %d, want %d", i, have.CumulativeGasUsed, want.CumulativeGasUsed)
+		}
+		if have.Bloom != want.Bloom {
+			t.Errorf("tx %d: bloom mismatch", i)
+		}
+		if len(have.Logs) != len(want.Logs) {
+			t.Fatalf("tx %d: log count mismatch: have %d, want %d", i, len(have.Logs), len(want.Logs))
+		}
+		for j := range have.Logs {
+			if have.Logs[j].Address != want.Logs[j].Address {
+				t.Errorf("tx %d, log %d: address mismatch: have %v, want %v", i, j, have.Logs[j].Address, want.Logs[j].Address)
+			}
+		}
+	}
+	if _, err := ec.RawReceipt(context.Background(), common.Hash{0xff}); err != zond.NotFound {
+		t.Fatalf("unexpected error for unknown transaction: %v", err)
+	}
+}
+
+func TestEarliestBlock(t *testing.T) {
+	genesis := &core.Genesis{
+		Config:    params.AllBeaconProtocolChanges,
+		Alloc:     core.GenesisAlloc{testAddr: {Balance: testBalance}},
+		Timestamp: 9000,
+	}
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, beacon.NewFaker(), 8, func(i int, g *core.BlockGen) {
+		g.OffsetTime(5)
+	})
+	backend, zondservice := newTestNode(t, genesis)
+	client := backend.Attach()
+	defer backend.Close()
+	defer client.Close()
+
+	if _, err := zondservice.BlockChain().InsertChain(blocks); err != nil {
+		t.Fatalf("can't import test blocks: %v", err)
+	}
+	ec := New(client)
+	earliest, err := ec.EarliestBlock(context.Background())
+	if err != nil {
+		t.Fatalf("failed to retrieve earliest block: %v", err)
+	}
+	if earliest != 0 {
+		t.Fatalf("earliest block mismatch on full history: have %d, want 0", earliest)
+	}
+	// Drop the bodies of the oldest blocks to simulate a partial history.
+	db := zondservice.ChainDb()
+	rawdb.DeleteBody(db, zondservice.BlockChain().Genesis().Hash(), 0)
+	for _, block := range blocks[:4] {
+		rawdb.DeleteBody(db, block.Hash(), block.NumberU64())
+	}
+	earliest, err = ec.EarliestBlock(context.Background())
+	if err != nil {
+		t.Fatalf("failed to retrieve earliest block: %v", err)
+	}
+	if earliest != 5 {
+		t.Fatalf("earliest block mismatch on partial history: have %d, want 5", earliest)
+	}
+}